@@ -1,10 +1,32 @@
 package main
 
 import (
+	"context"
+	"log/slog"
+	"time"
 	_ "todo-list/docs"
+	"todo-list/internal/blob"
+	"todo-list/internal/buildinfo"
+	"todo-list/internal/config"
+	http2 "todo-list/internal/controller/http"
+	v1 "todo-list/internal/controller/http/v1"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/health"
+	"todo-list/internal/logger"
+	"todo-list/internal/metrics"
+	"todo-list/internal/notifier"
 	"todo-list/internal/repository/postgres"
 	"todo-list/internal/server"
+	"todo-list/internal/service/apikey"
+	"todo-list/internal/service/attachment"
+	"todo-list/internal/service/comment"
+	"todo-list/internal/service/project"
+	"todo-list/internal/service/reminder"
+	"todo-list/internal/service/savedfilter"
+	"todo-list/internal/service/timeentry"
 	"todo-list/internal/service/todo"
+	"todo-list/internal/service/user"
+	"todo-list/internal/tracing"
 )
 
 // @title TodoList API
@@ -12,8 +34,134 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1
 func main() {
+	shutdownTracing, err := tracing.Init(context.Background(), config.Config.TracingConfig)
+	if err != nil {
+		logger.Fatal("tracing init error", slog.Any("error", err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("tracing shutdown error", slog.Any("error", err))
+		}
+	}()
+
+	info := buildinfo.Get()
+	slog.Info("starting", slog.String("version", info.Version), slog.String("commit", info.Commit))
+	metrics.RegisterBuildInfo(info.Version, info.Commit)
+
 	repo := postgres.NewPostgresTodoRepository()
-	s := todo.NewTodoService(repo)
-	srv := server.NewServer(s)
-	_ = srv.Run()
+	metrics.RegisterDB(repo.DB, repo)
+
+	h := health.New(config.Config.ServerConfig.HealthCheckTimeout,
+		health.Check{Name: "database", Probe: repo.Ping},
+		health.Check{Name: "migrations", Probe: repo.CheckMigrations},
+	)
+	if config.Config.TracingConfig.Exporter == "otlp" {
+		h.Add(health.DialCheck("otlp_collector", config.Config.TracingConfig.OTLPEndpoint))
+	}
+
+	s := todo.NewLoggingService(todo.NewMetricsService(todo.NewTracingService(todo.NewTodoService(repo, newTodoTransitions(config.Config.Todos.Transitions)))))
+	reminderRepo := postgres.NewReminderRepository(repo.DB)
+	n := newNotifier(config.Config.Notifier)
+	reminderService := reminder.NewReminderService(reminderRepo, s, n)
+	attachmentRepo := postgres.NewAttachmentRepository(repo.DB)
+	store := newBlobStore(config.Config.Attachments)
+	services := v1.Services{
+		TodoService:     s,
+		APIKeyService:   apikey.NewAPIKeyService(postgres.NewAPIKeyRepository(repo.DB)),
+		UserService:     user.NewUserService(postgres.NewUserRepository(repo.DB)),
+		ProjectService:  project.NewProjectService(postgres.NewProjectRepository(repo.DB)),
+		ReminderService: reminderService,
+		CommentService:  comment.NewCommentService(postgres.NewCommentRepository(repo.DB), s, n),
+		AttachmentService: attachment.NewAttachmentService(attachmentRepo, s, store, attachment.Limits{
+			MaxFileBytes:   config.Config.Attachments.MaxBytes,
+			UserQuotaBytes: config.Config.Attachments.UserQuotaBytes,
+		}),
+		TimeEntryService:   timeentry.NewTimeEntryService(postgres.NewTimeEntryRepository(repo.DB), s),
+		SavedFilterService: savedfilter.NewSavedFilterService(postgres.NewSavedFilterRepository(repo.DB), s),
+	}
+	idempotencyRepo := postgres.NewIdempotencyRepository(repo.DB)
+
+	router := http2.NewHandler(services, h, idempotencyRepo).NewRouter()
+	srv := server.NewServer(router, h, config.Config.ServerConfig.ShutdownDelay)
+	srv.AddWorker(func(ctx context.Context) {
+		idempotencyRepo.RunCleanup(ctx, config.Config.Idempotency.CleanupInterval)
+	})
+	srv.AddWorker(func(ctx context.Context) {
+		repo.RunRebalance(ctx, config.Config.Positions.RebalanceInterval, config.Config.Positions.MaxLength)
+	})
+	srv.AddWorker(func(ctx context.Context) {
+		reminderRepo.RunScheduler(ctx, postgres.SchedulerOptions{
+			Interval:    config.Config.Reminders.PollInterval,
+			BatchSize:   uint64(config.Config.Reminders.BatchSize),
+			MaxAttempts: int(config.Config.Reminders.MaxAttempts),
+			Backoff:     config.Config.Reminders.RetryBackoff,
+			Lease:       config.Config.Reminders.Lease,
+		}, reminderService.Notify)
+	})
+	srv.AddWorker(func(ctx context.Context) {
+		attachmentRepo.RunBlobCleanup(ctx, config.Config.Attachments.CleanupInterval, 100, store.Delete)
+	})
+	if err := srv.Run(); err != nil {
+		slog.Error("server shutdown error", slog.Any("error", err))
+	}
+}
+
+// newNotifier combines the configured notification channels.
+func newNotifier(cfg config.NotifierConfig) notifier.Notifier {
+	res := make(notifier.Multi, 0, len(cfg.Channels))
+	for _, ch := range cfg.Channels {
+		switch ch {
+		case "log":
+			res = append(res, notifier.NewLog())
+		case "smtp":
+			res = append(res, notifier.NewSMTP(notifier.SMTPConfig{
+				Host:     cfg.SMTPHost,
+				Port:     cfg.SMTPPort,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.SMTPFrom,
+			}))
+		case "webhook":
+			res = append(res, notifier.NewWebhook(cfg.WebhookURL, cfg.WebhookSecret, cfg.WebhookTimeout))
+		}
+	}
+
+	return res
+}
+
+// newTodoTransitions parses the configured todo status workflow, the default one is used
+// when none is configured.
+func newTodoTransitions(spec string) model.TodoTransitions {
+	if spec == "" {
+		return model.DefaultTodoTransitions()
+	}
+
+	res, err := model.ParseTodoTransitions(spec)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", "TODO_STATUS_TRANSITIONS"), slog.Any("error", err))
+	}
+	return res
+}
+
+// newBlobStore opens the configured attachment storage.
+func newBlobStore(cfg config.AttachmentsConfig) blob.Store {
+	if cfg.Storage == "s3" {
+		return blob.NewS3(blob.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+			Timeout:         cfg.S3Timeout,
+		})
+	}
+
+	store, err := blob.NewLocal(cfg.Dir)
+	if err != nil {
+		logger.Fatal("attachment storage init error", slog.Any("error", err))
+	}
+	return store
 }
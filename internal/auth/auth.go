@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"todo-list/internal/domain/model"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated caller.
+func WithPrincipal(ctx context.Context, p model.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated caller of the request.
+func PrincipalFromContext(ctx context.Context) (model.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(model.Principal)
+	return p, ok
+}
+
+// anonymousMember is the user anonymous callers are restricted to. No user has the id,
+// so they are members of no project and reach only todos outside of projects.
+const anonymousMember = -1
+
+// Member returns the user whose project memberships limit the access of the caller.
+// ok is false for callers with unrestricted access: admins, keys not bound to a user
+// (service-to-service automation) and calls made outside of a request. Anonymous
+// callers are restricted to a user who is a member of no project.
+func Member(ctx context.Context) (userID int64, ok bool) {
+	p, found := PrincipalFromContext(ctx)
+	switch {
+	case !found:
+		return 0, false
+	case p.Anonymous:
+		return anonymousMember, true
+	case p.UserID == 0 || p.Can(model.ScopeAdmin):
+		return 0, false
+	}
+	return p.UserID, true
+}
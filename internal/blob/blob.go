@@ -0,0 +1,39 @@
+// Package blob keeps attachment contents in pluggable storages.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs by key. Put reads r to the end, size is -1 when it is not known upfront.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob, deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// validKey accepts slash separated segments of latin letters, digits, dots, dashes and
+// underscores, so keys map to file paths and URLs without escaping surprises.
+func validKey(key string) error {
+	if key == "" {
+		return errors.New("empty blob key")
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+		for _, r := range segment {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') {
+				return fmt.Errorf("invalid blob key %q", key)
+			}
+		}
+	}
+	return nil
+}
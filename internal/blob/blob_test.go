@@ -0,0 +1,154 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// fakeS3 is an in-memory stand-in for an S3 compatible service, it checks request signatures.
+type fakeS3 struct {
+	t       *testing.T
+	signer  *S3
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3(t *testing.T, cfg S3Config) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, signer: NewS3(cfg), objects: map[string][]byte{}, types: map[string]string{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	check := &http.Request{Method: r.Method, URL: r.URL, Header: http.Header{}}
+	check.URL.Host = r.Host
+	f.signer.sign(check, date)
+	if check.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		require.Equal(f.t, r.ContentLength, int64(len(body)))
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+
+	t.Run("put and get", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, "todos/1/a.txt", strings.NewReader("hello"), 5, "text/plain"))
+
+		rc, err := s.Get(ctx, "todos/1/a.txt")
+		require.NoError(t, err)
+		defer rc.Close()
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(body))
+	})
+
+	t.Run("unknown size", func(t *testing.T) {
+		data := bytes.Repeat([]byte("x"), 100_000)
+		require.NoError(t, s.Put(ctx, "todos/1/big", iotest.OneByteReader(bytes.NewReader(data)), -1, ""))
+
+		rc, err := s.Get(ctx, "todos/1/big")
+		require.NoError(t, err)
+		defer rc.Close()
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, data, body)
+	})
+
+	t.Run("failed put keeps no blob", func(t *testing.T) {
+		r := io.MultiReader(strings.NewReader("part"), iotest.ErrReader(io.ErrUnexpectedEOF))
+		require.Error(t, s.Put(ctx, "todos/1/broken", r, -1, ""))
+
+		_, err := s.Get(ctx, "todos/1/broken")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, s.Delete(ctx, "todos/1/a.txt"))
+		_, err := s.Get(ctx, "todos/1/a.txt")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NoError(t, s.Delete(ctx, "todos/1/a.txt"))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		for _, key := range []string{"", "../etc/passwd", "todos//1", "todos/1/a b", "/abs"} {
+			require.Error(t, s.Put(ctx, key, strings.NewReader("x"), 1, ""), key)
+		}
+	})
+}
+
+func TestLocal(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+	testStore(t, s)
+}
+
+func TestS3(t *testing.T) {
+	cfg := S3Config{Region: "us-east-1", Bucket: "todo", AccessKeyID: "AKID", SecretAccessKey: "secret", PathStyle: true, Timeout: time.Second}
+	f, srv := newFakeS3(t, cfg)
+	cfg.Endpoint = srv.URL
+
+	testStore(t, NewS3(cfg))
+
+	t.Run("content type is stored", func(t *testing.T) {
+		require.NoError(t, NewS3(cfg).Put(context.Background(), "todos/2/img", strings.NewReader("png"), 3, "image/png"))
+		require.Equal(t, "image/png", f.types["/todo/todos/2/img"])
+	})
+
+	t.Run("wrong secret is rejected", func(t *testing.T) {
+		bad := cfg
+		bad.SecretAccessKey = "other"
+		err := NewS3(bad).Put(context.Background(), "todos/2/img", strings.NewReader("png"), 3, "")
+		require.ErrorContains(t, err, "SignatureDoesNotMatch")
+	})
+}
+
+func TestS3ObjectURL(t *testing.T) {
+	s := NewS3(S3Config{Endpoint: "https://s3.eu-central-1.amazonaws.com/", Bucket: "todo"})
+	u, err := s.objectURL("todos/1/abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://todo.s3.eu-central-1.amazonaws.com/todos/1/abc", u.String())
+
+	s.cfg.PathStyle = true
+	u, err = s.objectURL("todos/1/abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://s3.eu-central-1.amazonaws.com/todo/todos/1/abc", u.String())
+}
@@ -0,0 +1,49 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Version and Commit are set at build time:
+//
+//	go build -ldflags "-X todo-list/internal/buildinfo.Version=1.2.3 -X todo-list/internal/buildinfo.Commit=abc123"
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+var startTime = time.Now()
+
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	GoVersion string    `json:"go_version"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// Get returns build info of the running binary. When Commit is not set by ldflags
+// the VCS revision embedded by the go toolchain is used.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		StartTime: startTime,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		if info.Commit == "" {
+			for _, s := range bi.Settings {
+				if s.Key == "vcs.revision" {
+					info.Commit = s.Value
+				}
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+
+	return info
+}
@@ -1,24 +1,147 @@
 package config
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"todo-list/internal/logger"
+	"todo-list/internal/timezone"
 )
 
 type ConfigFile struct {
 	AppLevel string
-	DBConfig DBConfig
+	// DefaultTimeZone is the time zone of callers who did not choose one.
+	DefaultTimeZone string
+	DBConfig        DBConfig
+	TracingConfig   TracingConfig
+	ServerConfig    ServerConfig
+	Idempotency     IdempotencyConfig
+	Limits          LimitsConfig
+	Auth            AuthConfig
+	Positions       PositionsConfig
+	Reminders       RemindersConfig
+	Notifier        NotifierConfig
+	Todos           TodosConfig
+	Attachments     AttachmentsConfig
+}
+
+type TodosConfig struct {
+	// Transitions is the graph of allowed todo status changes written as "from:to|to;from:to",
+	// empty for the default workflow.
+	Transitions string
+}
+
+type AuthConfig struct {
+	// Required rejects requests without an API key, otherwise they are served with read and write access.
+	Required bool
+	// BootstrapKey grants admin access to create the first API keys.
+	BootstrapKey string
+}
+
+type LimitsConfig struct {
+	// RateLimitRPS is the token bucket refill rate per client, 0 disables rate limiting.
+	RateLimitRPS   float64
+	RateLimitBurst int
+	// IPRateLimitRPS limits requests per client IP before credentials are checked, 0 disables it.
+	IPRateLimitRPS   float64
+	IPRateLimitBurst int
+	MaxBodyBytes     int64
+	MaxPageSize      int64
+	// TrustedProxies may set X-Forwarded-For, the client IP is taken from the connection otherwise.
+	TrustedProxies []string
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long a stored response is replayed for the same Idempotency-Key.
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
+
+type PositionsConfig struct {
+	// MaxLength of a todo rank, longer ranks are rebalanced by a background worker.
+	MaxLength         int64
+	RebalanceInterval time.Duration
+}
+
+type RemindersConfig struct {
+	// PollInterval is how often due reminders are looked up.
+	PollInterval time.Duration
+	BatchSize    int64
+	MaxAttempts  int64
+	// RetryBackoff before the first retry of a failed reminder, doubled on every next one.
+	RetryBackoff time.Duration
+	// Lease is how long a replica owns the reminders it claimed, they are sent again after
+	// it when the replica stopped before marking them.
+	Lease time.Duration
+}
+
+type NotifierConfig struct {
+	// Channels are any of log, smtp and webhook.
+	Channels       []string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
+	SMTPFrom       string
+	WebhookURL     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+}
+
+type AttachmentsConfig struct {
+	// Storage is local or s3.
+	Storage string
+	// Dir is the root directory of the local storage.
+	Dir string
+	// MaxBytes is the maximum size of one file.
+	MaxBytes int64
+	// UserQuotaBytes is the maximum total size of the files of a user, 0 is unlimited.
+	UserQuotaBytes int64
+	// CleanupInterval is how often the blobs of deleted attachments are removed.
+	CleanupInterval time.Duration
+	// TransferTimeout replaces the server timeouts for uploads and downloads of files.
+	TransferTimeout   time.Duration
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// S3PathStyle addresses the bucket in the path, most self hosted S3 compatible services need it.
+	S3PathStyle bool
+	S3Timeout   time.Duration
+}
+
+type ServerConfig struct {
+	// ShutdownDelay is the time between failing readiness and closing the listener.
+	ShutdownDelay time.Duration
+	// HealthCheckTimeout limits the duration of all readiness checks.
+	HealthCheckTimeout time.Duration
 }
 
 type DBConfig struct {
-	Host     string
-	Port     string
-	Driver   string
-	User     string
-	Password string
-	Name     string
+	Host        string
+	Port        string
+	Driver      string
+	User        string
+	Password    string
+	Name        string
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+}
+
+type TracingConfig struct {
+	// Exporter is one of none, stdout or otlp.
+	Exporter     string
+	ServiceName  string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	SampleRatio  float64
 }
 
 var (
@@ -26,45 +149,287 @@ var (
 	once   sync.Once
 )
 
+var tracingExporters = map[string]struct{}{
+	"none":   {},
+	"stdout": {},
+	"otlp":   {},
+}
+
+var notifierChannels = map[string]struct{}{
+	"log":     {},
+	"smtp":    {},
+	"webhook": {},
+}
+
+var attachmentStorages = map[string]struct{}{
+	"local": {},
+	"s3":    {},
+}
+
+var sslModes = map[string]struct{}{
+	"disable":     {},
+	"allow":       {},
+	"prefer":      {},
+	"require":     {},
+	"verify-ca":   {},
+	"verify-full": {},
+}
+
+func (d DBConfig) connectionURL() *url.URL {
+	q := url.Values{}
+	q.Set("sslmode", d.SSLMode)
+	if d.SSLRootCert != "" {
+		q.Set("sslrootcert", d.SSLRootCert)
+	}
+	if d.SSLCert != "" {
+		q.Set("sslcert", d.SSLCert)
+	}
+	if d.SSLKey != "" {
+		q.Set("sslkey", d.SSLKey)
+	}
+	q.Set("timezone", "UTC")
+
+	return &url.URL{
+		Scheme:   d.Driver,
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: q.Encode(),
+	}
+}
+
+// ConnectionString returns DSN with escaped credentials.
 func (d DBConfig) ConnectionString() string {
-	return fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=disable&timezone=UTC",
-		d.Driver,
-		d.User,
-		d.Password,
-		d.Host,
-		d.Port,
-		d.Name,
-	)
+	return d.connectionURL().String()
+}
+
+// String returns DSN with the password masked, safe for logging.
+func (d DBConfig) String() string {
+	return d.connectionURL().Redacted()
+}
+
+// LogValue logs the config as String does instead of its fields.
+func (d DBConfig) LogValue() slog.Value {
+	return slog.StringValue(d.String())
 }
 
 func newConfig() ConfigFile {
 	c := ConfigFile{
-		AppLevel: os.Getenv("APP_LEVEL"),
+		AppLevel:        getEnv("APP_LEVEL", ""),
+		DefaultTimeZone: getEnv("DEFAULT_TIME_ZONE", "UTC"),
 		DBConfig: DBConfig{
-			Host:     mustGetEnv("DB_HOST"),
-			Port:     mustGetEnv("DB_PORT"),
-			Driver:   mustGetEnv("DB_DRIVER"),
-			User:     mustGetEnv("DB_USER"),
-			Password: mustGetEnv("DB_PASSWORD"),
-			Name:     mustGetEnv("DB_NAME"),
+			Host:        mustGetEnv("DB_HOST"),
+			Port:        mustGetEnv("DB_PORT"),
+			Driver:      mustGetEnv("DB_DRIVER"),
+			User:        mustGetEnv("DB_USER"),
+			Password:    mustGetEnv("DB_PASSWORD"),
+			Name:        mustGetEnv("DB_NAME"),
+			SSLMode:     getEnv("DB_SSLMODE", "disable"),
+			SSLRootCert: getEnv("DB_SSLROOTCERT", ""),
+			SSLCert:     getEnv("DB_SSLCERT", ""),
+			SSLKey:      getEnv("DB_SSLKEY", ""),
+		},
+		ServerConfig: ServerConfig{
+			ShutdownDelay:      getDurationEnv("SHUTDOWN_DELAY", 5*time.Second),
+			HealthCheckTimeout: getDurationEnv("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             getDurationEnv("IDEMPOTENCY_TTL", 24*time.Hour),
+			CleanupInterval: getDurationEnv("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+		},
+		Positions: PositionsConfig{
+			MaxLength:         getIntEnv("POSITION_MAX_LENGTH", 32),
+			RebalanceInterval: getDurationEnv("POSITION_REBALANCE_INTERVAL", time.Hour),
+		},
+		Todos: TodosConfig{
+			Transitions: getEnv("TODO_STATUS_TRANSITIONS", ""),
+		},
+		Reminders: RemindersConfig{
+			PollInterval: getDurationEnv("REMINDER_POLL_INTERVAL", 30*time.Second),
+			BatchSize:    getIntEnv("REMINDER_BATCH_SIZE", 100),
+			MaxAttempts:  getIntEnv("REMINDER_MAX_ATTEMPTS", 5),
+			RetryBackoff: getDurationEnv("REMINDER_RETRY_BACKOFF", time.Minute),
+			Lease:        getDurationEnv("REMINDER_LEASE", 30*time.Minute),
 		},
+		Notifier: NotifierConfig{
+			Channels:       getListEnv("NOTIFIERS"),
+			SMTPHost:       getEnv("SMTP_HOST", ""),
+			SMTPPort:       getEnv("SMTP_PORT", "587"),
+			SMTPUsername:   getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
+			SMTPFrom:       getEnv("SMTP_FROM", ""),
+			WebhookURL:     getEnv("WEBHOOK_URL", ""),
+			WebhookSecret:  getEnv("WEBHOOK_SECRET", ""),
+			WebhookTimeout: getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Attachments: AttachmentsConfig{
+			Storage:           getEnv("ATTACHMENT_STORAGE", "local"),
+			Dir:               getEnv("ATTACHMENT_DIR", "data/attachments"),
+			MaxBytes:          getIntEnv("ATTACHMENT_MAX_BYTES", 10<<20),
+			UserQuotaBytes:    getIntEnv("ATTACHMENT_USER_QUOTA_BYTES", 100<<20),
+			CleanupInterval:   getDurationEnv("ATTACHMENT_CLEANUP_INTERVAL", time.Minute),
+			TransferTimeout:   getDurationEnv("ATTACHMENT_TRANSFER_TIMEOUT", 5*time.Minute),
+			S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+			S3Region:          getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:          getEnv("S3_BUCKET", ""),
+			S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+			S3PathStyle:       getBoolEnv("S3_PATH_STYLE", false),
+			S3Timeout:         getDurationEnv("S3_TIMEOUT", time.Minute),
+		},
+		Auth: AuthConfig{
+			Required:     getBoolEnv("AUTH_REQUIRED", false),
+			BootstrapKey: getEnv("AUTH_BOOTSTRAP_KEY", ""),
+		},
+		Limits: LimitsConfig{
+			RateLimitRPS:     getFloatEnv("RATE_LIMIT_RPS", 10),
+			RateLimitBurst:   int(getIntEnv("RATE_LIMIT_BURST", 20)),
+			IPRateLimitRPS:   getFloatEnv("IP_RATE_LIMIT_RPS", 50),
+			IPRateLimitBurst: int(getIntEnv("IP_RATE_LIMIT_BURST", 100)),
+			MaxBodyBytes:     getIntEnv("MAX_BODY_BYTES", 1<<20),
+			MaxPageSize:      getIntEnv("MAX_PAGE_SIZE", 500),
+			TrustedProxies:   getListEnv("TRUSTED_PROXIES"),
+		},
+		TracingConfig: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			ServiceName:  getEnv("TRACING_SERVICE_NAME", "todo-list"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+			OTLPInsecure: getBoolEnv("TRACING_OTLP_INSECURE", false),
+			SampleRatio:  getFloatEnv("TRACING_SAMPLE_RATIO", 1),
+		},
+	}
+
+	if _, ok := sslModes[c.DBConfig.SSLMode]; !ok {
+		logger.Fatal("config key has unsupported value", slog.String("key", "DB_SSLMODE"), slog.String("value", c.DBConfig.SSLMode))
+	}
+	if _, ok := tracingExporters[c.TracingConfig.Exporter]; !ok {
+		logger.Fatal("config key has unsupported value", slog.String("key", "TRACING_EXPORTER"), slog.String("value", c.TracingConfig.Exporter))
+	}
+	if !timezone.Valid(c.DefaultTimeZone) {
+		logger.Fatal("config key has unsupported value", slog.String("key", "DEFAULT_TIME_ZONE"), slog.String("value", c.DefaultTimeZone))
+	}
+	if len(c.Notifier.Channels) == 0 {
+		c.Notifier.Channels = []string{"log"}
+	}
+	for _, ch := range c.Notifier.Channels {
+		if _, ok := notifierChannels[ch]; !ok {
+			logger.Fatal("config key has unsupported value", slog.String("key", "NOTIFIERS"), slog.String("value", ch))
+		}
+		if ch == "smtp" && (c.Notifier.SMTPHost == "" || c.Notifier.SMTPFrom == "") {
+			logger.Fatal("config key not set", slog.String("key", "SMTP_HOST, SMTP_FROM"))
+		}
+		if ch == "webhook" && c.Notifier.WebhookURL == "" {
+			logger.Fatal("config key not set", slog.String("key", "WEBHOOK_URL"))
+		}
+	}
+	if c.Reminders.BatchSize <= 0 || c.Reminders.MaxAttempts <= 0 || c.Reminders.Lease <= 0 {
+		logger.Fatal("config key has unsupported value", slog.String("key", "REMINDER_BATCH_SIZE, REMINDER_MAX_ATTEMPTS, REMINDER_LEASE"))
+	}
+	if _, ok := attachmentStorages[c.Attachments.Storage]; !ok {
+		logger.Fatal("config key has unsupported value", slog.String("key", "ATTACHMENT_STORAGE"), slog.String("value", c.Attachments.Storage))
+	}
+	if c.Attachments.Storage == "s3" && (c.Attachments.S3Endpoint == "" || c.Attachments.S3Bucket == "") {
+		logger.Fatal("config key not set", slog.String("key", "S3_ENDPOINT, S3_BUCKET"))
+	}
+	if c.Attachments.MaxBytes <= 0 || c.Attachments.UserQuotaBytes < 0 {
+		logger.Fatal("config key has unsupported value", slog.String("key", "ATTACHMENT_MAX_BYTES, ATTACHMENT_USER_QUOTA_BYTES"))
 	}
 
 	return c
 }
 
 func mustGetEnv(key string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		log.Printf("config [%s]=%s\n", key, value)
-		return value
+	value, ok, err := lookupEnv(key)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
+	}
+	if !ok {
+		logger.Fatal("config key not set", slog.String("key", key))
+	}
+	slog.Info("config", slog.String("key", key), slog.String("value", redact(key, value)))
+
+	return value
+}
+
+func getEnv(key, defaultValue string) string {
+	value, ok, err := lookupEnv(key)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
+	}
+	if !ok || value == "" {
+		value = defaultValue
+	}
+	slog.Info("config", slog.String("key", key), slog.String("value", redact(key, value)))
+
+	return value
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	value := getEnv(key, strconv.FormatBool(defaultValue))
+	res, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return res
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	value := getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64))
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return res
+}
+
+func getIntEnv(key string, defaultValue int64) int64 {
+	value := getEnv(key, strconv.FormatInt(defaultValue, 10))
+	res, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return res
+}
+
+// getListEnv parses a comma separated list.
+func getListEnv(key string) []string {
+	value := getEnv(key, "")
+	if value == "" {
+		return nil
+	}
+
+	res := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, defaultValue.String())
+	res, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Fatal("config key error", slog.String("key", key), slog.Any("error", err))
 	}
-	log.Fatalf("config key %s not set", key)
 
-	return ""
+	return res
 }
 
 func init() {
 	once.Do(func() {
+		// logger is set up before reading the rest of the config so that config lines are
+		// structured and filtered by the level too
+		level, _, err := lookupEnv("LOG_LEVEL")
+		logger.Setup(level)
+		if err != nil {
+			logger.Fatal("config key error", slog.String("key", "LOG_LEVEL"), slog.Any("error", err))
+		}
 		Config = newConfig()
 	})
 }
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// the package init loads the config and exits without the required keys, package
+// variables are initialized before it runs
+var _ = func() error {
+	for key, value := range map[string]string{
+		"DB_HOST":     "localhost",
+		"DB_PORT":     "5432",
+		"DB_DRIVER":   "postgres",
+		"DB_USER":     "todo",
+		"DB_PASSWORD": "secret",
+		"DB_NAME":     "todo",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}()
+
+func TestDBConfig_Redacted(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{name: "password", password: "p@ss:word/1", want: "postgres://todo:xxxxx@db:5432/todo?sslmode=disable&timezone=UTC"},
+		{name: "no password", password: "", want: "postgres://todo:xxxxx@db:5432/todo?sslmode=disable&timezone=UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := DBConfig{Driver: "postgres", Host: "db", Port: "5432", User: "todo", Password: tt.password, Name: "todo", SSLMode: "disable"}
+			require.Equal(t, tt.want, c.String())
+			require.Equal(t, tt.want, slog.AnyValue(c).Resolve().String())
+
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", slog.Any("db", c))
+			if tt.password != "" {
+				require.NotContains(t, buf.String(), tt.password)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		want  string
+	}{
+		{key: "DB_PASSWORD", value: "secret", want: redactedValue},
+		{key: "AUTH_BOOTSTRAP_KEY", value: "secret", want: redactedValue},
+		{key: "smtp_password", value: "secret", want: redactedValue},
+		{key: "DB_PASSWORD", value: "", want: ""},
+		{key: "DB_HOST", value: "localhost", want: "localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			require.Equal(t, tt.want, redact(tt.key, tt.value))
+		})
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		env     map[string]string
+		want    string
+		found   bool
+		wantErr bool
+	}{
+		{
+			name:  "file with a trailing newline",
+			env:   map[string]string{"TEST_SECRET_FILE": write("newline", "from file\n")},
+			want:  "from file",
+			found: true,
+		},
+		{
+			name:  "file with windows line endings",
+			env:   map[string]string{"TEST_SECRET_FILE": write("crlf", "from file\r\n")},
+			want:  "from file",
+			found: true,
+		},
+		{
+			name:  "file wins over the variable",
+			env:   map[string]string{"TEST_SECRET_FILE": write("file", "from file"), "TEST_SECRET": "from env"},
+			want:  "from file",
+			found: true,
+		},
+		{
+			name:    "missing file",
+			env:     map[string]string{"TEST_SECRET_FILE": filepath.Join(dir, "missing"), "TEST_SECRET": "from env"},
+			wantErr: true,
+		},
+		{
+			name:  "variable",
+			env:   map[string]string{"TEST_SECRET": "from env"},
+			want:  "from env",
+			found: true,
+		},
+		{
+			name:  "secrets directory",
+			env:   map[string]string{secretsDirEnv: dir},
+			want:  "from dir",
+			found: true,
+		},
+		{
+			name: "not set",
+			env:  map[string]string{secretsDirEnv: t.TempDir()},
+		},
+		{
+			name: "secrets directory is ignored for plain keys",
+			key:  "TEST_HOST",
+			env:  map[string]string{secretsDirEnv: dir},
+		},
+		{
+			name:  "file of a plain key",
+			key:   "TEST_HOST",
+			env:   map[string]string{"TEST_HOST_FILE": write("host", "db\n")},
+			want:  "db",
+			found: true,
+		},
+	}
+	write("test_secret", "from dir\n")
+	write("test_host", "from dir\n")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"TEST_SECRET", "TEST_SECRET_FILE", "TEST_HOST", "TEST_HOST_FILE"} {
+				t.Setenv(key, "")
+				require.NoError(t, os.Unsetenv(key))
+			}
+			t.Setenv(secretsDirEnv, t.TempDir())
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			key := tt.key
+			if key == "" {
+				key = "TEST_SECRET"
+			}
+			value, found, err := lookupEnv(key)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
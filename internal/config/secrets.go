@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// fileEnvSuffix marks an env variable that holds a path to a file with the actual value,
+	// e.g. DB_PASSWORD_FILE=/run/secrets/db_password.
+	fileEnvSuffix = "_FILE"
+	// secretsDirEnv overrides the directory with mounted secrets (Docker/Kubernetes).
+	secretsDirEnv     = "SECRETS_DIR"
+	defaultSecretsDir = "/run/secrets"
+
+	redactedValue = "******"
+)
+
+var sensitiveMarkers = []string{
+	"PASSWORD",
+	"SECRET",
+	"TOKEN",
+	"KEY",
+}
+
+// isSensitive reports whether value of the key must not be printed to logs.
+func isSensitive(key string) bool {
+	key = strings.ToUpper(key)
+	for _, marker := range sensitiveMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact hides value of a sensitive key.
+func redact(key, value string) string {
+	if isSensitive(key) && value != "" {
+		return redactedValue
+	}
+	return value
+}
+
+// lookupEnv looks the key up in the following order:
+//  1. file referenced by <KEY>_FILE env variable;
+//  2. plain <KEY> env variable;
+//  3. file <key> or <KEY> in the secrets directory, for sensitive keys only so that
+//     a stray file does not override plain settings.
+func lookupEnv(key string) (string, bool, error) {
+	if path, ok := os.LookupEnv(key + fileEnvSuffix); ok && path != "" {
+		value, err := readSecretFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("read %s%s: %w", key, fileEnvSuffix, err)
+		}
+		return value, true, nil
+	}
+
+	if value, ok := os.LookupEnv(key); ok {
+		return value, true, nil
+	}
+	if !isSensitive(key) {
+		return "", false, nil
+	}
+
+	dir := os.Getenv(secretsDirEnv)
+	if dir == "" {
+		dir = defaultSecretsDir
+	}
+	for _, name := range []string{strings.ToLower(key), key} {
+		value, err := readSecretFile(filepath.Join(dir, name))
+		if err == nil {
+			return value, true, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", false, fmt.Errorf("read secret %s: %w", name, err)
+		}
+	}
+
+	return "", false, nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -2,33 +2,89 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"log/slog"
+	"time"
+	"todo-list/internal/config"
 	"todo-list/internal/controller/http/middleware"
 	v1 "todo-list/internal/controller/http/v1"
-	"todo-list/internal/service/todo"
+	"todo-list/internal/health"
+	"todo-list/internal/logger"
+	"todo-list/internal/timezone"
 )
 
+// multipartOverhead is the room left for the boundaries and part headers of an upload.
+const multipartOverhead = 64 << 10
+
 type Handler struct {
-	TodoService todo.Service
+	v1.Services
+	Health           *health.Health
+	IdempotencyStore middleware.IdempotencyStore
 }
 
-func NewHandler(ts todo.Service) *Handler {
+func NewHandler(s v1.Services, h *health.Health, is middleware.IdempotencyStore) *Handler {
 	return &Handler{
-		TodoService: ts,
+		Services:         s,
+		Health:           h,
+		IdempotencyStore: is,
 	}
 }
 
 func (h *Handler) NewRouter() *gin.Engine {
-	r := gin.Default()
-	r.Use(middleware.ErrorHandler)
+	r := gin.New()
+	// handlers pass *gin.Context to services, so values of the request context
+	// (trace span, etc.) must be reachable through it
+	r.ContextWithFallback = true
+	if err := r.SetTrustedProxies(config.Config.Limits.TrustedProxies); err != nil {
+		logger.Fatal("invalid trusted proxies", slog.Any("error", err))
+	}
+	r.Use(
+		gin.Recovery(),
+		middleware.RequestID,
+		otelgin.Middleware(config.Config.TracingConfig.ServiceName),
+		middleware.Logger,
+		middleware.Metrics,
+		middleware.ErrorHandler,
+	)
 
-	handlerV1 := v1.NewHandler(h.TodoService)
+	handlerV1 := v1.NewHandler(h.Services)
+	limits := config.Config.Limits
 	api := r.Group("/api")
+	// requests with bogus keys are throttled by IP before they cost a key lookup
+	if limits.IPRateLimitRPS > 0 {
+		api.Use(middleware.NewRateLimiter(limits.IPRateLimitRPS, limits.IPRateLimitBurst, middleware.IPKey).Handler)
+	}
+	api.Use(middleware.Auth(h.APIKeyService, config.Config.Auth.Required, config.Config.Auth.BootstrapKey))
+	if limits.RateLimitRPS > 0 {
+		api.Use(middleware.NewRateLimiter(limits.RateLimitRPS, limits.RateLimitBurst, middleware.ClientKey).Handler)
+	}
+	defaultTimeZone, err := timezone.Load(config.Config.DefaultTimeZone)
+	if err != nil {
+		logger.Fatal("invalid default time zone", slog.Any("error", err))
+	}
+	api.Use(
+		middleware.TimeZone(defaultTimeZone),
+		middleware.BodyLimit(limits.MaxBodyBytes, map[string]int64{
+			// the file plus the multipart envelope
+			"POST /api/v1/todo/:id/attachments": config.Config.Attachments.MaxBytes + multipartOverhead,
+		}),
+		middleware.Deadline(map[string]time.Duration{
+			"POST /api/v1/todo/:id/attachments":               config.Config.Attachments.TransferTimeout,
+			"GET /api/v1/todo/:id/attachments/:attachment_id": config.Config.Attachments.TransferTimeout,
+		}),
+		middleware.MaxPageSize(limits.MaxPageSize),
+		middleware.Idempotency(h.IdempotencyStore, config.Config.Idempotency.TTL),
+	)
 	{
 		handlerV1.Init(api)
 	}
 
+	r.GET("/healthz", h.Healthz)
+	r.GET("/readyz", h.Readyz)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	return r
 }
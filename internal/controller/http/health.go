@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	"todo-list/internal/buildinfo"
+)
+
+type liveness struct {
+	Status string `json:"status"`
+	buildinfo.Info
+	Uptime string `json:"uptime"`
+}
+
+// Healthz is the liveness probe: it only reports that the process serves requests, with build info.
+func (h *Handler) Healthz(c *gin.Context) {
+	info := buildinfo.Get()
+	c.JSON(http.StatusOK, liveness{
+		Status: "ok",
+		Info:   info,
+		Uptime: time.Since(info.StartTime).Round(time.Second).String(),
+	})
+}
+
+// Readyz is the readiness probe: database, migrations and outbound dependencies.
+// It fails as soon as graceful shutdown starts.
+func (h *Handler) Readyz(c *gin.Context) {
+	report, ok := h.Health.Ready(c.Request.Context())
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, report)
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (model.Principal, error)
+}
+
+// anonymous is the caller of requests without credentials while authentication is not required.
+// It may work with todos but never manage users or keys.
+var anonymous = model.Principal{
+	Name:      "anonymous",
+	Scopes:    []model.Scope{model.ScopeWrite},
+	Anonymous: true,
+}
+
+// Auth authenticates API keys passed as "Authorization: Bearer <key>". The bootstrap key
+// from config grants admin access without a database record, it is meant to create the first keys.
+func Auth(a Authenticator, required bool, bootstrapKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			if required {
+				unauthorized(c, "credentials are required")
+				return
+			}
+			setPrincipal(c, anonymous)
+			c.Next()
+			return
+		}
+
+		scheme, key, ok := strings.Cut(header, " ")
+		if !ok || !(strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, "ApiKey")) {
+			unauthorized(c, "unsupported authorization scheme")
+			return
+		}
+		key = strings.TrimSpace(key)
+
+		if bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(bootstrapKey)) == 1 {
+			setPrincipal(c, model.Principal{Name: "bootstrap", Scopes: []model.Scope{model.ScopeAdmin}})
+			c.Next()
+			return
+		}
+
+		p, err := a.Authenticate(c.Request.Context(), key)
+		if err != nil {
+			if errors.Is(err, errs.ErrUnauthorized) {
+				unauthorized(c, "invalid or revoked API key")
+				return
+			}
+			AbortWithProblem(c, toAppError(err))
+			return
+		}
+
+		setPrincipal(c, p)
+		c.Next()
+	}
+}
+
+func setPrincipal(c *gin.Context, p model.Principal) {
+	c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), p))
+}
+
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="todo-list"`)
+	AbortWithProblem(c, &errs.Error{
+		Code:    errs.CodeUnauthorized,
+		Message: message,
+		Kind:    errs.ErrUnauthorized,
+	})
+}
+
+// RequireCredentials allows the route only to callers with a key, even when
+// authentication is not required.
+func RequireCredentials(c *gin.Context) {
+	p, ok := auth.PrincipalFromContext(c.Request.Context())
+	if !ok || p.Anonymous {
+		unauthorized(c, "credentials are required")
+		return
+	}
+	c.Next()
+}
+
+// RequireScope allows the route only to principals having the scope.
+func RequireScope(scope model.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := auth.PrincipalFromContext(c.Request.Context())
+		if !ok || !p.Can(scope) {
+			AbortWithProblem(c, errs.Forbidden("API key lacks the "+string(scope)+" scope"))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type keyAuthenticator map[string]model.Principal
+
+func (a keyAuthenticator) Authenticate(_ context.Context, key string) (model.Principal, error) {
+	p, ok := a[key]
+	if !ok {
+		return model.Principal{}, errs.ErrUnauthorized
+	}
+	return p, nil
+}
+
+func TestAuth_Anonymous(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorHandler, Auth(keyAuthenticator{"admin-key": {KeyID: 1, Scopes: []model.Scope{model.ScopeAdmin}}}, false, ""))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/todo", RequireScope(model.ScopeWrite), ok)
+	r.GET("/keys", RequireCredentials, RequireScope(model.ScopeAdmin), ok)
+	r.GET("/admin", RequireScope(model.ScopeAdmin), ok)
+	r.GET("/me", RequireCredentials, ok)
+
+	do := func(path, key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, do("/todo", ""))
+	require.Equal(t, http.StatusForbidden, do("/admin", ""))
+	require.Equal(t, http.StatusUnauthorized, do("/keys", ""))
+	require.Equal(t, http.StatusUnauthorized, do("/me", ""))
+	require.Equal(t, http.StatusUnauthorized, do("/todo", "bogus"))
+
+	require.Equal(t, http.StatusOK, do("/keys", "admin-key"))
+	require.Equal(t, http.StatusOK, do("/me", "admin-key"))
+}
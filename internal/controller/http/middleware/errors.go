@@ -3,29 +3,104 @@ package middleware
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"log/slog"
 	"net/http"
-	"todo-list/internal/service/todo"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/logger"
 )
 
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response extended with a stable error code,
+// the request id and field violations.
+type Problem struct {
+	Type      string                `json:"type"`
+	Title     string                `json:"title"`
+	Status    int                   `json:"status"`
+	Detail    string                `json:"detail,omitempty"`
+	Instance  string                `json:"instance,omitempty"`
+	Code      errs.Code             `json:"code"`
+	RequestID string                `json:"request_id,omitempty"`
+	Errors    []errs.FieldViolation `json:"errors,omitempty"`
+}
+
+var codeStatus = map[errs.Code]int{
+	errs.CodeValidation:           http.StatusBadRequest,
+	errs.CodeMalformedInput:       http.StatusBadRequest,
+	errs.CodeNotFound:             http.StatusNotFound,
+	errs.CodeConflict:             http.StatusConflict,
+	errs.CodeInternal:             http.StatusInternalServerError,
+	errs.CodeTooLarge:             http.StatusRequestEntityTooLarge,
+	errs.CodeRateLimited:          http.StatusTooManyRequests,
+	errs.CodeUnauthorized:         http.StatusUnauthorized,
+	errs.CodeForbidden:            http.StatusForbidden,
+	errs.CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+
+	errs.CodeIdempotencyKeyReused:  http.StatusUnprocessableEntity,
+	errs.CodeIdempotencyInProgress: http.StatusConflict,
+}
+
+// ErrorHandler renders the last error added by a handler as application/problem+json.
 func ErrorHandler(c *gin.Context) {
 	c.Next()
 
-	for _, err := range c.Errors {
-		switch {
-		case errors.Is(err.Err, todo.ErrValidation):
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-		case errors.Is(err.Err, todo.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-		case errors.Is(err.Err, todo.ErrEmptyContent):
-			c.Status(http.StatusNoContent)
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
-		}
+	if len(c.Errors) == 0 {
+		return
+	}
+	err := c.Errors.Last().Err
+
+	if errors.Is(err, errs.ErrEmptyContent) {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	AbortWithProblem(c, toAppError(err))
+}
+
+// AbortWithProblem writes the error as a problem document and stops the handler chain.
+func AbortWithProblem(c *gin.Context, appErr *errs.Error) {
+	status, ok := codeStatus[appErr.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(c.Request.Context(), "request failed", slog.Any("error", appErr.Kind))
+	}
+
+	c.Abort()
+	// c.JSON keeps an already set Content-Type
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(status, Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    appErr.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      appErr.Code,
+		RequestID: logger.RequestID(c.Request.Context()),
+		Errors:    appErr.Violations,
+	})
+}
+
+func toAppError(err error) *errs.Error {
+	var appErr *errs.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	switch {
+	case errors.Is(err, errs.ErrValidation):
+		return &errs.Error{Code: errs.CodeValidation, Message: err.Error(), Kind: err}
+	case errors.Is(err, errs.ErrNotFound):
+		return &errs.Error{Code: errs.CodeNotFound, Message: err.Error(), Kind: err}
+	case errors.Is(err, errs.ErrConflict):
+		return &errs.Error{Code: errs.CodeConflict, Message: err.Error(), Kind: err}
+	case errors.Is(err, errs.ErrUnauthorized):
+		return &errs.Error{Code: errs.CodeUnauthorized, Message: err.Error(), Kind: err}
+	case errors.Is(err, errs.ErrForbidden):
+		return &errs.Error{Code: errs.CodeForbidden, Message: err.Error(), Kind: err}
+	default:
+		// details of internal errors are logged, not returned to clients
+		return &errs.Error{Code: errs.CodeInternal, Message: errs.ErrInternal.Error(), Kind: err}
 	}
 }
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key *dto.IdempotencyKey) (dto.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, clientKey, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, clientKey, key string) error
+}
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests carrying Idempotency-Key safe to retry: the first
+// successful response is stored and replayed for repeated requests with the same key
+// of the same client, while reuse of the key with a different request is rejected.
+// Multipart uploads are not covered, their bodies are too large to buffer.
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || strings.HasPrefix(c.ContentType(), "multipart/") {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			AbortWithProblem(c, errs.Validation(errs.FieldViolation{
+				Field:   IdempotencyKeyHeader,
+				Code:    errs.ViolationInvalid,
+				Message: "idempotency key must be at most 255 characters",
+			}))
+			return
+		}
+
+		body, err := c.GetRawData()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				AbortWithProblem(c, errs.TooLarge(fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit)))
+				return
+			}
+			AbortWithProblem(c, errs.MalformedInput("failed to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		client := ClientKey(c)
+		stored, created, err := store.Reserve(c.Request.Context(), &dto.IdempotencyKey{
+			ClientKey:   client,
+			Key:         key,
+			Fingerprint: fingerprint(c, body),
+			ExpiresAt:   time.Now().Add(ttl),
+		})
+		if err != nil {
+			AbortWithProblem(c, toAppError(err))
+			return
+		}
+
+		if !created {
+			replay(c, stored, fingerprint(c, body))
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// failed requests have no side effects worth replaying, so the key is released
+		// and the client may retry with it
+		ctx := context.WithoutCancel(c.Request.Context())
+		if len(c.Errors) > 0 || recorder.Status() >= http.StatusInternalServerError {
+			if err := store.Release(ctx, client, key); err != nil {
+				slog.ErrorContext(ctx, "release idempotency key", slog.Any("error", err))
+			}
+			return
+		}
+
+		err = store.Complete(ctx, client, key, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+		if err != nil {
+			slog.ErrorContext(ctx, "store idempotent response", slog.Any("error", err))
+		}
+	}
+}
+
+func replay(c *gin.Context, stored dto.IdempotencyKey, fp string) {
+	if stored.Fingerprint != fp {
+		AbortWithProblem(c, &errs.Error{
+			Code:    errs.CodeIdempotencyKeyReused,
+			Message: "idempotency key was already used for a different request",
+			Kind:    errs.ErrConflict,
+		})
+		return
+	}
+	if !stored.Completed() {
+		AbortWithProblem(c, &errs.Error{
+			Code:    errs.CodeIdempotencyInProgress,
+			Message: "request with this idempotency key is still in progress",
+			Kind:    errs.ErrConflict,
+		})
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if stored.ContentType != nil && *stored.ContentType != "" {
+		contentType = *stored.ContentType
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(*stored.StatusCode, contentType, stored.ResponseBody)
+	c.Abort()
+}
+
+// fingerprint identifies the request, keys are already scoped to the client.
+func fingerprint(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Request.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+type memoryIdempotencyStore map[[2]string]dto.IdempotencyKey
+
+func (s memoryIdempotencyStore) Reserve(_ context.Context, key *dto.IdempotencyKey) (dto.IdempotencyKey, bool, error) {
+	id := [2]string{key.ClientKey, key.Key}
+	if stored, ok := s[id]; ok {
+		return stored, false, nil
+	}
+	s[id] = *key
+	return *key, true, nil
+}
+
+func (s memoryIdempotencyStore) Complete(_ context.Context, clientKey, key string, statusCode int, contentType string, body []byte) error {
+	id := [2]string{clientKey, key}
+	stored := s[id]
+	stored.StatusCode, stored.ContentType, stored.ResponseBody = &statusCode, &contentType, body
+	s[id] = stored
+	return nil
+}
+
+func (s memoryIdempotencyStore) Release(_ context.Context, clientKey, key string) error {
+	delete(s, [2]string{clientKey, key})
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memoryIdempotencyStore{}
+	r := gin.New()
+	r.Use(ErrorHandler, Auth(keyAuthenticator{
+		"ann": {KeyID: 1, Scopes: []model.Scope{model.ScopeWrite}},
+		"bob": {KeyID: 2, Scopes: []model.Scope{model.ScopeWrite}},
+	}, true, ""), Idempotency(store, time.Hour))
+	calls := 0
+	r.POST("/todo", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+
+	do := func(key, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/todo", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+key)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set(IdempotencyKeyHeader, "1")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	require.JSONEq(t, `{"calls":1}`, do("ann", "application/json", `{"title":"a"}`).Body.String())
+	w := do("ann", "application/json", `{"title":"a"}`)
+	require.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	require.JSONEq(t, `{"calls":1}`, w.Body.String())
+
+	// the same key of another client is another request
+	w = do("bob", "application/json", `{"title":"b"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"calls":2}`, w.Body.String())
+
+	// uploads are never buffered for fingerprints
+	w = do("ann", "multipart/form-data; boundary=x", "--x--")
+	require.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	require.JSONEq(t, `{"calls":3}`, w.Body.String())
+	require.Len(t, store, 2)
+}
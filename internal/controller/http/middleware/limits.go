@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list/internal/domain/errs"
+)
+
+// BodyLimit rejects request bodies larger than max bytes. Declared sizes are checked
+// upfront, streamed bodies fail on read with *http.MaxBytesError. routes overrides the
+// limit of single routes, keyed by the method and the route path, e.g. "POST /api/v1/todo/:id/attachments".
+func BodyLimit(max int64, routes map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		max := max
+		if limit, ok := routes[c.Request.Method+" "+c.FullPath()]; ok {
+			max = limit
+		}
+		if c.Request.ContentLength > max {
+			AbortWithProblem(c, errs.TooLarge(fmt.Sprintf("request body must not exceed %d bytes", max)))
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		}
+		c.Next()
+	}
+}
+
+// Deadline replaces the read and write timeouts of the server for single routes, keyed
+// like the routes of BodyLimit, so that large files are not cut off on slow links.
+func Deadline(routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout, ok := routes[c.Request.Method+" "+c.FullPath()]; ok {
+			rc := http.NewResponseController(c.Writer)
+			deadline := time.Now().Add(timeout)
+			for _, set := range []func(time.Time) error{rc.SetReadDeadline, rc.SetWriteDeadline} {
+				if err := set(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+					slog.WarnContext(c, "extend deadline", slog.Any("error", err))
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+// MaxPageSize rejects list requests asking for more than max items per page.
+func MaxPageSize(max int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if raw, ok := c.GetQuery("limit"); ok {
+			limit, err := strconv.ParseInt(raw, 10, 64)
+			if err == nil && limit > max {
+				AbortWithProblem(c, errs.Validation(errs.FieldViolation{
+					Field:   "limit",
+					Code:    errs.ViolationOutOfRange,
+					Message: fmt.Sprintf("limit must not exceed %d", max),
+				}))
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Deadline(map[string]time.Duration{"POST /upload": 5 * time.Second}))
+	read := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusRequestTimeout)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+	r.POST("/upload", read)
+	r.POST("/other", read)
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.ReadTimeout = 200 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	// the body arrives after the read timeout of the server
+	slowPost := func(path string) (int, error) {
+		body, w := io.Pipe()
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_, _ = w.Write([]byte("file"))
+			_ = w.Close()
+		}()
+		res, err := http.Post(srv.URL+path, "application/octet-stream", body)
+		if err != nil {
+			return 0, err
+		}
+		defer res.Body.Close()
+		return res.StatusCode, nil
+	}
+
+	code, err := slowPost("/upload")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	code, err = slowPost("/other")
+	if err == nil {
+		require.Equal(t, http.StatusRequestTimeout, code)
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+	"todo-list/internal/metrics"
+)
+
+const unmatchedRoute = "unmatched"
+
+// Metrics records request count and latency labeled by the route template, not the raw path,
+// to keep label cardinality bounded.
+func Metrics(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	status := strconv.Itoa(c.Writer.Status())
+
+	metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+}
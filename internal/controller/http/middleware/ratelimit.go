@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/errs"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+
+	// limiters of clients idle for this long are dropped
+	limiterIdleTTL = 10 * time.Minute
+)
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter is a token bucket per client, clients are told apart by key.
+type RateLimiter struct {
+	rps   rate.Limit
+	burst int
+	key   func(c *gin.Context) string
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+func NewRateLimiter(rps float64, burst int, key func(c *gin.Context) string) *RateLimiter {
+	return &RateLimiter{
+		rps:       rate.Limit(rps),
+		burst:     burst,
+		key:       key,
+		clients:   make(map[string]*clientLimiter),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *RateLimiter) get(key string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > limiterIdleTTL {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) > limiterIdleTTL {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &clientLimiter{limiter: rate.NewLimiter(l.rps, l.burst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+
+	return c.limiter
+}
+
+// Handler rejects requests over the limit with 429 and reports the bucket state
+// in RateLimit-* headers.
+func (l *RateLimiter) Handler(c *gin.Context) {
+	now := time.Now()
+	lim := l.get(l.key(c), now)
+
+	allowed := lim.AllowN(now, 1)
+	remaining := math.Max(0, math.Floor(lim.TokensAt(now)))
+	// seconds until the bucket is full again
+	reset := math.Ceil((float64(l.burst) - remaining) / float64(l.rps))
+
+	c.Header(RateLimitLimitHeader, strconv.Itoa(l.burst))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(int(remaining)))
+	c.Header(RateLimitResetHeader, strconv.Itoa(int(reset)))
+
+	if !allowed {
+		retryAfter := math.Ceil((1 - lim.TokensAt(now)) / float64(l.rps))
+		c.Header(RetryAfterHeader, strconv.Itoa(int(math.Max(1, retryAfter))))
+		AbortWithProblem(c, &errs.Error{
+			Code:    errs.CodeRateLimited,
+			Message: "too many requests",
+			Kind:    errs.ErrRateLimited,
+		})
+		return
+	}
+
+	c.Next()
+}
+
+// ClientKey identifies the client a request is accounted to: the API key or the
+// bootstrap principal once Auth has validated them, otherwise the client IP.
+func ClientKey(c *gin.Context) string {
+	p, ok := auth.PrincipalFromContext(c.Request.Context())
+	switch {
+	case ok && p.KeyID != 0:
+		return "key:" + strconv.FormatInt(p.KeyID, 10)
+	case ok && !p.Anonymous:
+		return "auth:" + p.Name
+	}
+	return IPKey(c)
+}
+
+// IPKey identifies the client by IP only, it is used before the credentials are checked.
+func IPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
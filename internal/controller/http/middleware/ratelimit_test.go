@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimiter_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewRateLimiter(1, 2, ClientKey).Handler)
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("10.0.0.1:1000")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "2", w.Header().Get(RateLimitLimitHeader))
+	require.Equal(t, "1", w.Header().Get(RateLimitRemainingHeader))
+
+	require.Equal(t, http.StatusOK, do("10.0.0.1:1000").Code)
+
+	w = do("10.0.0.1:1000")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get(RetryAfterHeader))
+	require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	// other clients have their own bucket
+	require.Equal(t, http.StatusOK, do("10.0.0.2:1000").Code)
+}
+
+func TestMaxPageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MaxPageSize(100))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for query, status := range map[string]int{
+		"/":            http.StatusOK,
+		"/?limit=100":  http.StatusOK,
+		"/?limit=101":  http.StatusBadRequest,
+		"/?limit=9999": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, query, nil))
+		require.Equal(t, status, w.Code, query)
+	}
+}
+
+func TestRateLimiter_BeforeAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(
+		NewRateLimiter(1, 2, IPKey).Handler,
+		Auth(keyAuthenticator{}, true, ""),
+		NewRateLimiter(1, 2, ClientKey).Handler,
+	)
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1000"
+		req.Header.Set("Authorization", "Bearer "+key)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// rotating bogus keys does not give a fresh bucket
+	require.Equal(t, http.StatusUnauthorized, do("bogus-1"))
+	require.Equal(t, http.StatusUnauthorized, do("bogus-2"))
+	require.Equal(t, http.StatusTooManyRequests, do("bogus-3"))
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/timezone"
+)
+
+// TimeZone resolves the time zone of the caller: the tz query parameter, the X-Time-Zone
+// header, the time zone of the user of the API key or def, the first one set wins.
+// It must run after Auth.
+func TimeZone(def *time.Location) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		field, name := timezone.Query, c.Query(timezone.Query)
+		if name == "" {
+			field, name = timezone.Header, c.GetHeader(timezone.Header)
+		}
+		if name == "" {
+			p, _ := auth.PrincipalFromContext(c.Request.Context())
+			field, name = "time_zone", p.TimeZone
+		}
+
+		loc := def
+		if name != "" {
+			var err error
+			if loc, err = timezone.Load(name); err != nil {
+				AbortWithProblem(c, errs.Validation(errs.FieldViolation{
+					Field:   field,
+					Code:    errs.ViolationInvalid,
+					Message: name + " is not a known IANA time zone",
+				}))
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(timezone.WithLocation(c.Request.Context(), loc))
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
+)
+
+func TestTimeZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), model.Principal{TimeZone: "Asia/Tokyo"}))
+	}, TimeZone(time.UTC))
+	r.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, timezone.FromContext(c.Request.Context()).String())
+	})
+
+	do := func(target, header string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if header != "" {
+			req.Header.Set(timezone.Header, header)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, "Europe/Moscow", do("/?tz=Europe/Moscow", "America/New_York").Body.String())
+	require.Equal(t, "America/New_York", do("/", "America/New_York").Body.String())
+	require.Equal(t, "Asia/Tokyo", do("/", "").Body.String())
+
+	w := do("/?tz=Mars/Olympus", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+)
+
+// GetAgenda	godoc
+//
+// @Summary List visible todos between two days grouped by day, with counts per day
+// @Description Days are in the time zone of the caller, every day of the period is listed. Open recurring todos appear on every day they recur on. An agenda covers at most 92 days.
+// @Tags todo
+// @Produce json
+// @Param input query dto.AgendaFilter true "period and filter"
+// @Success 200 {object} model.Agenda
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /todo/agenda [get]
+func (h *Handler) GetAgenda(c *gin.Context) {
+	var filter dto.AgendaFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.GetAgenda(c, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-list/internal/domain/model"
+)
+
+// CreateAPIKey	godoc
+//
+// @Summary Create API key, the key is returned only once
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param input body model.APIKey true "key name and scopes"
+// @Success 200 {object} model.IssuedAPIKey
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var k model.APIKey
+	if err := c.ShouldBind(&k); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.APIKeyService.CreateKey(c, k)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ListAPIKeys	godoc
+//
+// @Summary List API keys
+// @Tags keys
+// @Produce json
+// @Success 200 {array} model.APIKey
+// @Failure 401,403,500 {object} middleware.Problem
+// @Router /keys [get]
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	res, err := h.APIKeyService.ListKeys(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RevokeAPIKey	godoc
+//
+// @Summary Revoke API key
+// @Tags keys
+// @Produce json
+// @Param id path int64 true "key id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		_ = c.Error(pathIDError())
+		return
+	}
+
+	if err = h.APIKeyService.RevokeKey(c, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// RotateAPIKey	godoc
+//
+// @Summary Rotate API key: issue a new secret with the same scopes and revoke the old one
+// @Tags keys
+// @Produce json
+// @Param id path int64 true "key id"
+// @Success 200 {object} model.IssuedAPIKey
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /keys/{id}/rotate [post]
+func (h *Handler) RotateAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		_ = c.Error(pathIDError())
+		return
+	}
+
+	res, err := h.APIKeyService.RotateKey(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
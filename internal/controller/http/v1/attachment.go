@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+// attachmentFormField is the multipart form field of the uploaded file.
+const attachmentFormField = "file"
+
+// uploadError marks errors of reading the request body, so they are reported as bad requests
+// and not as failures of the storage.
+type uploadError struct {
+	err error
+}
+
+func (e uploadError) Error() string { return e.err.Error() }
+
+func (e uploadError) Unwrap() error { return e.err }
+
+type uploadReader struct {
+	r io.Reader
+}
+
+func (u uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = uploadError{err: err}
+	}
+	return n, err
+}
+
+// CreateAttachment	godoc
+//
+// @Summary Attach file to todo
+// @Description The file is streamed as the "file" field of a multipart form. The content type is detected
+// @Description from the contents. The file size and the total size of the files of a user are limited.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param file formData file true "file"
+// @Success 200 {object} model.Attachment
+// @Failure 400,401,403,404,413,415,500 {object} middleware.Problem
+// @Router /todo/{id}/attachments [post]
+func (h *Handler) CreateAttachment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		_ = c.Error(errs.UnsupportedMediaType("content type must be multipart/form-data"))
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = c.Error(bindingError(err))
+			return
+		}
+		if part.FormName() != attachmentFormField || part.FileName() == "" {
+			_ = part.Close()
+			continue
+		}
+
+		a := model.Attachment{TodoID: todoID, FileName: part.FileName()}
+		err = h.AttachmentService.CreateAttachment(c, &a, uploadReader{r: part})
+		var upload uploadError
+		if errors.As(err, &upload) {
+			err = bindingError(upload.err)
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, a)
+		return
+	}
+
+	_ = c.Error(errs.Validation(errs.FieldViolation{
+		Field:   attachmentFormField,
+		Code:    errs.ViolationRequired,
+		Message: "file must be set",
+	}))
+}
+
+// ListAttachments	godoc
+//
+// @Summary List attachments of todo, oldest first
+// @Tags attachments
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {array} model.Attachment
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/attachments [get]
+func (h *Handler) ListAttachments(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.AttachmentService.ListAttachments(c, todoID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DownloadAttachment	godoc
+//
+// @Summary Download attachment
+// @Description The file is always sent as an attachment with its detected content type.
+// @Tags attachments
+// @Produce octet-stream
+// @Param id path int64 true "todo id"
+// @Param attachment_id path int64 true "attachment id"
+// @Success 200 {file} file
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/attachments/{attachment_id} [get]
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "attachment_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	a, rc, err := h.AttachmentService.OpenAttachment(c, todoID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	// uploads are never rendered by the browser in the origin of the API
+	c.DataFromReader(http.StatusOK, a.Size, a.ContentType, rc, map[string]string{
+		"Content-Disposition":     mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}),
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "sandbox",
+	})
+}
+
+// DeleteAttachment	godoc
+//
+// @Summary Delete attachment, only the uploader may delete it
+// @Tags attachments
+// @Param id path int64 true "todo id"
+// @Param attachment_id path int64 true "attachment id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/attachments/{attachment_id} [delete]
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "attachment_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.AttachmentService.DeleteAttachment(c, todoID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/domain/errs"
+)
+
+func init() {
+	// violations name the fields the way clients send them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName returns the name of the field in request bodies or, for query only fields,
+// in the query string. Fields without tags are named in lower case.
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(f.Name)
+}
+
+// bindingError converts errors of gin ShouldBind* into the application error shape.
+func bindingError(err error) error {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+		numErr         *strconv.NumError
+		timeErr        *time.ParseError
+		maxBytesErr    *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return errs.TooLarge("request body must not exceed " + strconv.FormatInt(maxBytesErr.Limit, 10) + " bytes")
+	case errors.As(err, &validationErrs):
+		violations := make([]errs.FieldViolation, len(validationErrs))
+		for i, fe := range validationErrs {
+			violations[i] = errs.FieldViolation{
+				Field:   fe.Field(),
+				Code:    fe.Tag(),
+				Message: fe.Error(),
+			}
+		}
+		return errs.Validation(violations...)
+	case errors.As(err, &typeErr):
+		return errs.Validation(errs.FieldViolation{
+			Field:   typeErr.Field,
+			Code:    errs.ViolationInvalidType,
+			Message: typeErr.Field + " must be " + typeErr.Type.String(),
+		})
+	case errors.As(err, &numErr):
+		return errs.Validation(errs.FieldViolation{
+			Field:   "",
+			Code:    errs.ViolationInvalidType,
+			Message: "value " + strconv.Quote(numErr.Num) + " must be a number",
+		})
+	case errors.As(err, &timeErr):
+		return errs.Validation(errs.FieldViolation{
+			Field:   "",
+			Code:    errs.ViolationInvalid,
+			Message: "value " + strconv.Quote(timeErr.Value) + " is not a valid date",
+		})
+	case errors.As(err, &syntaxErr):
+		return errs.MalformedInput("malformed JSON at offset " + strconv.FormatInt(syntaxErr.Offset, 10))
+	case errors.Is(err, io.EOF):
+		return errs.MalformedInput("request body is empty")
+	default:
+		return errs.MalformedInput(err.Error())
+	}
+}
+
+// pathIDError reports an unparsable id path parameter.
+func pathIDError() error {
+	return pathParamError("id")
+}
+
+func pathParamError(name string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   name,
+		Code:    errs.ViolationInvalidType,
+		Message: name + " must be an integer",
+	})
+}
+
+// pathID parses an integer path parameter.
+func pathID(c *gin.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, pathParamError(name)
+	}
+	return id, nil
+}
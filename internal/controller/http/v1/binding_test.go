@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+func TestBindingError_FieldNames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		bind   func(c *gin.Context) error
+		fields []string
+	}{
+		{
+			name:   "query",
+			target: "/todo?due_within_days=400&completed_within_days=400",
+			bind:   func(c *gin.Context) error { return c.ShouldBindQuery(&dto.TodoFilter{}) },
+			fields: []string{"due_within_days", "completed_within_days"},
+		},
+		{
+			name:   "query only",
+			target: "/time-entries/report",
+			bind:   func(c *gin.Context) error { return c.ShouldBindQuery(&dto.TimeReportFilter{}) },
+			fields: []string{"group_by"},
+		},
+		{
+			name:   "body",
+			target: "/todo/1/checklist/order",
+			body:   `{"ids": [1, 0]}`,
+			bind:   func(c *gin.Context) error { return c.ShouldBindJSON(&model.ChecklistOrder{}) },
+			fields: []string{"ids[1]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			var e *errs.Error
+			require.True(t, errors.As(bindingError(tt.bind(c)), &e))
+			fields := make([]string, len(e.Violations))
+			for i, v := range e.Violations {
+				fields[i] = v.Field
+			}
+			require.Equal(t, tt.fields, fields)
+		})
+	}
+}
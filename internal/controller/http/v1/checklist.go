@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/model"
+)
+
+// GetChecklist	godoc
+//
+// @Summary Get checklist of todo
+// @Tags checklist
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {object} model.Checklist
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/checklist [get]
+func (h *Handler) GetChecklist(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.TodoService.GetChecklist(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddChecklistItem	godoc
+//
+// @Summary Append item to checklist of todo
+// @Description A todo with auto_complete is completed once all checklist items are checked.
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.ChecklistItem true "item title, optionally checked"
+// @Success 200 {object} model.Checklist
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/checklist [post]
+func (h *Handler) AddChecklistItem(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var item model.ChecklistItem
+	if err = c.ShouldBind(&item); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.AddChecklistItem(c, id, &item)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateChecklistItem	godoc
+//
+// @Summary Rename, check or uncheck checklist item
+// @Description Checking the last unchecked item completes a todo with auto_complete, unless the todo is blocked
+// @Description or the status workflow does not allow it. Unchecking an item does not reopen the todo.
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param item_id path int64 true "checklist item id"
+// @Param input body model.ChecklistItemPatch true "changed fields"
+// @Success 200 {object} model.Checklist
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/checklist/{item_id} [patch]
+func (h *Handler) UpdateChecklistItem(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	itemID, err := pathID(c, "item_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var patch model.ChecklistItemPatch
+	if err = c.ShouldBindJSON(&patch); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.UpdateChecklistItem(c, id, itemID, patch)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteChecklistItem	godoc
+//
+// @Summary Delete checklist item
+// @Tags checklist
+// @Param id path int64 true "todo id"
+// @Param item_id path int64 true "checklist item id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/checklist/{item_id} [delete]
+func (h *Handler) DeleteChecklistItem(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	itemID, err := pathID(c, "item_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.TodoService.DeleteChecklistItem(c, id, itemID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// ReorderChecklist	godoc
+//
+// @Summary Reorder checklist of todo
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.ChecklistOrder true "ids of all items in the new order"
+// @Success 200 {object} model.Checklist
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id}/checklist/order [put]
+func (h *Handler) ReorderChecklist(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var order model.ChecklistOrder
+	if err = c.ShouldBindJSON(&order); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.ReorderChecklist(c, id, order)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
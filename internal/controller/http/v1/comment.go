@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// CreateComment	godoc
+//
+// @Summary Comment on todo, @username mentions notify the mentioned users
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.Comment true "markdown body"
+// @Success 200 {object} model.Comment
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/comments [post]
+func (h *Handler) CreateComment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var comment model.Comment
+	if err = c.ShouldBind(&comment); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	comment.TodoID = todoID
+
+	if err = h.CommentService.CreateComment(c, &comment); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// ListComments	godoc
+//
+// @Summary List comments on todo with pagination, oldest first
+// @Tags comments
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input query dto.CommentFilter false "page"
+// @Success 200 {object} model.CommentPagination
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/comments [get]
+func (h *Handler) ListComments(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var filter dto.CommentFilter
+	if err = c.ShouldBindQuery(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.CommentService.ListComments(c, todoID, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateComment	godoc
+//
+// @Summary Edit comment, only the author may edit it
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param comment_id path int64 true "comment id"
+// @Param input body model.Comment true "markdown body"
+// @Success 200 {object} model.Comment
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/comments/{comment_id} [patch]
+func (h *Handler) UpdateComment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "comment_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var comment model.Comment
+	if err = c.ShouldBind(&comment); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	comment.ID, comment.TodoID = id, todoID
+
+	if err = h.CommentService.UpdateComment(c, &comment); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment	godoc
+//
+// @Summary Delete comment, only the author may delete it
+// @Tags comments
+// @Param id path int64 true "todo id"
+// @Param comment_id path int64 true "comment id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/comments/{comment_id} [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "comment_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.CommentService.DeleteComment(c, todoID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
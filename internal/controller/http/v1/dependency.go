@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// ListTodoDependencies	godoc
+//
+// @Summary List todos the todo is blocked by and todos it blocks
+// @Tags dependencies
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {object} model.TodoDependencies
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/dependencies [get]
+func (h *Handler) ListTodoDependencies(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.TodoService.ListDependencies(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddTodoDependency	godoc
+//
+// @Summary Add a todo the todo is blocked by (blocked_by) or a todo it blocks (blocks)
+// @Description A todo can not be completed while it is blocked, links which make todos block each other are rejected with 409.
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.TodoLink true "linked todo, exactly one of blocked_by and blocks"
+// @Success 200 {object} model.TodoDependencies
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id}/dependencies [post]
+func (h *Handler) AddTodoDependency(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var link model.TodoLink
+	if err = c.ShouldBind(&link); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.AddDependency(c, id, link)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RemoveTodoDependency	godoc
+//
+// @Summary Remove a todo the todo is blocked by
+// @Tags dependencies
+// @Param id path int64 true "todo id"
+// @Param blocker_id path int64 true "id of the blocking todo"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/dependencies/{blocker_id} [delete]
+func (h *Handler) RemoveTodoDependency(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	blockerID, err := pathID(c, "blocker_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.TodoService.RemoveDependency(c, id, blockerID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// OrderTodos	godoc
+//
+// @Summary Order todos so that every todo follows the todos it depends on
+// @Description Dependencies through todos which are not listed are taken into account, independent todos are ordered by id.
+// @Tags dependencies
+// @Produce json
+// @Param ids query []int64 true "todo ids" collectionFormat(multi)
+// @Success 200 {array} model.TodoItem
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/order [get]
+func (h *Handler) OrderTodos(c *gin.Context) {
+	var q dto.TodoIDs
+	if err := c.ShouldBindQuery(&q); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.OrderTodos(c, q.IDs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -2,28 +2,150 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
+	"todo-list/internal/controller/http/middleware"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/service/apikey"
+	"todo-list/internal/service/attachment"
+	"todo-list/internal/service/comment"
+	"todo-list/internal/service/project"
+	"todo-list/internal/service/reminder"
+	"todo-list/internal/service/savedfilter"
+	"todo-list/internal/service/timeentry"
 	"todo-list/internal/service/todo"
+	"todo-list/internal/service/user"
 )
 
+// Services are the application services the API is built on.
+type Services struct {
+	TodoService        todo.Service
+	APIKeyService      apikey.Service
+	UserService        user.Service
+	ProjectService     project.Service
+	ReminderService    reminder.Service
+	CommentService     comment.Service
+	AttachmentService  attachment.Service
+	TimeEntryService   timeentry.Service
+	SavedFilterService savedfilter.Service
+}
+
 type Handler struct {
-	TodoService todo.Service
+	Services
 }
 
-func NewHandler(ts todo.Service) *Handler {
+func NewHandler(s Services) *Handler {
 	return &Handler{
-		TodoService: ts,
+		Services: s,
 	}
 }
+
 func (h *Handler) Init(api *gin.RouterGroup) {
+	read := middleware.RequireScope(model.ScopeRead)
+	write := middleware.RequireScope(model.ScopeWrite)
+	admin := middleware.RequireScope(model.ScopeAdmin)
+
 	v1 := api.Group("/v1")
 	{
 		td := v1.Group("/todo")
 		{
-			td.GET(":id", h.GetTodo)
-			td.POST("", h.CreateTodo)
-			td.PATCH("", h.UpdateTodo)
-			td.DELETE(":id", h.DeleteTodo)
-			td.GET("", h.ListTodos)
+			td.GET(":id", read, h.GetTodo)
+			td.POST("", write, h.CreateTodo)
+			td.PATCH("", write, h.UpdateTodo)
+			td.PATCH(":id", write, h.PatchTodo)
+			td.PUT(":id", write, h.ReplaceTodo)
+			td.DELETE(":id", write, h.DeleteTodo)
+			td.GET("", read, h.ListTodos)
+			td.POST(":id/move", write, h.MoveTodo)
+			td.GET(":id/reminders", read, h.ListReminders)
+			td.POST(":id/reminders", write, h.CreateReminder)
+			td.DELETE(":id/reminders/:reminder_id", write, h.DeleteReminder)
+			td.GET("order", read, h.OrderTodos)
+			td.GET("agenda", read, h.GetAgenda)
+			td.GET(":id/dependencies", read, h.ListTodoDependencies)
+			td.POST(":id/dependencies", write, h.AddTodoDependency)
+			td.DELETE(":id/dependencies/:blocker_id", write, h.RemoveTodoDependency)
+			td.GET(":id/comments", read, h.ListComments)
+			td.POST(":id/comments", write, h.CreateComment)
+			td.PATCH(":id/comments/:comment_id", write, h.UpdateComment)
+			td.DELETE(":id/comments/:comment_id", write, h.DeleteComment)
+			td.GET(":id/checklist", read, h.GetChecklist)
+			td.POST(":id/checklist", write, h.AddChecklistItem)
+			td.PUT(":id/checklist/order", write, h.ReorderChecklist)
+			td.PATCH(":id/checklist/:item_id", write, h.UpdateChecklistItem)
+			td.DELETE(":id/checklist/:item_id", write, h.DeleteChecklistItem)
+			td.GET(":id/attachments", read, h.ListAttachments)
+			td.POST(":id/attachments", write, h.CreateAttachment)
+			td.GET(":id/attachments/:attachment_id", read, h.DownloadAttachment)
+			td.DELETE(":id/attachments/:attachment_id", write, h.DeleteAttachment)
+			td.POST(":id/timer/start", write, h.StartTimer)
+			td.POST(":id/timer/stop", write, h.StopTimer)
+			td.GET(":id/time-entries", read, h.ListTimeEntries)
+			td.POST(":id/time-entries", write, h.CreateTimeEntry)
+			td.PATCH(":id/time-entries/:entry_id", write, h.UpdateTimeEntry)
+			td.DELETE(":id/time-entries/:entry_id", write, h.DeleteTimeEntry)
+		}
+
+		tm := v1.Group("/time")
+		{
+			tm.GET("report", read, h.TimeReport)
+		}
+
+		stats := v1.Group("/stats")
+		{
+			stats.GET("", read, h.GetStats)
+			stats.GET("series", read, h.GetSeries)
+		}
+
+		filters := v1.Group("/filters")
+		{
+			filters.GET("", read, h.ListSavedFilters)
+			filters.POST("", write, h.CreateSavedFilter)
+			filters.GET(":id", read, h.GetSavedFilter)
+			filters.PUT(":id", write, h.ReplaceSavedFilter)
+			filters.DELETE(":id", write, h.DeleteSavedFilter)
+			filters.GET(":id/todos", read, h.RunSavedFilter)
+		}
+
+		lists := v1.Group("/smart-lists")
+		{
+			lists.GET("", read, h.ListSmartLists)
+			lists.GET(":key/todos", read, h.RunSmartList)
+		}
+
+		projects := v1.Group("/projects", middleware.RequireCredentials)
+		{
+			projects.GET("", read, h.ListProjects)
+			projects.POST("", write, h.CreateProject)
+			projects.GET(":id", read, h.GetProject)
+			projects.PATCH(":id", write, h.UpdateProject)
+			projects.DELETE(":id", write, h.DeleteProject)
+			projects.GET(":id/members", read, h.ListProjectMembers)
+			projects.PATCH(":id/members/:user_id", write, h.UpdateProjectMember)
+			projects.DELETE(":id/members/:user_id", write, h.RemoveProjectMember)
+			projects.GET(":id/invitations", read, h.ListProjectInvitations)
+			projects.POST(":id/invitations", write, h.InviteToProject)
+		}
+
+		invitations := v1.Group("/invitations", middleware.RequireCredentials)
+		{
+			invitations.GET("", read, h.ListMyInvitations)
+			invitations.POST(":id/accept", write, h.AcceptInvitation)
+			invitations.POST(":id/decline", write, h.DeclineInvitation)
+		}
+
+		users := v1.Group("/users", middleware.RequireCredentials)
+		{
+			users.GET("me", read, h.GetCurrentUser)
+			users.PATCH("me", write, h.UpdateCurrentUser)
+			users.GET("", admin, h.ListUsers)
+			users.POST("", admin, h.CreateUser)
+		}
+
+		keys := v1.Group("/keys", middleware.RequireCredentials, admin)
+		{
+			keys.GET("", h.ListAPIKeys)
+			keys.POST("", h.CreateAPIKey)
+			keys.DELETE(":id", h.RevokeAPIKey)
+			keys.POST(":id/rotate", h.RotateAPIKey)
 		}
 	}
 }
@@ -0,0 +1,348 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// CreateProject	godoc
+//
+// @Summary Create project, the caller becomes its owner
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param input body model.Project true "project info"
+// @Success 200 {object} model.Project
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /projects [post]
+func (h *Handler) CreateProject(c *gin.Context) {
+	var p model.Project
+	if err := c.ShouldBind(&p); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.ProjectService.CreateProject(c, &p); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// ListProjects	godoc
+//
+// @Summary List projects of the caller ordered by position, with counts of pending and completed todos
+// @Tags projects
+// @Produce json
+// @Param input query dto.ProjectFilter false "filter for list projects"
+// @Success 200 {array} model.Project
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /projects [get]
+func (h *Handler) ListProjects(c *gin.Context) {
+	var filter dto.ProjectFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.ProjectService.ListProjects(c, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetProject	godoc
+//
+// @Summary Get project by id
+// @Tags projects
+// @Produce json
+// @Param id path int64 true "project id"
+// @Success 200 {object} model.Project
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /projects/{id} [get]
+func (h *Handler) GetProject(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.ProjectService.GetProject(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateProject	godoc
+//
+// @Summary Update project fields, archive or reorder it, only owners may do it
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int64 true "project id"
+// @Param input body model.Project true "project info"
+// @Success 200 {object} model.Project
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /projects/{id} [patch]
+func (h *Handler) UpdateProject(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var p model.Project
+	if err = c.ShouldBind(&p); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	p.ID = id
+
+	if err = h.ProjectService.UpdateProject(c, &p); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// DeleteProject	godoc
+//
+// @Summary Delete project with all its todos, only owners may do it
+// @Tags projects
+// @Produce json
+// @Param id path int64 true "project id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /projects/{id} [delete]
+func (h *Handler) DeleteProject(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.ProjectService.DeleteProject(c, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// ListProjectMembers	godoc
+//
+// @Summary List members of the project
+// @Tags projects
+// @Produce json
+// @Param id path int64 true "project id"
+// @Success 200 {array} model.ProjectMember
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /projects/{id}/members [get]
+func (h *Handler) ListProjectMembers(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.ProjectService.ListMembers(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateProjectMember	godoc
+//
+// @Summary Change role of a member, only owners may do it
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int64 true "project id"
+// @Param user_id path int64 true "user id"
+// @Param input body model.ProjectMember true "new role"
+// @Success 200
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /projects/{id}/members/{user_id} [patch]
+func (h *Handler) UpdateProjectMember(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, err := pathID(c, "user_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var m model.ProjectMember
+	if err = c.ShouldBind(&m); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	m.ProjectID, m.UserID = id, userID
+
+	if err = h.ProjectService.UpdateMember(c, m); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// RemoveProjectMember	godoc
+//
+// @Summary Remove member from the project, members may remove themselves
+// @Tags projects
+// @Produce json
+// @Param id path int64 true "project id"
+// @Param user_id path int64 true "user id"
+// @Success 200
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /projects/{id}/members/{user_id} [delete]
+func (h *Handler) RemoveProjectMember(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, err := pathID(c, "user_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.ProjectService.RemoveMember(c, id, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// InviteToProject	godoc
+//
+// @Summary Invite a user by email, only owners may do it
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int64 true "project id"
+// @Param input body model.Invitation true "email and role"
+// @Success 200 {object} model.Invitation
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /projects/{id}/invitations [post]
+func (h *Handler) InviteToProject(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var inv model.Invitation
+	if err = c.ShouldBind(&inv); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	inv.ProjectID = id
+
+	if err = h.ProjectService.Invite(c, &inv); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, inv)
+}
+
+// ListProjectInvitations	godoc
+//
+// @Summary List invitations of the project, only owners may do it
+// @Tags projects
+// @Produce json
+// @Param id path int64 true "project id"
+// @Success 200 {array} model.Invitation
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /projects/{id}/invitations [get]
+func (h *Handler) ListProjectInvitations(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.ProjectService.ListProjectInvitations(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ListMyInvitations	godoc
+//
+// @Summary List pending invitations addressed to the caller
+// @Tags invitations
+// @Produce json
+// @Success 200 {array} model.Invitation
+// @Failure 401,403,500 {object} middleware.Problem
+// @Router /invitations [get]
+func (h *Handler) ListMyInvitations(c *gin.Context) {
+	res, err := h.ProjectService.ListMyInvitations(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AcceptInvitation	godoc
+//
+// @Summary Accept invitation and join the project
+// @Tags invitations
+// @Produce json
+// @Param id path int64 true "invitation id"
+// @Success 200
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /invitations/{id}/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.ProjectService.AcceptInvitation(c, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// DeclineInvitation	godoc
+//
+// @Summary Decline invitation
+// @Tags invitations
+// @Produce json
+// @Param id path int64 true "invitation id"
+// @Success 200
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /invitations/{id}/decline [post]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.ProjectService.DeclineInvitation(c, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/model"
+)
+
+// CreateReminder	godoc
+//
+// @Summary Set a reminder on todo, at remind_at or offset_seconds relative to the todo date
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.Reminder true "reminder time"
+// @Success 200 {object} model.Reminder
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/reminders [post]
+func (h *Handler) CreateReminder(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var r model.Reminder
+	if err := c.ShouldBind(&r); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	r.TodoID = todoID
+
+	if err := h.ReminderService.CreateReminder(c, &r); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, r)
+}
+
+// ListReminders	godoc
+//
+// @Summary List reminders of the caller on todo
+// @Tags reminders
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {array} model.Reminder
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/reminders [get]
+func (h *Handler) ListReminders(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.ReminderService.ListReminders(c, todoID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteReminder	godoc
+//
+// @Summary Delete reminder
+// @Tags reminders
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param reminder_id path int64 true "reminder id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/reminders/{reminder_id} [delete]
+func (h *Handler) DeleteReminder(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "reminder_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.ReminderService.DeleteReminder(c, todoID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
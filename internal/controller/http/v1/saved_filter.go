@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// ListSavedFilters	godoc
+//
+// @Summary List saved filters of the caller by name
+// @Tags filters
+// @Produce json
+// @Success 200 {array} model.SavedFilter
+// @Failure 401,403,500 {object} middleware.Problem
+// @Router /filters [get]
+func (h *Handler) ListSavedFilters(c *gin.Context) {
+	res, err := h.SavedFilterService.ListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateSavedFilter	godoc
+//
+// @Summary Save a todo list filter under a name, names are unique per user
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Param input body model.SavedFilter true "name and filter"
+// @Success 200 {object} model.SavedFilter
+// @Failure 400,401,403,409,500 {object} middleware.Problem
+// @Router /filters [post]
+func (h *Handler) CreateSavedFilter(c *gin.Context) {
+	var f model.SavedFilter
+	if err := c.ShouldBindJSON(&f); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.SavedFilterService.CreateFilter(c, &f); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// GetSavedFilter	godoc
+//
+// @Summary Get saved filter of the caller
+// @Tags filters
+// @Produce json
+// @Param id path int64 true "filter id"
+// @Success 200 {object} model.SavedFilter
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /filters/{id} [get]
+func (h *Handler) GetSavedFilter(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.SavedFilterService.GetFilter(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ReplaceSavedFilter	godoc
+//
+// @Summary Replace name and filter of a saved filter of the caller
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Param id path int64 true "filter id"
+// @Param input body model.SavedFilter true "name and filter"
+// @Success 200 {object} model.SavedFilter
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /filters/{id} [put]
+func (h *Handler) ReplaceSavedFilter(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var f model.SavedFilter
+	if err = c.ShouldBindJSON(&f); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	f.ID = id
+
+	if err = h.SavedFilterService.UpdateFilter(c, &f); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// DeleteSavedFilter	godoc
+//
+// @Summary Delete saved filter of the caller
+// @Tags filters
+// @Param id path int64 true "filter id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /filters/{id} [delete]
+func (h *Handler) DeleteSavedFilter(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.SavedFilterService.DeleteFilter(c, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// RunSavedFilter	godoc
+//
+// @Summary List visible todos matching a saved filter of the caller with pagination
+// @Tags filters
+// @Produce json
+// @Param id path int64 true "filter id"
+// @Param input query dto.TodoPage false "page"
+// @Success 200 {object} model.TodoPagination
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /filters/{id}/todos [get]
+func (h *Handler) RunSavedFilter(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var page dto.TodoPage
+	if err = c.ShouldBindQuery(&page); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.SavedFilterService.RunFilter(c, id, page)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ListSmartLists	godoc
+//
+// @Summary List built-in smart lists: today, upcoming, overdue and completed
+// @Tags filters
+// @Produce json
+// @Success 200 {array} model.SmartList
+// @Failure 401,403,500 {object} middleware.Problem
+// @Router /smart-lists [get]
+func (h *Handler) ListSmartLists(c *gin.Context) {
+	c.JSON(http.StatusOK, h.SavedFilterService.ListSmartLists(c))
+}
+
+// RunSmartList	godoc
+//
+// @Summary List visible todos of a smart list with pagination
+// @Tags filters
+// @Produce json
+// @Param key path string true "smart list key" Enums(today, upcoming, overdue, completed)
+// @Param input query dto.TodoPage false "page"
+// @Success 200 {object} model.TodoPagination
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /smart-lists/{key}/todos [get]
+func (h *Handler) RunSmartList(c *gin.Context) {
+	var page dto.TodoPage
+	if err := c.ShouldBindQuery(&page); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.SavedFilterService.RunSmartList(c, c.Param("key"), page)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
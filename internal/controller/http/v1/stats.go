@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/domain/dto"
+)
+
+// GetStats	godoc
+//
+// @Summary Summarize visible todos: counts by status, overdue todos and average time from creation to completion
+// @Tags stats
+// @Produce json
+// @Param input query dto.TodoFilter false "filter, pages are ignored"
+// @Success 200 {object} model.TodoStats
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /stats [get]
+func (h *Handler) GetStats(c *gin.Context) {
+	var filter dto.TodoFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.GetStats(c, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetSeries	godoc
+//
+// @Summary Count visible todos created and completed per day, week or month with running totals for burn-up charts. Periods are in the time zone of the caller, the last 30 periods are returned by default
+// @Tags stats
+// @Produce json
+// @Param input query dto.TodoSeriesFilter false "period, interval and filter"
+// @Success 200 {object} model.TodoSeries
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /stats/series [get]
+func (h *Handler) GetSeries(c *gin.Context) {
+	var filter dto.TodoSeriesFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.GetSeries(c, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// StartTimer	godoc
+//
+// @Summary Start timer of the caller on todo, a user runs one timer at a time
+// @Tags time tracking
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.TimerStart false "note"
+// @Success 200 {object} model.TimeEntry
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id}/timer/start [post]
+func (h *Handler) StartTimer(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var start model.TimerStart
+	if err = c.ShouldBindJSON(&start); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TimeEntryService.StartTimer(c, todoID, start.Note)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// StopTimer	godoc
+//
+// @Summary Stop timer of the caller on todo
+// @Tags time tracking
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {object} model.TimeEntry
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/timer/stop [post]
+func (h *Handler) StopTimer(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.TimeEntryService.StopTimer(c, todoID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ListTimeEntries	godoc
+//
+// @Summary List time tracked on todo, oldest first
+// @Tags time tracking
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Success 200 {array} model.TimeEntry
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/time-entries [get]
+func (h *Handler) ListTimeEntries(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.TimeEntryService.ListEntries(c, todoID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateTimeEntry	godoc
+//
+// @Summary Record time spent on todo, the entry must not overlap other entries of the caller
+// @Tags time tracking
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.TimeEntry true "started_at, ended_at and note"
+// @Success 200 {object} model.TimeEntry
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id}/time-entries [post]
+func (h *Handler) CreateTimeEntry(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var entry model.TimeEntry
+	if err = c.ShouldBindJSON(&entry); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	entry.TodoID = todoID
+
+	if err = h.TimeEntryService.CreateEntry(c, &entry); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateTimeEntry	godoc
+//
+// @Summary Edit time entry, only the user who tracked it may edit it. Setting ended_at stops a running timer
+// @Tags time tracking
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param entry_id path int64 true "time entry id"
+// @Param input body model.TimeEntryPatch true "changed fields"
+// @Success 200 {object} model.TimeEntry
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id}/time-entries/{entry_id} [patch]
+func (h *Handler) UpdateTimeEntry(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "entry_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var patch model.TimeEntryPatch
+	if err = c.ShouldBindJSON(&patch); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TimeEntryService.UpdateEntry(c, todoID, id, patch)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteTimeEntry	godoc
+//
+// @Summary Delete time entry, only the user who tracked it may delete it
+// @Tags time tracking
+// @Param id path int64 true "todo id"
+// @Param entry_id path int64 true "time entry id"
+// @Success 200
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/time-entries/{entry_id} [delete]
+func (h *Handler) DeleteTimeEntry(c *gin.Context) {
+	todoID, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := pathID(c, "entry_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.TimeEntryService.DeleteEntry(c, todoID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+}
+
+// TimeReport	godoc
+//
+// @Summary Report time tracked on visible todos by day, project, status or todo. Days are in the time zone of the caller, the last 30 days are reported by default
+// @Tags time tracking
+// @Produce json
+// @Param input query dto.TimeReportFilter true "grouping, period and filters"
+// @Success 200 {object} model.TimeReport
+// @Failure 400,401,403,500 {object} middleware.Problem
+// @Router /time/report [get]
+func (h *Handler) TimeReport(c *gin.Context) {
+	var filter dto.TimeReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TimeEntryService.Report(c, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
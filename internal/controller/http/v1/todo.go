@@ -1,13 +1,22 @@
 package v1
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
 	"todo-list/internal/domain/model"
-	"todo-list/internal/service/todo"
+	"todo-list/internal/util/jsonpatch"
+)
+
+// Media types of the patch documents accepted by PATCH /todo/{id}.
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
 )
 
 // GetTodo	godoc
@@ -18,13 +27,13 @@ import (
 // @Produce json
 // @Param id path int64 true "todo id"
 // @Success 200 {object} model.TodoItem
-// @Failure 400,404,500 {string} string
+// @Failure 400,404,500 {object} middleware.Problem
 // @Router /todo/{id} [get]
 func (h *Handler) GetTodo(c *gin.Context) {
 	id := c.Param("id")
 	intID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		_ = c.Error(fmt.Errorf("%w: %v", todo.ErrValidation, err))
+		_ = c.Error(pathIDError())
 		return
 	}
 
@@ -44,36 +53,41 @@ func (h *Handler) GetTodo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param input body model.TodoItem true "todo info"
-// @Success 200
-// @Failure 400,404,500 {string} string
+// @Param Idempotency-Key header string false "makes retries of the request safe"
+// @Success 200 {object} model.TodoItem
+// @Failure 400,404,500 {object} middleware.Problem
 // @Router /todo [post]
 func (h *Handler) CreateTodo(c *gin.Context) {
 	var t model.TodoItem
 	if err := c.ShouldBind(&t); err != nil {
-		_ = c.Error(err)
+		_ = c.Error(bindingError(err))
 		return
 	}
 	if err := h.TodoService.CreateTodo(c, &t); err != nil {
 		_ = c.Error(err)
 		return
 	}
+
+	c.JSON(http.StatusOK, t)
 }
 
 // UpdateTodo	godoc
 //
-// @Summary Update todo item by id
+// @Summary Update todo item by id, empty fields are left unchanged
+// @Description Deprecated, use PATCH /todo/{id} which can also clear fields.
 // @Tags todo
 // @Accept json
 // @Produce json
 // @Param input body model.TodoItem true "updated todo item"
 // @Success 200
-// @Failure 400,404,500 {string} string
+// @Failure 400,404,500 {object} middleware.Problem
+// @Deprecated
 // @Router /todo [patch]
 func (h *Handler) UpdateTodo(c *gin.Context) {
 	var t model.TodoItem
 
 	if err := c.ShouldBind(&t); err != nil {
-		_ = c.Error(err)
+		_ = c.Error(bindingError(err))
 		return
 	}
 	if err := h.TodoService.UpdateTodo(c, &t); err != nil {
@@ -82,6 +96,129 @@ func (h *Handler) UpdateTodo(c *gin.Context) {
 	}
 }
 
+// PatchTodo	godoc
+//
+// @Summary Patch todo by id
+// @Description The body is a JSON Merge Patch (RFC 7396, also sent as application/json) or a JSON Patch (RFC 6902)
+// @Description of the writable fields: title, description, date, due_at, time_zone, status, project_id, auto_complete, estimate_minutes and recurrence.
+// @Description null in a merge patch and remove in a JSON Patch clear a field. The patched todo is validated as a whole.
+// @Tags todo
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body object true "merge patch or JSON Patch"
+// @Success 200 {object} model.TodoItem
+// @Failure 400,401,403,404,409,413,415,500 {object} middleware.Problem
+// @Router /todo/{id} [patch]
+func (h *Handler) PatchTodo(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	apply := jsonpatch.MergePatch
+	switch c.ContentType() {
+	case mergePatchContentType, gin.MIMEJSON:
+	case jsonPatchContentType:
+		apply = jsonpatch.Apply
+	default:
+		_ = c.Error(errs.UnsupportedMediaType("content type must be " + mergePatchContentType + " or " + jsonPatchContentType))
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	if len(bytes.TrimSpace(patch)) == 0 {
+		_ = c.Error(errs.MalformedInput("request body is empty"))
+		return
+	}
+
+	current, err := h.TodoService.GetTodoByID(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	doc, err := json.Marshal(model.NewTodoDocument(current))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := apply(doc, patch)
+	if err != nil {
+		_ = c.Error(patchError(err))
+		return
+	}
+
+	var patched model.TodoDocument
+	d := json.NewDecoder(bytes.NewReader(res))
+	d.DisallowUnknownFields()
+	if err = d.Decode(&patched); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	t := patched.Item(id)
+	if err = h.TodoService.PatchTodo(c, &t, current.Changed(t)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// patchError converts errors of applying a patch document into the application error shape.
+func patchError(err error) error {
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return errs.Conflict(err.Error())
+	case errors.Is(err, jsonpatch.ErrInvalid):
+		return errs.MalformedInput(err.Error())
+	default:
+		return bindingError(err)
+	}
+}
+
+// ReplaceTodo	godoc
+//
+// @Summary Replace todo by id
+// @Description Sets all writable fields, omitted fields are cleared. time_zone defaults to the zone of the caller.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.TodoItem true "todo info"
+// @Success 200 {object} model.TodoItem
+// @Failure 400,401,403,404,409,500 {object} middleware.Problem
+// @Router /todo/{id} [put]
+func (h *Handler) ReplaceTodo(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var t model.TodoItem
+	if err = c.ShouldBindJSON(&t); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	t.ID = id
+
+	if err = h.TodoService.ReplaceTodo(c, &t); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
 // DeleteTodo	godoc
 //
 // @Summary delete todo by id
@@ -90,13 +227,13 @@ func (h *Handler) UpdateTodo(c *gin.Context) {
 // @Produce json
 // @Param id path int64 true "id todo for delete"
 // @Success 200
-// @Failure 400,404,500 {string} string
+// @Failure 400,404,500 {object} middleware.Problem
 // @Router /todo/{id} [delete]
 func (h *Handler) DeleteTodo(c *gin.Context) {
 	id := c.Param("id")
 	intID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		_ = c.Error(err)
+		_ = c.Error(pathIDError())
 		return
 	}
 
@@ -113,14 +250,15 @@ func (h *Handler) DeleteTodo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param input query dto.TodoFilter true "filter for list todos"
+// @Param tz query string false "IANA time zone of date, today and overdue, the X-Time-Zone header or the user time zone otherwise"
 // @Success 200,204 {object} model.TodoPagination
-// @Failure 400,404,500 {string} string
+// @Failure 400,404,500 {object} middleware.Problem
 // @Router /todo [get]
 func (h *Handler) ListTodos(c *gin.Context) {
 	var filter dto.TodoFilter
 	err := c.ShouldBind(&filter)
 	if err != nil {
-		_ = c.Error(err)
+		_ = c.Error(bindingError(err))
 		return
 	}
 
@@ -132,3 +270,36 @@ func (h *Handler) ListTodos(c *gin.Context) {
 
 	c.JSON(http.StatusOK, pagination)
 }
+
+// MoveTodo	godoc
+//
+// @Summary Move todo right before or after another todo in the manual order (sort=manual)
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path int64 true "todo id"
+// @Param input body model.TodoMove true "neighbour todo, exactly one of before_id and after_id"
+// @Success 200 {object} model.TodoItem
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /todo/{id}/move [post]
+func (h *Handler) MoveTodo(c *gin.Context) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var move model.TodoMove
+	if err = c.ShouldBind(&move); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	res, err := h.TodoService.MoveTodo(c, id, move)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+// CreateUser	godoc
+//
+// @Summary Create user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body model.User true "user email and name"
+// @Success 200 {object} model.User
+// @Failure 400,401,403,409,500 {object} middleware.Problem
+// @Router /users [post]
+func (h *Handler) CreateUser(c *gin.Context) {
+	var u model.User
+	if err := c.ShouldBind(&u); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.UserService.CreateUser(c, &u); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, u)
+}
+
+// ListUsers	godoc
+//
+// @Summary List users
+// @Tags users
+// @Produce json
+// @Success 200 {array} model.User
+// @Failure 401,403,500 {object} middleware.Problem
+// @Router /users [get]
+func (h *Handler) ListUsers(c *gin.Context) {
+	res, err := h.UserService.ListUsers(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateCurrentUser	godoc
+//
+// @Summary Change name, username or time zone of the user the API key is issued to
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body model.User true "user name, username and IANA time zone"
+// @Success 200 {object} model.User
+// @Failure 400,401,403,404,500 {object} middleware.Problem
+// @Router /users/me [patch]
+func (h *Handler) UpdateCurrentUser(c *gin.Context) {
+	p, _ := auth.PrincipalFromContext(c)
+	if p.UserID == 0 {
+		_ = c.Error(errs.NotFound("API key is not issued to a user"))
+		return
+	}
+
+	var u model.User
+	if err := c.ShouldBind(&u); err != nil {
+		_ = c.Error(bindingError(err))
+		return
+	}
+	u.ID = p.UserID
+
+	if err := h.UserService.UpdateUser(c, &u); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, u)
+}
+
+// GetCurrentUser	godoc
+//
+// @Summary Get the user the API key is issued to
+// @Tags users
+// @Produce json
+// @Success 200 {object} model.User
+// @Failure 401,404,500 {object} middleware.Problem
+// @Router /users/me [get]
+func (h *Handler) GetCurrentUser(c *gin.Context) {
+	p, _ := auth.PrincipalFromContext(c)
+	if p.UserID == 0 {
+		_ = c.Error(errs.NotFound("API key is not issued to a user"))
+		return
+	}
+
+	res, err := h.UserService.GetUserByID(c, p.UserID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
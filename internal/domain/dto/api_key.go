@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+type APIKey struct {
+	ID         int64          `db:"id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	Hash       string         `db:"hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	UserID     *int64         `db:"user_id"`
+	UserEmail  *string        `db:"user_email"`
+	UserTZ     *string        `db:"user_time_zone"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+}
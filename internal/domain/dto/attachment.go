@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+)
+
+type Attachment struct {
+	ID          int64     `db:"id"`
+	TodoID      int64     `db:"todo_id"`
+	UploaderID  *int64    `db:"uploader_id"`
+	FileName    string    `db:"file_name"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size_bytes"`
+	StorageKey  string    `db:"storage_key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+)
+
+type ChecklistItem struct {
+	ID        int64      `db:"id"`
+	TodoID    int64      `db:"todo_id"`
+	Title     string     `db:"title"`
+	Checked   bool       `db:"checked"`
+	CheckedAt *time.Time `db:"checked_at"`
+	Position  int64      `db:"position"`
+	CreatedAt time.Time  `db:"created_at"`
+}
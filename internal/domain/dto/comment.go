@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+)
+
+type Comment struct {
+	ID         int64      `db:"id"`
+	TodoID     int64      `db:"todo_id"`
+	AuthorID   *int64     `db:"author_id"`
+	AuthorName *string    `db:"author_name"`
+	Body       string     `db:"body"`
+	CreatedAt  time.Time  `db:"created_at"`
+	EditedAt   *time.Time `db:"edited_at"`
+	TotalItems int64      `db:"total_items"`
+}
+
+type CommentFilter struct {
+	Page  int64 `json:"page,omitempty" form:"page"`
+	Limit int64 `json:"limit,omitempty" form:"limit"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type IdempotencyKey struct {
+	// ClientKey scopes the key to the client which sent it
+	ClientKey    string    `db:"client_key"`
+	Key          string    `db:"key"`
+	Fingerprint  string    `db:"fingerprint"`
+	StatusCode   *int      `db:"status_code"`
+	ContentType  *string   `db:"content_type"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// Completed reports whether the response of the first request is stored.
+func (k IdempotencyKey) Completed() bool {
+	return k.StatusCode != nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+)
+
+type Project struct {
+	ID             int64      `db:"id"`
+	Name           string     `db:"name"`
+	Color          string     `db:"color"`
+	Archived       bool       `db:"archived"`
+	Position       int64      `db:"position"`
+	Role           *string    `db:"role"`
+	PendingTodos   int64      `db:"pending_todos"`
+	CompletedTodos int64      `db:"completed_todos"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at"`
+}
+
+type ProjectFilter struct {
+	IncludeArchived bool `json:"include_archived,omitempty" form:"include_archived"`
+	// MemberID limits the list to projects of the user, it is set by the service
+	MemberID int64 `json:"-" form:"-"`
+}
+
+type ProjectMember struct {
+	ProjectID int64     `db:"project_id"`
+	UserID    int64     `db:"user_id"`
+	Email     string    `db:"email"`
+	Name      string    `db:"name"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Invitation struct {
+	ID          int64      `db:"id"`
+	ProjectID   int64      `db:"project_id"`
+	ProjectName string     `db:"project_name"`
+	Email       string     `db:"email"`
+	Role        string     `db:"role"`
+	Status      string     `db:"status"`
+	InvitedBy   *int64     `db:"invited_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+	RespondedAt *time.Time `db:"responded_at"`
+}
+
+// InvitationFilter selects invitations of a project or addressed to an email, zero fields are ignored.
+type InvitationFilter struct {
+	ProjectID int64
+	Email     string
+	Status    string
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+type Reminder struct {
+	ID            int64      `db:"id"`
+	TodoID        int64      `db:"todo_id"`
+	UserID        *int64     `db:"user_id"`
+	RemindAt      *time.Time `db:"remind_at"`
+	OffsetSeconds *int64     `db:"offset_seconds"`
+	DueAt         *time.Time `db:"due_at"`
+	Attempts      int        `db:"attempts"`
+	LastError     *string    `db:"last_error"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+	SentAt        *time.Time `db:"sent_at"`
+	FailedAt      *time.Time `db:"failed_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// DueReminder is a reminder ready to be sent together with its todo and recipient.
+type DueReminder struct {
+	ID          int64     `db:"id"`
+	TodoID      int64     `db:"todo_id"`
+	Attempts    int       `db:"attempts"`
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	DueAt       time.Time `db:"due_at"`
+	Email       *string   `db:"email"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+)
+
+type SavedFilter struct {
+	ID     int64  `db:"id"`
+	UserID int64  `db:"user_id"`
+	Name   string `db:"name"`
+	// Filter is the JSON of a model.TodoQuery
+	Filter    []byte     `db:"filter"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// TodoPage selects a page of a saved filter or of a smart list.
+type TodoPage struct {
+	Page  int64 `json:"page,omitempty" form:"page"`
+	Limit int64 `json:"limit,omitempty" form:"limit"`
+}
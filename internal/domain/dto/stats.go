@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+)
+
+// TodoStatusStats aggregates the todos in a status.
+type TodoStatusStats struct {
+	Status  string `db:"status"`
+	Total   int64  `db:"total"`
+	Overdue int64  `db:"overdue"`
+	// Completed counts the todos with a completion time, CompletionSeconds sums their time from creation to completion
+	Completed         int64 `db:"completed"`
+	CompletionSeconds int64 `db:"completion_seconds"`
+}
+
+// TodoSeriesFilter selects the todos and the period of a series.
+type TodoSeriesFilter struct {
+	TodoFilter
+	// From and To are the first and the last day of the series, they are aligned to the interval
+	From     *time.Time `json:"from,omitempty" form:"from" time_format:"2006-01-02"`
+	To       *time.Time `json:"to,omitempty" form:"to" time_format:"2006-01-02"`
+	Interval string     `json:"interval,omitempty" form:"interval" binding:"omitempty,oneof=day week month"`
+}
+
+// TodoSeriesCount counts the todos created and completed in a period, Period is empty for
+// todos created or completed before the series.
+type TodoSeriesCount struct {
+	Period    *string `db:"period"`
+	Created   int64   `db:"created"`
+	Completed int64   `db:"completed"`
+}
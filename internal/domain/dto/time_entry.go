@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+)
+
+type TimeEntry struct {
+	ID              int64      `db:"id"`
+	TodoID          int64      `db:"todo_id"`
+	UserID          *int64     `db:"user_id"`
+	StartedAt       time.Time  `db:"started_at"`
+	EndedAt         *time.Time `db:"ended_at"`
+	Note            string     `db:"note"`
+	DurationSeconds int64      `db:"duration_seconds"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+type TimeReportRow struct {
+	Key             string `db:"key"`
+	Label           string `db:"label"`
+	TrackedSeconds  int64  `db:"tracked_seconds"`
+	EstimateMinutes *int64 `db:"estimate_minutes"`
+}
+
+type TimeReportFilter struct {
+	// From and To are the first and the last day of the report, the last 30 days by default
+	From      *time.Time `json:"from,omitempty" form:"from" time_format:"2006-01-02"`
+	To        *time.Time `json:"to,omitempty" form:"to" time_format:"2006-01-02"`
+	GroupBy   string     `json:"group_by" form:"group_by" binding:"required,oneof=day project status todo"`
+	ProjectID *int64     `json:"project_id,omitempty" form:"project_id"`
+	UserID    *int64     `json:"user_id,omitempty" form:"user_id"`
+	// VisibleTo limits the report to todos the user may read, it is set by the service
+	VisibleTo int64 `json:"-" form:"-"`
+	// TimeZone of the caller, it is set by the service
+	TimeZone string `json:"-" form:"-"`
+}
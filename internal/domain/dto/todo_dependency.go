@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+)
+
+type TodoDependency struct {
+	TodoID    int64     `db:"todo_id"`
+	BlockerID int64     `db:"blocker_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TodoIDs selects a set of todos by id.
+type TodoIDs struct {
+	IDs []int64 `json:"ids" form:"ids" binding:"required,min=1,max=500,dive,gt=0"`
+}
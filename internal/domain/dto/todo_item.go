@@ -6,20 +6,70 @@ import (
 
 const TodoTableName = "todos"
 
+const (
+	TodoSortID     = "id"
+	TodoSortManual = "manual"
+	TodoSortDate   = "date"
+)
+
 type TodoItem struct {
-	ID          int64      `db:"id"`
-	Title       string     `db:"title"`
-	Description string     `db:"description"`
-	Date        *time.Time `db:"date"`
-	Status      string     `db:"status"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   *time.Time `db:"updated_at"`
-	TotalItems  int64      `db:"total_items"`
+	ID              int64      `db:"id"`
+	Title           string     `db:"title"`
+	Description     string     `db:"description"`
+	Date            *time.Time `db:"date"`
+	DueAt           *time.Time `db:"due_at"`
+	TimeZone        string     `db:"time_zone"`
+	Status          string     `db:"status"`
+	ProjectID       *int64     `db:"project_id"`
+	CompletedAt     *time.Time `db:"completed_at"`
+	AutoComplete    bool       `db:"auto_complete"`
+	EstimateMinutes *int64     `db:"estimate_minutes"`
+	TrackedSeconds  int64      `db:"tracked_seconds"`
+	Recurrence      string     `db:"recurrence"`
+	Blocked         bool       `db:"blocked"`
+	CommentCount    int64      `db:"comment_count"`
+	Position        string     `db:"position"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       *time.Time `db:"updated_at"`
+	TotalItems      int64      `db:"total_items"`
 }
 
 type TodoFilter struct {
-	Date   *time.Time `json:"date,omitempty" form:"date"`
-	Status string     `json:"status,omitempty" form:"status"`
-	Page   int64      `json:"page,omitempty" form:"page"`
-	Limit  int64      `json:"limit,omitempty" form:"limit"`
+	// Date, Today and Overdue are evaluated in the time zone of the caller
+	Date    *time.Time `json:"date,omitempty" form:"date"`
+	Today   bool       `json:"today,omitempty" form:"today"`
+	Overdue bool       `json:"overdue,omitempty" form:"overdue"`
+	// Open selects todos which are neither completed nor cancelled
+	Open bool `json:"open,omitempty" form:"open"`
+	// DueWithinDays selects todos falling on one of the next days, today included
+	DueWithinDays int `json:"due_within_days,omitempty" form:"due_within_days" binding:"omitempty,min=1,max=366"`
+	// CompletedWithinDays selects todos completed in the last days
+	CompletedWithinDays int    `json:"completed_within_days,omitempty" form:"completed_within_days" binding:"omitempty,min=1,max=366"`
+	Status              string `json:"status,omitempty" form:"status" binding:"omitempty,oneof=pending in_progress blocked completed cancelled"`
+	Page                int64  `json:"page,omitempty" form:"page"`
+	Limit               int64  `json:"limit,omitempty" form:"limit"`
+	// ProjectID selects todos of the project, archived or not
+	ProjectID *int64 `json:"project_id,omitempty" form:"project_id"`
+	// IncludeArchived lists todos of archived projects as well
+	IncludeArchived bool `json:"include_archived,omitempty" form:"include_archived"`
+	// Sort orders the list by id (default), by manual position or by day and due time
+	Sort string `json:"sort,omitempty" form:"sort" binding:"omitempty,oneof=id manual date"`
+	// Query is a search expression, e.g. status:pending and (project:3 or due<2026-11-01)
+	Query string `json:"q,omitempty" form:"q"`
+	// VisibleTo limits the list to todos the user may read, it is set by the service
+	VisibleTo int64 `json:"-" form:"-"`
+	// TimeZone of the caller, it is set by the service
+	TimeZone string `json:"-" form:"-"`
+}
+
+// AgendaFilter selects the period and the todos of an agenda.
+type AgendaFilter struct {
+	// From and To are the first and the last day of the agenda in the time zone of the caller
+	From   *time.Time `json:"from" form:"from" time_format:"2006-01-02" binding:"required"`
+	To     *time.Time `json:"to" form:"to" time_format:"2006-01-02" binding:"required"`
+	Status string     `json:"status,omitempty" form:"status" binding:"omitempty,oneof=pending in_progress blocked completed cancelled"`
+	// ProjectID selects todos of the project, archived or not
+	ProjectID *int64 `json:"project_id,omitempty" form:"project_id"`
+	// IncludeArchived shows todos of archived projects as well
+	IncludeArchived bool `json:"include_archived,omitempty" form:"include_archived"`
 }
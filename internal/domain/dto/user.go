@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"time"
+)
+
+type User struct {
+	ID        int64     `db:"id"`
+	Email     string    `db:"email"`
+	Name      string    `db:"name"`
+	Username  *string   `db:"username"`
+	TimeZone  string    `db:"time_zone"`
+	CreatedAt time.Time `db:"created_at"`
+}
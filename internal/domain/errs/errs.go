@@ -0,0 +1,150 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// Code is a stable machine-readable error code returned to API clients.
+type Code string
+
+const (
+	CodeValidation           Code = "validation_error"
+	CodeMalformedInput       Code = "malformed_request"
+	CodeNotFound             Code = "not_found"
+	CodeConflict             Code = "conflict"
+	CodeInternal             Code = "internal_error"
+	CodeTooLarge             Code = "payload_too_large"
+	CodeRateLimited          Code = "rate_limited"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeForbidden            Code = "forbidden"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+
+	CodeIdempotencyKeyReused  Code = "idempotency_key_reused"
+	CodeIdempotencyInProgress Code = "idempotency_key_in_progress"
+)
+
+// Codes of a single field violation.
+const (
+	ViolationRequired    = "required"
+	ViolationInvalid     = "invalid"
+	ViolationInvalidType = "invalid_type"
+	ViolationOutOfRange  = "out_of_range"
+)
+
+var (
+	ErrValidation   = errors.New("validation error")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrInternal     = errors.New("internal error")
+	ErrEmptyContent = errors.New("empty content")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+type FieldViolation struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Position is the 1-based character position of the problem in a text field
+	Position int `json:"position,omitempty"`
+}
+
+// Error is an application error with a code, a human-readable message and optional
+// per-field violations. It unwraps to one of the sentinel errors above, so
+// errors.Is(err, errs.ErrValidation) keeps working for wrapped values.
+type Error struct {
+	Code       Code
+	Message    string
+	Violations []FieldViolation
+	Kind       error
+}
+
+func (e *Error) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Message
+	}
+
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Message
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func Validation(violations ...FieldViolation) *Error {
+	return &Error{
+		Code:       CodeValidation,
+		Message:    ErrValidation.Error(),
+		Violations: violations,
+		Kind:       ErrValidation,
+	}
+}
+
+func MalformedInput(message string) *Error {
+	return &Error{
+		Code:    CodeMalformedInput,
+		Message: message,
+		Kind:    ErrValidation,
+	}
+}
+
+func TooLarge(message string) *Error {
+	return &Error{
+		Code:    CodeTooLarge,
+		Message: message,
+		Kind:    ErrValidation,
+	}
+}
+
+func UnsupportedMediaType(message string) *Error {
+	return &Error{
+		Code:    CodeUnsupportedMediaType,
+		Message: message,
+		Kind:    ErrValidation,
+	}
+}
+
+func NotFound(message string) *Error {
+	return &Error{
+		Code:    CodeNotFound,
+		Message: message,
+		Kind:    ErrNotFound,
+	}
+}
+
+func Conflict(message string) *Error {
+	return &Error{
+		Code:    CodeConflict,
+		Message: message,
+		Kind:    ErrConflict,
+	}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{
+		Code:    CodeForbidden,
+		Message: message,
+		Kind:    ErrForbidden,
+	}
+}
+
+// Violations collects all problems of an input before reporting them at once.
+type Violations []FieldViolation
+
+func (v *Violations) Add(field, code, message string) {
+	*v = append(*v, FieldViolation{Field: field, Code: code, Message: message})
+}
+
+// Err returns nil when nothing was collected.
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return Validation(v...)
+}
@@ -0,0 +1,25 @@
+package model
+
+// AgendaItem is a todo on a day of the agenda. Recurring todos appear on every day they recur on,
+// the date and the due time of a repetition are those of the day.
+type AgendaItem struct {
+	TodoItem
+	// Occurrence is set for the repetitions of a recurring todo, the todo itself is its first occurrence
+	Occurrence bool `json:"occurrence,omitempty"`
+}
+
+type AgendaDay struct {
+	// Date is the day in the time zone of the caller, YYYY-MM-DD
+	Date  string       `json:"date"`
+	Count int64        `json:"count"`
+	Items []AgendaItem `json:"items"`
+}
+
+// Agenda lists every day of a period with the todos falling on it, all-day todos first,
+// then by due time.
+type Agenda struct {
+	From  string      `json:"from"`
+	To    string      `json:"to"`
+	Total int64       `json:"total"`
+	Days  []AgendaDay `json:"days"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+	"todo-list/internal/domain/errs"
+)
+
+type Scope string
+
+// Scopes are hierarchical: admin grants write, write grants read.
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+	ScopeAdmin Scope = "admin"
+)
+
+var scopeRank = map[Scope]int{
+	ScopeRead:  1,
+	ScopeWrite: 2,
+	ScopeAdmin: 3,
+}
+
+func (s Scope) Valid() bool {
+	_, ok := scopeRank[s]
+	return ok
+}
+
+type APIKey struct {
+	ID         int64      `json:"id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Prefix     string     `json:"prefix,omitempty"`
+	Scopes     []Scope    `json:"scopes,omitempty"`
+	UserID     *int64     `json:"user_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) Validate() error {
+	var v errs.Violations
+	if k.Name == "" {
+		v.Add("name", errs.ViolationRequired, "name must be set")
+	}
+	if len(k.Scopes) == 0 {
+		v.Add("scopes", errs.ViolationRequired, "at least one scope must be set")
+	}
+	for _, s := range k.Scopes {
+		if !s.Valid() {
+			v.Add("scopes", errs.ViolationInvalid, "unknown scope "+string(s))
+		}
+	}
+	return v.Err()
+}
+
+// IssuedAPIKey is returned once on creation or rotation, only the hash of Key is stored.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Principal is the authenticated caller of a request. UserID, Email and TimeZone are
+// set for keys issued to a user.
+type Principal struct {
+	KeyID    int64
+	UserID   int64
+	Email    string
+	TimeZone string
+	Name     string
+	Scopes   []Scope
+	// Anonymous is set for requests without credentials while authentication is not required
+	Anonymous bool
+}
+
+// Can reports whether the principal has the scope or a wider one.
+func (p Principal) Can(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if scopeRank[s] >= scopeRank[scope] {
+			return true
+		}
+	}
+	return false
+}
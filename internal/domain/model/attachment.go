@@ -0,0 +1,54 @@
+package model
+
+import (
+	"path"
+	"strings"
+	"time"
+	"todo-list/internal/domain/errs"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxFileNameLength is the maximum length of an attachment file name in characters.
+const MaxFileNameLength = 255
+
+// Attachment is a file attached to a todo, its contents are kept in the blob storage.
+type Attachment struct {
+	ID     int64 `json:"id,omitempty"`
+	TodoID int64 `json:"todo_id,omitempty"`
+	// UploaderID is empty for files uploaded with keys without a user
+	UploaderID *int64 `json:"uploader_id,omitempty"`
+	FileName   string `json:"file_name"`
+	// ContentType is sniffed from the contents, the type sent by the client is not trusted
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// NormalizeFileName drops directories and control characters from a client supplied file name.
+func NormalizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+func (a *Attachment) Validate() error {
+	var v errs.Violations
+	switch {
+	case a.FileName == "":
+		v.Add("file_name", errs.ViolationRequired, "file name must be set")
+	case utf8.RuneCountInString(a.FileName) > MaxFileNameLength:
+		v.Add("file_name", errs.ViolationOutOfRange, "file name must not be longer than 255 characters")
+	}
+	return v.Err()
+}
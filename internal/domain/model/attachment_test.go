@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNormalizeFileName(t *testing.T) {
+	for in, want := range map[string]string{
+		"report.pdf":           "report.pdf",
+		"../../etc/passwd":     "passwd",
+		`C:\Users\ann\a b.txt`: "a b.txt",
+		" notes\r\n.md ":       "notes.md",
+		"..":                   "",
+		"/":                    "",
+		"отчёт.docx":           "отчёт.docx",
+	} {
+		require.Equal(t, want, NormalizeFileName(in), in)
+	}
+}
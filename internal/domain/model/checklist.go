@@ -0,0 +1,113 @@
+package model
+
+import (
+	"time"
+	"todo-list/internal/domain/errs"
+	"unicode/utf8"
+)
+
+const (
+	// MaxChecklistItemLength is the maximum length of a checklist item title in characters.
+	MaxChecklistItemLength = 500
+	// MaxChecklistItems is the maximum number of items in the checklist of a todo.
+	MaxChecklistItems = 200
+)
+
+// ChecklistItem is a step of the procedure a todo consists of.
+type ChecklistItem struct {
+	ID     int64  `json:"id,omitempty"`
+	TodoID int64  `json:"todo_id,omitempty"`
+	Title  string `json:"title" form:"title"`
+	// Checked is set when the step is done, CheckedAt records when
+	Checked   bool       `json:"checked" form:"checked"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	// Position orders the items of a checklist, ascending
+	Position  int64     `json:"position"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func validateChecklistTitle(v *errs.Violations, title string) {
+	switch {
+	case title == "":
+		v.Add("title", errs.ViolationRequired, "title must be set")
+	case utf8.RuneCountInString(title) > MaxChecklistItemLength:
+		v.Add("title", errs.ViolationOutOfRange, "title must not be longer than 500 characters")
+	}
+}
+
+func (i *ChecklistItem) Validate() error {
+	var v errs.Violations
+	validateChecklistTitle(&v, i.Title)
+	return v.Err()
+}
+
+const (
+	ChecklistTitleField   = "title"
+	ChecklistCheckedField = "checked"
+)
+
+// ChecklistItemPatch changes a checklist item, fields which are not set are left unchanged.
+type ChecklistItemPatch struct {
+	Title   *string `json:"title,omitempty"`
+	Checked *bool   `json:"checked,omitempty"`
+}
+
+func (p *ChecklistItemPatch) Validate() error {
+	var v errs.Violations
+	if p.Title == nil && p.Checked == nil {
+		v.Add("", errs.ViolationRequired, "one of title and checked must be set")
+	}
+	if p.Title != nil {
+		validateChecklistTitle(&v, *p.Title)
+	}
+	return v.Err()
+}
+
+// Fields returns the fields set in the patch.
+func (p *ChecklistItemPatch) Fields() []string {
+	res := make([]string, 0, 2)
+	if p.Title != nil {
+		res = append(res, ChecklistTitleField)
+	}
+	if p.Checked != nil {
+		res = append(res, ChecklistCheckedField)
+	}
+	return res
+}
+
+// ChecklistOrder lists all item ids of a checklist in the new order.
+type ChecklistOrder struct {
+	IDs []int64 `json:"ids" binding:"required,max=200,dive,gt=0"`
+}
+
+// Checklist is the ordered checklist of a todo together with the todo status, which
+// changes when checking the last item auto-completes the todo.
+type Checklist struct {
+	TodoID       int64           `json:"todo_id"`
+	Status       TodoStatus      `json:"status"`
+	AutoComplete bool            `json:"auto_complete"`
+	Checked      int             `json:"checked"`
+	Total        int             `json:"total"`
+	Items        []ChecklistItem `json:"items"`
+}
+
+func NewChecklist(todo TodoItem, items []ChecklistItem) Checklist {
+	res := Checklist{
+		TodoID:       todo.ID,
+		Status:       todo.Status,
+		AutoComplete: todo.AutoComplete,
+		Total:        len(items),
+		Items:        items,
+	}
+	for _, item := range items {
+		if item.Checked {
+			res.Checked++
+		}
+	}
+	return res
+}
+
+// Done reports whether the checklist has items and all of them are checked.
+func (c Checklist) Done() bool {
+	return c.Total > 0 && c.Checked == c.Total
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todo-list/internal/util/pointer"
+)
+
+func TestChecklistItemPatch_Validate(t *testing.T) {
+	require.Error(t, (&ChecklistItemPatch{}).Validate())
+	require.Error(t, (&ChecklistItemPatch{Title: pointer.Pointer("")}).Validate())
+	require.Error(t, (&ChecklistItemPatch{Title: pointer.Pointer(strings.Repeat("ы", MaxChecklistItemLength+1))}).Validate())
+	require.NoError(t, (&ChecklistItemPatch{Checked: pointer.Pointer(false)}).Validate())
+
+	p := ChecklistItemPatch{Title: pointer.Pointer("fill it"), Checked: pointer.Pointer(true)}
+	require.Equal(t, []string{ChecklistTitleField, ChecklistCheckedField}, p.Fields())
+}
+
+func TestChecklist_Done(t *testing.T) {
+	todo := TodoItem{ID: 1, Status: TodoStatusPending}
+	require.False(t, NewChecklist(todo, nil).Done())
+	require.False(t, NewChecklist(todo, []ChecklistItem{{Checked: true}, {}}).Done())
+	require.True(t, NewChecklist(todo, []ChecklistItem{{Checked: true}, {Checked: true}}).Done())
+}
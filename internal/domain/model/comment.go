@@ -0,0 +1,55 @@
+package model
+
+import (
+	"regexp"
+	"time"
+	"todo-list/internal/domain/errs"
+	"unicode/utf8"
+)
+
+// MaxCommentLength is the maximum length of a comment body in characters.
+const MaxCommentLength = 10000
+
+// Comment is a markdown message in the discussion of a todo.
+type Comment struct {
+	ID     int64 `json:"id,omitempty"`
+	TodoID int64 `json:"todo_id,omitempty"`
+	// AuthorID is empty for comments written with keys without a user
+	AuthorID   *int64 `json:"author_id,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+	// Body is markdown, @username mentions notify the mentioned users
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+type CommentPagination Pagination[Comment]
+
+func (c *Comment) Validate() error {
+	var v errs.Violations
+	switch {
+	case c.Body == "":
+		v.Add("body", errs.ViolationRequired, "body must be set")
+	case utf8.RuneCountInString(c.Body) > MaxCommentLength:
+		v.Add("body", errs.ViolationOutOfRange, "body must not be longer than 10000 characters")
+	}
+	return v.Err()
+}
+
+// mentionPattern matches @username not preceded by a word character, so emails are
+// not taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_]{2,32})\b`)
+
+// Mentions returns the normalized usernames mentioned in the body, each once.
+func (c *Comment) Mentions() []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(c.Body, -1) {
+		name := NormalizeUsername(m[1])
+		if !seen[name] {
+			seen[name] = true
+			res = append(res, name)
+		}
+	}
+	return res
+}
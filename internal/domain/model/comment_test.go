@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestComment_Mentions(t *testing.T) {
+	c := Comment{Body: "@ann, ping @Bob and @ann again; mail me at carl@example.com"}
+	require.Equal(t, []string{"ann", "bob"}, c.Mentions())
+}
+
+func TestComment_Validate(t *testing.T) {
+	require.Error(t, (&Comment{}).Validate())
+	require.Error(t, (&Comment{Body: strings.Repeat("ы", MaxCommentLength+1)}).Validate())
+	require.NoError(t, (&Comment{Body: strings.Repeat("ы", MaxCommentLength)}).Validate())
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"regexp"
+	"time"
+	"todo-list/internal/domain/errs"
+)
+
+type ProjectRole string
+
+// Roles are hierarchical: owner manages the project and its members, editor modifies
+// todos, viewer only reads them.
+const (
+	ProjectRoleViewer ProjectRole = "viewer"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleOwner  ProjectRole = "owner"
+)
+
+var projectRoleRank = map[ProjectRole]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleEditor: 2,
+	ProjectRoleOwner:  3,
+}
+
+func (r ProjectRole) Valid() bool {
+	_, ok := projectRoleRank[r]
+	return ok
+}
+
+// Can reports whether the role grants the permissions of role other.
+func (r ProjectRole) Can(other ProjectRole) bool {
+	return r.Valid() && projectRoleRank[r] >= projectRoleRank[other]
+}
+
+func validateRole(v *errs.Violations, role ProjectRole) {
+	if role == "" {
+		v.Add("role", errs.ViolationRequired, "role must be set")
+		return
+	}
+	if !role.Valid() {
+		v.Add("role", errs.ViolationInvalid, "unknown role "+string(role))
+	}
+}
+
+const (
+	ProjectNameField     = "name"
+	ProjectColorField    = "color"
+	ProjectArchivedField = "archived"
+	ProjectPositionField = "position"
+)
+
+var projectColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Project struct {
+	ID    int64  `json:"id,omitempty"`
+	Name  string `json:"name,omitempty" form:"name"`
+	Color string `json:"color,omitempty" form:"color"`
+	// Archived projects and their todos are hidden from default lists
+	Archived *bool `json:"archived,omitempty" form:"archived"`
+	// Position orders projects in lists, ascending
+	Position *int64 `json:"position,omitempty" form:"position"`
+	// OwnerID is required only when the project is created by a caller not bound to a user
+	OwnerID int64 `json:"owner_id,omitempty" form:"owner_id"`
+	// Role of the caller in the project
+	Role ProjectRole `json:"role,omitempty"`
+	// PendingTodos counts todos which are neither completed nor cancelled
+	PendingTodos   int64      `json:"pending_todos"`
+	CompletedTodos int64      `json:"completed_todos"`
+	CreatedAt      time.Time  `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (p *Project) validateColor(v *errs.Violations) {
+	if p.Color != "" && !projectColor.MatchString(p.Color) {
+		v.Add(ProjectColorField, errs.ViolationInvalid, "color must be a hex color like #1a2b3c")
+	}
+}
+
+func (p *Project) Validate() error {
+	var v errs.Violations
+	if p.Name == "" {
+		v.Add(ProjectNameField, errs.ViolationRequired, "name must be set")
+	}
+	p.validateColor(&v)
+	return v.Err()
+}
+
+// ValidateUpdate checks the fields set for a partial update.
+func (p *Project) ValidateUpdate() error {
+	var v errs.Violations
+	p.validateColor(&v)
+	return v.Err()
+}
+
+func (p *Project) EditableFields() []string {
+	res := make([]string, 0)
+	if p.Name != "" {
+		res = append(res, ProjectNameField)
+	}
+
+	if p.Color != "" {
+		res = append(res, ProjectColorField)
+	}
+
+	if p.Archived != nil {
+		res = append(res, ProjectArchivedField)
+	}
+
+	if p.Position != nil {
+		res = append(res, ProjectPositionField)
+	}
+
+	return res
+}
+
+type ProjectMember struct {
+	ProjectID int64       `json:"project_id,omitempty"`
+	UserID    int64       `json:"user_id,omitempty"`
+	Email     string      `json:"email,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Role      ProjectRole `json:"role,omitempty"`
+	CreatedAt time.Time   `json:"created_at,omitempty"`
+}
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+)
+
+type Invitation struct {
+	ID          int64            `json:"id,omitempty"`
+	ProjectID   int64            `json:"project_id,omitempty"`
+	ProjectName string           `json:"project_name,omitempty"`
+	Email       string           `json:"email,omitempty"`
+	Role        ProjectRole      `json:"role,omitempty"`
+	Status      InvitationStatus `json:"status,omitempty"`
+	InvitedBy   *int64           `json:"invited_by,omitempty"`
+	CreatedAt   time.Time        `json:"created_at,omitempty"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+}
+
+func (i *Invitation) Validate() error {
+	var v errs.Violations
+	validateEmail(&v, i.Email)
+	validateRole(&v, i.Role)
+	return v.Err()
+}
+
+func (m *ProjectMember) Validate() error {
+	var v errs.Violations
+	validateRole(&v, m.Role)
+	return v.Err()
+}
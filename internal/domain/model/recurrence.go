@@ -0,0 +1,200 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies of a recurrence rule.
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// MaxRecurrenceInterval limits the INTERVAL of a recurrence rule.
+const MaxRecurrenceInterval = 1000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is a parsed recurrence rule, a subset of the RRULE of RFC 5545: FREQ (DAILY,
+// WEEKLY, MONTHLY or YEARLY), INTERVAL, COUNT, UNTIL (a date, YYYYMMDD) and BYDAY for
+// weekly rules, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+type Recurrence struct {
+	Frequency string
+	Interval  int
+	// Count limits the number of occurrences, the first one included
+	Count int
+	// Until is the last day an occurrence may fall on, midnight UTC
+	Until *time.Time
+	// ByDay are the days of the week of weekly rules, Monday first
+	ByDay []time.Weekday
+}
+
+// ParseRecurrence parses a recurrence rule, an "RRULE:" prefix is allowed.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	res := Recurrence{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Recurrence{}, fmt.Errorf("rule part %q is not KEY=VALUE", part)
+		}
+		if seen[key] {
+			return Recurrence{}, fmt.Errorf("rule part %s is repeated", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			switch value {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				res.Frequency = value
+			default:
+				return Recurrence{}, errors.New("FREQ must be one of DAILY, WEEKLY, MONTHLY and YEARLY")
+			}
+		case "INTERVAL":
+			if res.Interval, err = strconv.Atoi(value); err != nil || res.Interval < 1 || res.Interval > MaxRecurrenceInterval {
+				return Recurrence{}, fmt.Errorf("INTERVAL must be a number from 1 to %d", MaxRecurrenceInterval)
+			}
+		case "COUNT":
+			if res.Count, err = strconv.Atoi(value); err != nil || res.Count < 1 {
+				return Recurrence{}, errors.New("COUNT must be a positive number")
+			}
+		case "UNTIL":
+			until, err := time.Parse("20060102", value)
+			if err != nil {
+				return Recurrence{}, errors.New("UNTIL must be a date in the form YYYYMMDD")
+			}
+			res.Until = &until
+		case "BYDAY":
+			for _, name := range strings.Split(value, ",") {
+				day, ok := weekdays[name]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("BYDAY day %q must be one of MO, TU, WE, TH, FR, SA and SU", name)
+				}
+				res.ByDay = append(res.ByDay, day)
+			}
+		default:
+			return Recurrence{}, fmt.Errorf("rule part %s is not supported", key)
+		}
+	}
+
+	switch {
+	case res.Frequency == "":
+		return Recurrence{}, errors.New("FREQ must be set")
+	case res.Count != 0 && res.Until != nil:
+		return Recurrence{}, errors.New("only one of COUNT and UNTIL may be set")
+	case len(res.ByDay) != 0 && res.Frequency != FrequencyWeekly:
+		return Recurrence{}, errors.New("BYDAY is supported for WEEKLY rules only")
+	}
+	sort.Slice(res.ByDay, func(i, j int) bool { return mondayFirst(res.ByDay[i]) < mondayFirst(res.ByDay[j]) })
+	for i := 1; i < len(res.ByDay); i++ {
+		if res.ByDay[i] == res.ByDay[i-1] {
+			return Recurrence{}, errors.New("BYDAY days are repeated")
+		}
+	}
+	return res, nil
+}
+
+// mondayFirst returns the index of the day in a week starting on Monday.
+func mondayFirst(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+// Occurrences returns the days between from and to, both included, the rule recurs on when it
+// starts on start. Days are midnight UTC like todo dates. Monthly and yearly rules skip months
+// and years without the day of start, as RFC 5545 does.
+func (r Recurrence) Occurrences(start, from, to time.Time) []time.Time {
+	res := make([]time.Time, 0)
+	if r.Until != nil && r.Until.Before(to) {
+		to = *r.Until
+	}
+	if start.After(to) {
+		return res
+	}
+
+	n := 0
+	// emit records an occurrence, false is returned once no more occurrences are wanted
+	emit := func(day time.Time) bool {
+		if day.After(to) {
+			return false
+		}
+		n++
+		if r.Count != 0 && n > r.Count {
+			return false
+		}
+		if !day.Before(from) {
+			res = append(res, day)
+		}
+		return true
+	}
+
+	// without COUNT the periods before from are skipped
+	days := int(from.Sub(start).Hours() / 24)
+	skip := func(periodDays int) int {
+		if r.Count != 0 || days <= 0 {
+			return 0
+		}
+		return days / (periodDays * r.Interval)
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		for i := skip(1); emit(start.AddDate(0, 0, i*r.Interval)); i++ {
+		}
+	case FrequencyWeekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+		monday := start.AddDate(0, 0, -mondayFirst(start.Weekday()))
+		for i := skip(7); ; i++ {
+			week := monday.AddDate(0, 0, 7*i*r.Interval)
+			for _, d := range byDay {
+				day := week.AddDate(0, 0, mondayFirst(d))
+				if day.Before(start) {
+					continue
+				}
+				if !emit(day) {
+					return res
+				}
+			}
+		}
+	case FrequencyMonthly, FrequencyYearly:
+		months := r.Interval
+		if r.Frequency == FrequencyYearly {
+			months *= 12
+		}
+		for i := 0; ; i++ {
+			first := time.Date(start.Year(), start.Month()+time.Month(i*months), 1, 0, 0, 0, 0, time.UTC)
+			if first.After(to) {
+				return res
+			}
+			day := first.AddDate(0, 0, start.Day()-1)
+			if day.Month() != first.Month() {
+				continue
+			}
+			if !emit(day) {
+				return res
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func days(ts []time.Time) []string {
+	res := make([]string, len(ts))
+	for i, t := range ts {
+		res[i] = t.Format(time.DateOnly)
+	}
+	return res
+}
+
+func TestParseRecurrence(t *testing.T) {
+	r, err := ParseRecurrence("RRULE:freq=weekly;interval=2;byday=TH,MO")
+	require.NoError(t, err)
+	require.Equal(t, Recurrence{Frequency: FrequencyWeekly, Interval: 2, ByDay: []time.Weekday{time.Monday, time.Thursday}}, r)
+
+	r, err = ParseRecurrence("FREQ=DAILY;UNTIL=20261231")
+	require.NoError(t, err)
+	require.Equal(t, day("2026-12-31"), *r.Until)
+
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=MO,MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYMONTH=1",
+		"FREQ=DAILY;UNTIL=2026-12-31",
+	} {
+		_, err = ParseRecurrence(rule)
+		require.Error(t, err, rule)
+	}
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	tests := []struct {
+		rule     string
+		start    string
+		from, to string
+		expected []string
+	}{
+		{"FREQ=DAILY;INTERVAL=3", "2026-10-01", "2026-10-05", "2026-10-12", []string{"2026-10-07", "2026-10-10"}},
+		{"FREQ=DAILY;COUNT=3", "2026-10-01", "2026-10-02", "2026-10-12", []string{"2026-10-02", "2026-10-03"}},
+		{"FREQ=DAILY;UNTIL=20261003", "2026-10-01", "2026-10-01", "2026-10-12", []string{"2026-10-01", "2026-10-02", "2026-10-03"}},
+		// Wednesday start, the Monday before it is skipped
+		{"FREQ=WEEKLY;BYDAY=MO,FR", "2026-10-07", "2026-10-01", "2026-10-20", []string{"2026-10-09", "2026-10-12", "2026-10-16", "2026-10-19"}},
+		{"FREQ=WEEKLY;INTERVAL=2", "2026-10-07", "2026-10-08", "2026-11-30", []string{"2026-10-21", "2026-11-04", "2026-11-18"}},
+		{"FREQ=MONTHLY", "2026-01-31", "2026-01-01", "2026-05-31", []string{"2026-01-31", "2026-03-31", "2026-05-31"}},
+		{"FREQ=YEARLY", "2024-02-29", "2024-01-01", "2028-12-31", []string{"2024-02-29", "2028-02-29"}},
+		{"FREQ=DAILY", "2026-11-01", "2026-10-01", "2026-10-31", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, days(r.Occurrences(day(tt.start), day(tt.from), day(tt.to))))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+	"todo-list/internal/domain/errs"
+)
+
+// Reminder notifies about a todo either at RemindAt or OffsetSeconds relative to the
+// date of the todo (negative is before).
+type Reminder struct {
+	ID            int64      `json:"id,omitempty"`
+	TodoID        int64      `json:"todo_id,omitempty"`
+	UserID        *int64     `json:"user_id,omitempty"`
+	RemindAt      *time.Time `json:"remind_at,omitempty"`
+	OffsetSeconds *int64     `json:"offset_seconds,omitempty"`
+	// DueAt is when the reminder fires
+	DueAt     *time.Time `json:"due_at,omitempty"`
+	Attempts  int        `json:"attempts,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	FailedAt  *time.Time `json:"failed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+}
+
+func (r *Reminder) Validate() error {
+	var v errs.Violations
+	switch {
+	case r.RemindAt == nil && r.OffsetSeconds == nil:
+		v.Add("remind_at", errs.ViolationRequired, "one of remind_at and offset_seconds must be set")
+	case r.RemindAt != nil && r.OffsetSeconds != nil:
+		v.Add("offset_seconds", errs.ViolationInvalid, "only one of remind_at and offset_seconds may be set")
+	}
+	return v.Err()
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/util/search"
+	"unicode/utf8"
+)
+
+// MaxSavedFilterNameLength is the maximum length of the name of a saved filter in characters.
+const MaxSavedFilterNameLength = 100
+
+// MaxTodoQueryDays limits the relative periods of a todo query.
+const MaxTodoQueryDays = 366
+
+// TodoQuery is a stored definition of a todo list filter. Relative periods are evaluated
+// when the list is shown, in the time zone of the caller.
+type TodoQuery struct {
+	Status          string `json:"status,omitempty"`
+	ProjectID       *int64 `json:"project_id,omitempty"`
+	IncludeArchived bool   `json:"include_archived,omitempty"`
+	// Open selects todos which are neither completed nor cancelled
+	Open    bool `json:"open,omitempty"`
+	Today   bool `json:"today,omitempty"`
+	Overdue bool `json:"overdue,omitempty"`
+	// DueWithinDays selects todos falling on one of the next days, today included
+	DueWithinDays int `json:"due_within_days,omitempty"`
+	// CompletedWithinDays selects todos completed in the last days
+	CompletedWithinDays int `json:"completed_within_days,omitempty"`
+	// Sort orders the list by id (default), by manual position or by day and due time
+	Sort string `json:"sort,omitempty" enums:"id,manual,date"`
+	// Query is a search expression, its fields are checked when the list is shown
+	Query string `json:"q,omitempty"`
+}
+
+// violations adds the invalid fields of the query to v, prefixed with prefix.
+func (q *TodoQuery) violations(prefix string, v *errs.Violations) {
+	if q.Status != "" && !TodoStatus(q.Status).Valid() {
+		v.Add(prefix+TodoStatusField, errs.ViolationInvalid, "status must be one of "+todoStatusList())
+	}
+	if q.ProjectID != nil && *q.ProjectID <= 0 {
+		v.Add(prefix+TodoProjectIDField, errs.ViolationInvalid, "project_id must be positive")
+	}
+	if q.DueWithinDays < 0 || q.DueWithinDays > MaxTodoQueryDays {
+		v.Add(prefix+"due_within_days", errs.ViolationOutOfRange, "due_within_days must be from 1 to 366")
+	}
+	if q.CompletedWithinDays < 0 || q.CompletedWithinDays > MaxTodoQueryDays {
+		v.Add(prefix+"completed_within_days", errs.ViolationOutOfRange, "completed_within_days must be from 1 to 366")
+	}
+	switch q.Sort {
+	case "", "id", "manual", "date":
+	default:
+		v.Add(prefix+"sort", errs.ViolationInvalid, "sort must be one of id, manual and date")
+	}
+	if _, err := search.Parse(q.Query); err != nil {
+		e := err.(*search.Error)
+		*v = append(*v, errs.FieldViolation{Field: prefix + "q", Code: errs.ViolationInvalid, Message: e.Error(), Position: e.Pos})
+	}
+}
+
+// SavedFilter is a named todo query of a user.
+type SavedFilter struct {
+	ID        int64      `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	Filter    TodoQuery  `json:"filter"`
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+const SavedFilterNameField = "name"
+
+// Validate trims the name and reports all invalid fields at once.
+func (f *SavedFilter) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+
+	var v errs.Violations
+	switch {
+	case f.Name == "":
+		v.Add(SavedFilterNameField, errs.ViolationRequired, "name must be set")
+	case utf8.RuneCountInString(f.Name) > MaxSavedFilterNameLength:
+		v.Add(SavedFilterNameField, errs.ViolationOutOfRange, "name must not be longer than 100 characters")
+	}
+	f.Filter.violations("filter.", &v)
+	return v.Err()
+}
+
+// SmartList is a todo list built into the service.
+type SmartList struct {
+	Key    string    `json:"key"`
+	Name   string    `json:"name"`
+	Filter TodoQuery `json:"filter"`
+}
+
+// SmartLists are the built-in todo lists.
+var SmartLists = []SmartList{
+	{Key: "today", Name: "Today", Filter: TodoQuery{Today: true, Open: true, Sort: "date"}},
+	{Key: "upcoming", Name: "Upcoming", Filter: TodoQuery{DueWithinDays: 7, Open: true, Sort: "date"}},
+	{Key: "overdue", Name: "Overdue", Filter: TodoQuery{Overdue: true, Sort: "date"}},
+	{Key: "completed", Name: "Completed recently", Filter: TodoQuery{Status: TodoStatusCompleted, CompletedWithinDays: 7}},
+}
+
+// FindSmartList returns the built-in list with the key.
+func FindSmartList(key string) (SmartList, bool) {
+	for _, l := range SmartLists {
+		if l.Key == key {
+			return l, true
+		}
+	}
+	return SmartList{}, false
+}
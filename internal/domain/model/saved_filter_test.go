@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todo-list/internal/domain/errs"
+)
+
+func TestSavedFilter_Validate(t *testing.T) {
+	f := SavedFilter{Name: "  work  ", Filter: TodoQuery{Open: true, Sort: "date"}}
+	require.NoError(t, f.Validate())
+	require.Equal(t, "work", f.Name)
+
+	require.Error(t, (&SavedFilter{Name: strings.Repeat("ы", MaxSavedFilterNameLength+1)}).Validate())
+
+	err := (&SavedFilter{Filter: TodoQuery{Status: "later", DueWithinDays: MaxTodoQueryDays + 1, Sort: "title"}}).Validate()
+	var e *errs.Error
+	require.True(t, errors.As(err, &e))
+	require.Len(t, e.Violations, 4)
+	require.Equal(t, SavedFilterNameField, e.Violations[0].Field)
+	require.Equal(t, "filter.status", e.Violations[1].Field)
+
+	err = (&SavedFilter{Name: "work", Filter: TodoQuery{Query: "status:pending and (project:1"}}).Validate()
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, []errs.FieldViolation{{
+		Field:    "filter.q",
+		Code:     errs.ViolationInvalid,
+		Message:  "expected ')' to close '(' at position 20, got end of expression at position 30",
+		Position: 30,
+	}}, e.Violations)
+}
+
+func TestFindSmartList(t *testing.T) {
+	for _, l := range SmartLists {
+		res, ok := FindSmartList(l.Key)
+		require.True(t, ok)
+		require.Equal(t, l.Name, res.Name)
+		require.NoError(t, (&SavedFilter{Name: l.Name, Filter: l.Filter}).Validate())
+	}
+
+	_, ok := FindSmartList("someday")
+	require.False(t, ok)
+}
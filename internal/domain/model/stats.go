@@ -0,0 +1,38 @@
+package model
+
+// Intervals of a todo series.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// TodoStats summarizes the todos matching a filter.
+type TodoStats struct {
+	Total int64 `json:"total"`
+	// ByStatus counts the todos in each status, statuses without todos are reported as 0
+	ByStatus map[string]int64 `json:"by_status"`
+	// Overdue counts the open todos due in the past
+	Overdue   int64 `json:"overdue"`
+	Completed int64 `json:"completed"`
+	// AvgCompletionSeconds is the average time from creation to completion of the completed todos
+	AvgCompletionSeconds *int64 `json:"avg_completion_seconds,omitempty"`
+}
+
+// TodoSeriesPoint counts the todos created and completed in a period starting on Period (YYYY-MM-DD).
+// The totals include todos created and completed before the series, plotted together they form a burn-up chart.
+type TodoSeriesPoint struct {
+	Period         string `json:"period"`
+	Created        int64  `json:"created"`
+	Completed      int64  `json:"completed"`
+	TotalCreated   int64  `json:"total_created"`
+	TotalCompleted int64  `json:"total_completed"`
+}
+
+type TodoSeries struct {
+	Interval string `json:"interval"`
+	// From and To are the first and the last day of the series in the time zone of the caller
+	From   string            `json:"from"`
+	To     string            `json:"to"`
+	Points []TodoSeriesPoint `json:"points"`
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"time"
+	"todo-list/internal/domain/errs"
+	"unicode/utf8"
+)
+
+// MaxTimeEntryNoteLength is the maximum length of a time entry note in characters.
+const MaxTimeEntryNoteLength = 1000
+
+// TimeEntry is a span of time a user worked on a todo, tracked with a timer or entered manually.
+type TimeEntry struct {
+	ID     int64 `json:"id,omitempty"`
+	TodoID int64 `json:"todo_id,omitempty"`
+	// UserID is empty for time tracked with keys without a user
+	UserID    *int64    `json:"user_id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	// EndedAt is empty while the timer runs
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+	Note    string     `json:"note,omitempty"`
+	// DurationSeconds is the tracked time, up to now while the timer runs
+	DurationSeconds int64     `json:"duration_seconds"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+}
+
+const (
+	TimeEntryStartedAtField = "started_at"
+	TimeEntryEndedAtField   = "ended_at"
+	TimeEntryNoteField      = "note"
+)
+
+// Running reports whether the timer of the entry runs.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+func (e *TimeEntry) Validate(now time.Time) error {
+	var v errs.Violations
+	switch {
+	case e.StartedAt.IsZero():
+		v.Add(TimeEntryStartedAtField, errs.ViolationRequired, "started_at must be set")
+	case e.StartedAt.After(now):
+		v.Add(TimeEntryStartedAtField, errs.ViolationOutOfRange, "started_at must not be in the future")
+	}
+	if e.EndedAt != nil {
+		switch {
+		case e.EndedAt.Before(e.StartedAt):
+			v.Add(TimeEntryEndedAtField, errs.ViolationOutOfRange, "ended_at must not be before started_at")
+		case e.EndedAt.After(now):
+			v.Add(TimeEntryEndedAtField, errs.ViolationOutOfRange, "ended_at must not be in the future")
+		}
+	}
+	if utf8.RuneCountInString(e.Note) > MaxTimeEntryNoteLength {
+		v.Add(TimeEntryNoteField, errs.ViolationOutOfRange, "note must not be longer than 1000 characters")
+	}
+	return v.Err()
+}
+
+// TimeEntryPatch changes a time entry, fields which are not set are left unchanged.
+// Setting ended_at stops a running timer.
+type TimeEntryPatch struct {
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+}
+
+// Fields returns the fields set in the patch.
+func (p *TimeEntryPatch) Fields() []string {
+	res := make([]string, 0, 3)
+	if p.StartedAt != nil {
+		res = append(res, TimeEntryStartedAtField)
+	}
+	if p.EndedAt != nil {
+		res = append(res, TimeEntryEndedAtField)
+	}
+	if p.Note != nil {
+		res = append(res, TimeEntryNoteField)
+	}
+	return res
+}
+
+// Apply returns the entry with the fields of the patch.
+func (p *TimeEntryPatch) Apply(e TimeEntry) TimeEntry {
+	if p.StartedAt != nil {
+		e.StartedAt = *p.StartedAt
+	}
+	if p.EndedAt != nil {
+		e.EndedAt = p.EndedAt
+	}
+	if p.Note != nil {
+		e.Note = *p.Note
+	}
+	return e
+}
+
+// Groupings of a time report.
+const (
+	TimeReportByDay     = "day"
+	TimeReportByProject = "project"
+	TimeReportByStatus  = "status"
+	TimeReportByTodo    = "todo"
+)
+
+// TimeReportRow is the time tracked in a group of a report. Key is the day (YYYY-MM-DD),
+// the project id (empty for todos outside of projects), the todo status or the todo id.
+type TimeReportRow struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+	// TrackedSeconds is the time tracked in the period
+	TrackedSeconds int64 `json:"tracked_seconds"`
+	// EstimateMinutes is the sum of the estimates of the todos of the group, not reported per day
+	EstimateMinutes *int64 `json:"estimate_minutes,omitempty"`
+}
+
+type TimeReport struct {
+	GroupBy string `json:"group_by"`
+	// From and To are the first and the last day of the report in the time zone of the caller
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Rows         []TimeReportRow `json:"rows"`
+}
+
+// TimerStart is the optional body of a request starting a timer.
+type TimerStart struct {
+	Note string `json:"note,omitempty"`
+}
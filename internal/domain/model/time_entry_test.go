@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/util/pointer"
+)
+
+func TestTimeEntry_Validate(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	require.Error(t, (&TimeEntry{}).Validate(now))
+	require.Error(t, (&TimeEntry{StartedAt: now.Add(time.Minute)}).Validate(now))
+	require.Error(t, (&TimeEntry{StartedAt: hourAgo, EndedAt: pointer.Pointer(hourAgo.Add(-time.Second))}).Validate(now))
+	require.Error(t, (&TimeEntry{StartedAt: hourAgo, EndedAt: pointer.Pointer(now.Add(time.Minute))}).Validate(now))
+	require.Error(t, (&TimeEntry{StartedAt: hourAgo, Note: strings.Repeat("ы", MaxTimeEntryNoteLength+1)}).Validate(now))
+	require.NoError(t, (&TimeEntry{StartedAt: hourAgo, EndedAt: &now}).Validate(now))
+	require.NoError(t, (&TimeEntry{StartedAt: hourAgo}).Validate(now))
+}
+
+func TestTimeEntryPatch_Apply(t *testing.T) {
+	now := time.Now()
+	e := TimeEntry{ID: 1, StartedAt: now.Add(-time.Hour), Note: "draft"}
+	p := TimeEntryPatch{EndedAt: &now}
+	require.Equal(t, []string{TimeEntryEndedAtField}, p.Fields())
+
+	res := p.Apply(e)
+	require.False(t, res.Running())
+	require.Equal(t, "draft", res.Note)
+	require.True(t, e.Running())
+}
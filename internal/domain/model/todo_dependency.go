@@ -0,0 +1,139 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"todo-list/internal/domain/errs"
+)
+
+// ErrDependencyCycle reports todos which block each other.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// TodoDependency means that the todo can not be completed before its blocker is done.
+type TodoDependency struct {
+	TodoID    int64 `json:"todo_id"`
+	BlockerID int64 `json:"blocker_id"`
+}
+
+// TodoLink adds a todo the todo is blocked by or a todo it blocks.
+type TodoLink struct {
+	BlockedBy int64 `json:"blocked_by,omitempty"`
+	Blocks    int64 `json:"blocks,omitempty"`
+}
+
+func (l *TodoLink) Validate() error {
+	var v errs.Violations
+	switch {
+	case l.BlockedBy == 0 && l.Blocks == 0:
+		v.Add("blocked_by", errs.ViolationRequired, "one of blocked_by and blocks must be set")
+	case l.BlockedBy != 0 && l.Blocks != 0:
+		v.Add("blocks", errs.ViolationInvalid, "only one of blocked_by and blocks may be set")
+	case l.BlockedBy < 0:
+		v.Add("blocked_by", errs.ViolationInvalid, "blocked_by must be positive")
+	case l.Blocks < 0:
+		v.Add("blocks", errs.ViolationInvalid, "blocks must be positive")
+	}
+	return v.Err()
+}
+
+// Field returns the name of the field which is set.
+func (l *TodoLink) Field() string {
+	if l.Blocks != 0 {
+		return "blocks"
+	}
+	return "blocked_by"
+}
+
+// Dependency returns the dependency the link creates for the todo.
+func (l *TodoLink) Dependency(id int64) TodoDependency {
+	if l.Blocks != 0 {
+		return TodoDependency{TodoID: l.Blocks, BlockerID: id}
+	}
+	return TodoDependency{TodoID: id, BlockerID: l.BlockedBy}
+}
+
+// TodoDependencies are the todos a todo is blocked by and the todos it blocks.
+type TodoDependencies struct {
+	BlockedBy []TodoItem `json:"blocked_by"`
+	Blocks    []TodoItem `json:"blocks"`
+}
+
+// DependsOn reports whether the todo is blocked by another one directly or through
+// other todos.
+func DependsOn(deps []TodoDependency, todoID, blockerID int64) bool {
+	blockers := make(map[int64][]int64)
+	for _, d := range deps {
+		blockers[d.TodoID] = append(blockers[d.TodoID], d.BlockerID)
+	}
+
+	seen := map[int64]bool{todoID: true}
+	queue := []int64{todoID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, b := range blockers[id] {
+			if b == blockerID {
+				return true
+			}
+			if !seen[b] {
+				seen[b] = true
+				queue = append(queue, b)
+			}
+		}
+	}
+	return false
+}
+
+// TopologicalOrder orders the ids so that every todo follows the todos it depends on,
+// including dependencies through todos outside of ids. Independent todos are ordered by id.
+func TopologicalOrder(ids []int64, deps []TodoDependency) ([]int64, error) {
+	pending := make(map[int64]int)
+	dependents := make(map[int64][]int64)
+	for _, id := range ids {
+		pending[id] = 0
+	}
+	for _, d := range deps {
+		if _, ok := pending[d.BlockerID]; !ok {
+			pending[d.BlockerID] = 0
+		}
+		pending[d.TodoID]++
+		dependents[d.BlockerID] = append(dependents[d.BlockerID], d.TodoID)
+	}
+
+	ready := make([]int64, 0)
+	for id, n := range pending {
+		if n == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	res := make([]int64, 0, len(wanted))
+	visited := 0
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return ready[i] < ready[j] })
+		id := ready[0]
+		ready = ready[1:]
+		visited++
+
+		if wanted[id] {
+			res = append(res, id)
+			delete(wanted, id)
+		}
+		for _, next := range dependents[id] {
+			pending[next]--
+			if pending[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if visited < len(pending) {
+		return nil, ErrDependencyCycle
+	}
+	return res, nil
+}
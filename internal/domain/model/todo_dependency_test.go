@@ -0,0 +1,53 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDependsOn(t *testing.T) {
+	deps := []TodoDependency{
+		{TodoID: 3, BlockerID: 2},
+		{TodoID: 2, BlockerID: 1},
+		{TodoID: 4, BlockerID: 1},
+	}
+
+	require.True(t, DependsOn(deps, 3, 2))
+	require.True(t, DependsOn(deps, 3, 1))
+	require.False(t, DependsOn(deps, 1, 3))
+	require.False(t, DependsOn(deps, 3, 4))
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	t.Run("blockers first", func(t *testing.T) {
+		res, err := TopologicalOrder([]int64{1, 2, 3}, []TodoDependency{
+			{TodoID: 1, BlockerID: 3},
+			{TodoID: 3, BlockerID: 2},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int64{2, 3, 1}, res)
+	})
+
+	t.Run("through todos which are not listed", func(t *testing.T) {
+		res, err := TopologicalOrder([]int64{1, 2}, []TodoDependency{
+			{TodoID: 1, BlockerID: 5},
+			{TodoID: 5, BlockerID: 2},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int64{2, 1}, res)
+	})
+
+	t.Run("independent todos by id", func(t *testing.T) {
+		res, err := TopologicalOrder([]int64{3, 1, 2}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2, 3}, res)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := TopologicalOrder([]int64{1, 2}, []TodoDependency{
+			{TodoID: 1, BlockerID: 2},
+			{TodoID: 2, BlockerID: 1},
+		})
+		require.ErrorIs(t, err, ErrDependencyCycle)
+	})
+}
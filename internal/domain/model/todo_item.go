@@ -1,8 +1,9 @@
 package model
 
 import (
-	"fmt"
 	"time"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/timezone"
 )
 
 type TodoItem struct {
@@ -10,23 +11,72 @@ type TodoItem struct {
 	Title       string     `json:"title,omitempty" form:"title"`
 	Description string     `json:"description,omitempty" form:"description"`
 	Date        *time.Time `json:"date,omitempty" form:"date" time_format:"2006-01-02"`
-	Status      TodoStatus `json:"status,omitempty" form:"status"`
-	CreatedAt   time.Time  `json:"created_at,omitempty"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	// DueAt is an optional due time, Date is kept the day it falls on in TimeZone
+	DueAt *time.Time `json:"due_at,omitempty" form:"due_at"`
+	// TimeZone is the IANA time zone of the todo, the zone of the caller when not set
+	TimeZone  string     `json:"time_zone,omitempty" form:"time_zone"`
+	Status    TodoStatus `json:"status,omitempty" form:"status" enums:"pending,in_progress,blocked,completed,cancelled"`
+	ProjectID *int64     `json:"project_id,omitempty" form:"project_id"`
+	// AutoComplete completes the todo once all of its checklist items are checked
+	AutoComplete bool `json:"auto_complete" form:"auto_complete"`
+	// EstimateMinutes is the expected effort, TrackedSeconds the time tracked on the todo so far
+	EstimateMinutes *int64 `json:"estimate_minutes,omitempty" form:"estimate_minutes"`
+	TrackedSeconds  int64  `json:"tracked_seconds"`
+	// Recurrence repeats the todo from its date, an RRULE like "FREQ=WEEKLY;BYDAY=MO,TH"
+	Recurrence string `json:"recurrence,omitempty" form:"recurrence"`
+	// CompletedAt is recorded when the todo becomes completed
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Blocked is set while a todo this todo depends on is neither completed nor cancelled
+	Blocked bool `json:"blocked"`
+	// CommentCount is the number of comments on the todo
+	CommentCount int64 `json:"comment_count"`
+	// Position is the manual order rank, it is changed by moving the todo
+	Position  string     `json:"position,omitempty"`
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
-type TodoStatus string
+// TodoMove places a todo right before or right after another todo.
+type TodoMove struct {
+	BeforeID int64 `json:"before_id,omitempty"`
+	AfterID  int64 `json:"after_id,omitempty"`
+}
 
-var (
-	TodoStatusCompleted = "completed"
-	TodoStatusPending   = "pending"
-)
+func (m *TodoMove) Validate() error {
+	var v errs.Violations
+	switch {
+	case m.BeforeID == 0 && m.AfterID == 0:
+		v.Add("before_id", errs.ViolationRequired, "one of before_id and after_id must be set")
+	case m.BeforeID != 0 && m.AfterID != 0:
+		v.Add("after_id", errs.ViolationInvalid, "only one of before_id and after_id may be set")
+	case m.BeforeID < 0:
+		v.Add("before_id", errs.ViolationInvalid, "before_id must be positive")
+	case m.AfterID < 0:
+		v.Add("after_id", errs.ViolationInvalid, "after_id must be positive")
+	}
+	return v.Err()
+}
+
+// Target returns the id of the neighbour todo and whether the todo goes after it.
+func (m *TodoMove) Target() (id int64, after bool) {
+	if m.AfterID != 0 {
+		return m.AfterID, true
+	}
+	return m.BeforeID, false
+}
 
 const (
-	TodoTitleField       = "title"
-	TodoDescriptionField = "description"
-	TodoDateField        = "date"
-	TodoStatusField      = "status"
+	TodoTitleField        = "title"
+	TodoDescriptionField  = "description"
+	TodoDateField         = "date"
+	TodoStatusField       = "status"
+	TodoProjectIDField    = "project_id"
+	TodoDueAtField        = "due_at"
+	TodoTimeZoneField     = "time_zone"
+	TodoCompletedAtField  = "completed_at"
+	TodoAutoCompleteField = "auto_complete"
+	TodoEstimateField     = "estimate_minutes"
+	TodoRecurrenceField   = "recurrence"
 )
 
 var TodoFields = []string{
@@ -34,19 +84,41 @@ var TodoFields = []string{
 	TodoDescriptionField,
 	TodoDateField,
 	TodoStatusField,
+	TodoProjectIDField,
+	TodoDueAtField,
+	TodoTimeZoneField,
+	TodoCompletedAtField,
+	TodoAutoCompleteField,
+	TodoEstimateField,
+	TodoRecurrenceField,
 }
 
+// Validate reports all invalid fields at once.
 func (t *TodoItem) Validate() error {
+	var v errs.Violations
 	if t.Title == "" {
-		return fmt.Errorf("title must be set")
+		v.Add(TodoTitleField, errs.ViolationRequired, "title must be set")
 	}
-	if t.Date == nil {
-		return fmt.Errorf("date must be set")
+	if t.Date == nil && t.DueAt == nil {
+		v.Add(TodoDateField, errs.ViolationRequired, "one of date and due_at must be set")
 	}
 	if t.Status == "" {
-		return fmt.Errorf("status must be set")
+		v.Add(TodoStatusField, errs.ViolationRequired, "status must be set")
+	} else if !t.Status.Valid() {
+		v.Add(TodoStatusField, errs.ViolationInvalid, "status must be one of "+todoStatusList())
+	}
+	if t.TimeZone != "" && !timezone.Valid(t.TimeZone) {
+		v.Add(TodoTimeZoneField, errs.ViolationInvalid, "time_zone is not a known IANA time zone")
+	}
+	if t.EstimateMinutes != nil && *t.EstimateMinutes <= 0 {
+		v.Add(TodoEstimateField, errs.ViolationOutOfRange, "estimate_minutes must be positive")
 	}
-	return nil
+	if t.Recurrence != "" {
+		if _, err := ParseRecurrence(t.Recurrence); err != nil {
+			v.Add(TodoRecurrenceField, errs.ViolationInvalid, "recurrence: "+err.Error())
+		}
+	}
+	return v.Err()
 }
 
 func (t *TodoItem) EditableFields() []string {
@@ -67,5 +139,29 @@ func (t *TodoItem) EditableFields() []string {
 		res = append(res, TodoStatusField)
 	}
 
+	if t.ProjectID != nil {
+		res = append(res, TodoProjectIDField)
+	}
+
+	if t.DueAt != nil {
+		res = append(res, TodoDueAtField)
+	}
+
+	if t.TimeZone != "" {
+		res = append(res, TodoTimeZoneField)
+	}
+
+	if t.AutoComplete {
+		res = append(res, TodoAutoCompleteField)
+	}
+
+	if t.EstimateMinutes != nil {
+		res = append(res, TodoEstimateField)
+	}
+
+	if t.Recurrence != "" {
+		res = append(res, TodoRecurrenceField)
+	}
+
 	return res
 }
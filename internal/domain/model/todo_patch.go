@@ -0,0 +1,144 @@
+package model
+
+import (
+	"time"
+)
+
+// TodoWritableFields are the fields a client sets, the others are maintained by the service.
+var TodoWritableFields = []string{
+	TodoTitleField,
+	TodoDescriptionField,
+	TodoDateField,
+	TodoDueAtField,
+	TodoTimeZoneField,
+	TodoStatusField,
+	TodoProjectIDField,
+	TodoAutoCompleteField,
+	TodoEstimateField,
+	TodoRecurrenceField,
+}
+
+// TodoDocument is the writable part of a todo which patches are applied to. Unlike TodoItem
+// all members are present, so that JSON Patch may replace or remove any of them.
+type TodoDocument struct {
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Date            *time.Time `json:"date"`
+	DueAt           *time.Time `json:"due_at"`
+	TimeZone        string     `json:"time_zone"`
+	Status          TodoStatus `json:"status"`
+	ProjectID       *int64     `json:"project_id"`
+	AutoComplete    bool       `json:"auto_complete"`
+	EstimateMinutes *int64     `json:"estimate_minutes"`
+	Recurrence      string     `json:"recurrence"`
+}
+
+func NewTodoDocument(t TodoItem) TodoDocument {
+	return TodoDocument{
+		Title:           t.Title,
+		Description:     t.Description,
+		Date:            t.Date,
+		DueAt:           t.DueAt,
+		TimeZone:        t.TimeZone,
+		Status:          t.Status,
+		ProjectID:       t.ProjectID,
+		AutoComplete:    t.AutoComplete,
+		EstimateMinutes: t.EstimateMinutes,
+		Recurrence:      t.Recurrence,
+	}
+}
+
+// Item returns the todo with the fields of the document.
+func (d TodoDocument) Item(id int64) TodoItem {
+	return TodoItem{
+		ID:              id,
+		Title:           d.Title,
+		Description:     d.Description,
+		Date:            d.Date,
+		DueAt:           d.DueAt,
+		TimeZone:        d.TimeZone,
+		Status:          d.Status,
+		ProjectID:       d.ProjectID,
+		AutoComplete:    d.AutoComplete,
+		EstimateMinutes: d.EstimateMinutes,
+		Recurrence:      d.Recurrence,
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Changed returns the writable fields which values differ in other.
+func (t *TodoItem) Changed(other TodoItem) []string {
+	res := make([]string, 0)
+	if t.Title != other.Title {
+		res = append(res, TodoTitleField)
+	}
+	if t.Description != other.Description {
+		res = append(res, TodoDescriptionField)
+	}
+	if !equalTime(t.Date, other.Date) {
+		res = append(res, TodoDateField)
+	}
+	if !equalTime(t.DueAt, other.DueAt) {
+		res = append(res, TodoDueAtField)
+	}
+	if t.TimeZone != other.TimeZone {
+		res = append(res, TodoTimeZoneField)
+	}
+	if t.Status != other.Status {
+		res = append(res, TodoStatusField)
+	}
+	if !equalID(t.ProjectID, other.ProjectID) {
+		res = append(res, TodoProjectIDField)
+	}
+	if t.AutoComplete != other.AutoComplete {
+		res = append(res, TodoAutoCompleteField)
+	}
+	if !equalID(t.EstimateMinutes, other.EstimateMinutes) {
+		res = append(res, TodoEstimateField)
+	}
+	if t.Recurrence != other.Recurrence {
+		res = append(res, TodoRecurrenceField)
+	}
+	return res
+}
+
+// Set copies the fields from src, empty values clear the fields.
+func (t *TodoItem) Set(src TodoItem, fields []string) {
+	for _, f := range fields {
+		switch f {
+		case TodoTitleField:
+			t.Title = src.Title
+		case TodoDescriptionField:
+			t.Description = src.Description
+		case TodoDateField:
+			t.Date = src.Date
+		case TodoDueAtField:
+			t.DueAt = src.DueAt
+		case TodoTimeZoneField:
+			t.TimeZone = src.TimeZone
+		case TodoStatusField:
+			t.Status = src.Status
+		case TodoProjectIDField:
+			t.ProjectID = src.ProjectID
+		case TodoAutoCompleteField:
+			t.AutoComplete = src.AutoComplete
+		case TodoEstimateField:
+			t.EstimateMinutes = src.EstimateMinutes
+		case TodoRecurrenceField:
+			t.Recurrence = src.Recurrence
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type TodoStatus string
+
+const (
+	TodoStatusPending    = "pending"
+	TodoStatusInProgress = "in_progress"
+	TodoStatusBlocked    = "blocked"
+	TodoStatusCompleted  = "completed"
+	TodoStatusCancelled  = "cancelled"
+)
+
+// TodoStatuses lists all statuses in their natural order.
+var TodoStatuses = []TodoStatus{
+	TodoStatusPending,
+	TodoStatusInProgress,
+	TodoStatusBlocked,
+	TodoStatusCompleted,
+	TodoStatusCancelled,
+}
+
+func (s TodoStatus) Valid() bool {
+	for _, v := range TodoStatuses {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func todoStatusList() string {
+	res := make([]string, len(TodoStatuses))
+	for i, v := range TodoStatuses {
+		res[i] = string(v)
+	}
+	return strings.Join(res, ", ")
+}
+
+// Closed reports whether no more work is expected on a todo in the status.
+func (s TodoStatus) Closed() bool {
+	return s == TodoStatusCompleted || s == TodoStatusCancelled
+}
+
+// TodoTransitions is the graph of allowed status changes, keeping a status is always allowed.
+type TodoTransitions map[TodoStatus][]TodoStatus
+
+// DefaultTodoTransitions allows any change except finishing blocked todos and returning
+// to work on closed ones other than by reopening them.
+func DefaultTodoTransitions() TodoTransitions {
+	return TodoTransitions{
+		TodoStatusPending:    {TodoStatusInProgress, TodoStatusBlocked, TodoStatusCompleted, TodoStatusCancelled},
+		TodoStatusInProgress: {TodoStatusPending, TodoStatusBlocked, TodoStatusCompleted, TodoStatusCancelled},
+		TodoStatusBlocked:    {TodoStatusPending, TodoStatusInProgress, TodoStatusCancelled},
+		TodoStatusCompleted:  {TodoStatusPending},
+		TodoStatusCancelled:  {TodoStatusPending},
+	}
+}
+
+func (t TodoTransitions) Allows(from, to TodoStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, v := range t[from] {
+		if v == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTodoTransitions parses a graph written as "from:to|to;from:to", e.g.
+// "pending:in_progress|completed;in_progress:completed;completed:pending".
+// Statuses missing on the left side can not be left.
+func ParseTodoTransitions(s string) (TodoTransitions, error) {
+	res := make(TodoTransitions)
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		from, targets, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("transition %q: expected from:to", rule)
+		}
+		fromStatus := TodoStatus(strings.TrimSpace(from))
+		if !fromStatus.Valid() {
+			return nil, fmt.Errorf("transition %q: unknown status %q", rule, fromStatus)
+		}
+
+		for _, to := range strings.Split(targets, "|") {
+			toStatus := TodoStatus(strings.TrimSpace(to))
+			if !toStatus.Valid() {
+				return nil, fmt.Errorf("transition %q: unknown status %q", rule, toStatus)
+			}
+			res[fromStatus] = append(res[fromStatus], toStatus)
+		}
+	}
+
+	return res, nil
+}
+
+// String formats the graph the way ParseTodoTransitions reads it.
+func (t TodoTransitions) String() string {
+	rules := make([]string, 0, len(t))
+	for _, from := range TodoStatuses {
+		targets, ok := t[from]
+		if !ok {
+			continue
+		}
+		to := make([]string, len(targets))
+		for i, v := range targets {
+			to[i] = string(v)
+		}
+		sort.Strings(to)
+		rules = append(rules, string(from)+":"+strings.Join(to, "|"))
+	}
+
+	return strings.Join(rules, ";")
+}
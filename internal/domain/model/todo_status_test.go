@@ -0,0 +1,28 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseTodoTransitions(t *testing.T) {
+	t.Run("default graph round trip", func(t *testing.T) {
+		def := DefaultTodoTransitions()
+		parsed, err := ParseTodoTransitions(def.String())
+		require.NoError(t, err)
+		require.Equal(t, def.String(), parsed.String())
+	})
+
+	t.Run("custom graph", func(t *testing.T) {
+		tr, err := ParseTodoTransitions("pending: in_progress | completed; in_progress:completed")
+		require.NoError(t, err)
+		require.True(t, tr.Allows(TodoStatusPending, TodoStatusCompleted))
+		require.True(t, tr.Allows(TodoStatusCompleted, TodoStatusCompleted))
+		require.False(t, tr.Allows(TodoStatusCompleted, TodoStatusPending))
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := ParseTodoTransitions("pending:done")
+		require.Error(t, err)
+	})
+}
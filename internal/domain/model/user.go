@@ -0,0 +1,78 @@
+package model
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/timezone"
+)
+
+type User struct {
+	ID    int64  `json:"id,omitempty"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+	// Username is the handle the user is mentioned by in comments, e.g. @ann
+	Username string `json:"username,omitempty"`
+	// TimeZone is the IANA time zone dates are shown in for the user, empty for the server default
+	TimeZone  string    `json:"time_zone,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// NormalizeEmail makes addresses comparable, emails are stored lowercased.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// usernamePattern is the form of usernames, they are stored lowercased.
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_]{2,32}$`)
+
+// NormalizeUsername makes usernames comparable, a leading @ is dropped.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+}
+
+func validateUsername(v *errs.Violations, username string) {
+	if username != "" && !usernamePattern.MatchString(username) {
+		v.Add("username", errs.ViolationInvalid, "username must be 2 to 32 latin letters, digits or underscores")
+	}
+}
+
+func validateEmail(v *errs.Violations, email string) {
+	if email == "" {
+		v.Add("email", errs.ViolationRequired, "email must be set")
+		return
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		v.Add("email", errs.ViolationInvalid, "email is not a valid address")
+	}
+}
+
+func (u *User) Validate() error {
+	var v errs.Violations
+	validateEmail(&v, u.Email)
+	if u.Name == "" {
+		v.Add("name", errs.ViolationRequired, "name must be set")
+	}
+	validateUsername(&v, u.Username)
+	validateTimeZone(&v, u.TimeZone)
+	return v.Err()
+}
+
+// ValidateUpdate checks the fields a user may change about themselves.
+func (u *User) ValidateUpdate() error {
+	var v errs.Violations
+	if u.Name == "" && u.TimeZone == "" && u.Username == "" {
+		v.Add("name", errs.ViolationRequired, "one of name, username and time_zone must be set")
+	}
+	validateUsername(&v, u.Username)
+	validateTimeZone(&v, u.TimeZone)
+	return v.Err()
+}
+
+func validateTimeZone(v *errs.Violations, name string) {
+	if name != "" && !timezone.Valid(name) {
+		v.Add("time_zone", errs.ViolationInvalid, "time_zone is not a known IANA time zone")
+	}
+}
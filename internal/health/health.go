@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrShuttingDown = errors.New("shutting down")
+
+// Check is a single readiness probe of a dependency.
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Health aggregates readiness checks and the shutdown flag of the process.
+type Health struct {
+	checks  []Check
+	timeout time.Duration
+	ready   atomic.Bool
+}
+
+func New(timeout time.Duration, checks ...Check) *Health {
+	h := &Health{
+		checks:  checks,
+		timeout: timeout,
+	}
+	h.ready.Store(true)
+
+	return h
+}
+
+// Add registers additional checks. It must be called before serving requests.
+func (h *Health) Add(checks ...Check) {
+	h.checks = append(h.checks, checks...)
+}
+
+// SetReady flips readiness, e.g. to stop receiving traffic during graceful shutdown.
+func (h *Health) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Ready runs all checks concurrently and reports whether the process may receive traffic.
+func (h *Health) Ready(ctx context.Context) (Report, bool) {
+	if !h.ready.Load() {
+		return Report{
+			Status: StatusFail,
+			Checks: []CheckResult{{Name: "shutdown", Status: StatusFail, Error: ErrShuttingDown.Error()}},
+		}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c Check) {
+			defer wg.Done()
+			start := time.Now()
+			res := CheckResult{Name: c.Name, Status: StatusOK}
+			if err := c.Probe(ctx); err != nil {
+				res.Status = StatusFail
+				res.Error = err.Error()
+			}
+			res.Duration = time.Since(start)
+			results[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, r := range results {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+			return report, false
+		}
+	}
+
+	return report, true
+}
+
+// DialCheck verifies that a TCP connection to addr can be established.
+func DialCheck(name, addr string) Check {
+	return Check{
+		Name: name,
+		Probe: func(ctx context.Context) error {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
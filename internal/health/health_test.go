@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestHealth_Ready(t *testing.T) {
+	okCheck := Check{Name: "ok", Probe: func(ctx context.Context) error { return nil }}
+	failCheck := Check{Name: "fail", Probe: func(ctx context.Context) error { return errors.New("boom") }}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		h := New(time.Second, okCheck)
+		report, ok := h.Ready(context.Background())
+		require.True(t, ok)
+		require.Equal(t, StatusOK, report.Status)
+		require.Len(t, report.Checks, 1)
+	})
+
+	t.Run("failed check", func(t *testing.T) {
+		h := New(time.Second, okCheck, failCheck)
+		report, ok := h.Ready(context.Background())
+		require.False(t, ok)
+		require.Equal(t, StatusFail, report.Status)
+		require.Equal(t, "boom", report.Checks[1].Error)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		h := New(time.Second, okCheck)
+		h.SetReady(false)
+		report, ok := h.Ready(context.Background())
+		require.False(t, ok)
+		require.Equal(t, ErrShuttingDown.Error(), report.Checks[0].Error)
+	})
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"log/slog"
+	"time"
+	"todo-list/internal/domain/model"
+)
+
+const namespace = "todo"
+
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	ServiceOperationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "operations_total",
+		Help:      "Total number of service operations by outcome.",
+	}, []string{"operation", "outcome"})
+
+	ServiceOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "operation_duration_seconds",
+		Help:      "Service operation latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	RemindersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reminders",
+		Name:      "notifications_total",
+		Help:      "Total number of reminder notifications by outcome.",
+	}, []string{"outcome"})
+
+	MentionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "comments",
+		Name:      "mention_notifications_total",
+		Help:      "Total number of notifications about mentions in comments by outcome.",
+	}, []string{"outcome"})
+)
+
+// RegisterBuildInfo exports a constant gauge labeled with the version and commit of the binary.
+func RegisterBuildInfo(version, commit string) {
+	promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "build_info",
+		Help:        "Build information of the running binary.",
+		ConstLabels: prometheus.Labels{"version": version, "commit": commit},
+	}).Set(1)
+}
+
+// TodoCounter is implemented by repositories able to count todos grouped by status.
+type TodoCounter interface {
+	CountTodosByStatus(ctx context.Context) (map[string]int64, error)
+}
+
+// TodoCollector exposes business gauges computed on every scrape.
+type TodoCollector struct {
+	counter TodoCounter
+	timeout time.Duration
+	items   *prometheus.Desc
+}
+
+func NewTodoCollector(counter TodoCounter) *TodoCollector {
+	return &TodoCollector{
+		counter: counter,
+		timeout: 5 * time.Second,
+		items: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "items"),
+			"Number of todo items by status.",
+			[]string{"status"}, nil,
+		),
+	}
+}
+
+func (c *TodoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.items
+}
+
+func (c *TodoCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	counts, err := c.counter.CountTodosByStatus(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "metrics: count todos by status", slog.Any("error", err))
+		ch <- prometheus.NewInvalidMetric(c.items, err)
+		return
+	}
+
+	// gauges of all statuses are always exported, even when there are no such todos
+	for _, status := range model.TodoStatuses {
+		if _, ok := counts[string(status)]; !ok {
+			counts[string(status)] = 0
+		}
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.items, prometheus.GaugeValue, float64(count), status)
+	}
+}
+
+// RegisterDB registers connection pool stats and business gauges for the database.
+func RegisterDB(db *sqlx.DB, counter TodoCounter) {
+	prometheus.MustRegister(
+		collectors.NewDBStatsCollector(db.DB, namespace),
+		NewTodoCollector(counter),
+	)
+}
@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log writes notifications to the application log, useful in development.
+type Log struct{}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) Notify(ctx context.Context, n Notification) error {
+	attrs := []any{
+		slog.Int64("todo_id", n.TodoID),
+		slog.String("title", n.Title),
+		slog.String("recipient", n.Recipient),
+	}
+	if n.Kind == KindMention {
+		attrs = append(attrs, slog.Int64("comment_id", n.CommentID), slog.String("author", n.Author))
+	} else {
+		attrs = append(attrs, slog.Int64("reminder_id", n.ReminderID))
+	}
+	if n.DueAt != nil {
+		attrs = append(attrs, slog.Time("due_at", *n.DueAt))
+	}
+
+	slog.InfoContext(ctx, n.Kind, attrs...)
+	return nil
+}
@@ -0,0 +1,48 @@
+// Package notifier delivers reminders and mentions through pluggable channels.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kinds of notifications.
+const (
+	KindReminder = "reminder"
+	KindMention  = "mention"
+)
+
+// Notification is a reminder about a todo or a mention in a comment on a todo.
+type Notification struct {
+	Kind       string `json:"kind"`
+	ReminderID int64  `json:"reminder_id,omitempty"`
+	CommentID  int64  `json:"comment_id,omitempty"`
+	TodoID     int64  `json:"todo_id"`
+	Title      string `json:"title"`
+	// Description is the description of the todo for reminders and the comment for mentions
+	Description string `json:"description,omitempty"`
+	// DueAt is the due time of a reminder
+	DueAt *time.Time `json:"due_at,omitempty"`
+	// Author is the name of the user who mentioned the recipient
+	Author string `json:"author,omitempty"`
+	// Recipient is the email of the user who set the reminder or who is mentioned, empty
+	// for keys without a user.
+	Recipient string `json:"recipient,omitempty"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Multi sends a notification through every notifier, errors of all of them are joined.
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	var res error
+	for _, v := range m {
+		res = errors.Join(res, v.Notify(ctx, n))
+	}
+
+	return res
+}
@@ -0,0 +1,132 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/util/pointer"
+)
+
+var notification = Notification{
+	Kind:       KindReminder,
+	ReminderID: 1,
+	TodoID:     2,
+	Title:      "Купить молоко",
+	DueAt:      pointer.Pointer(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)),
+	Recipient:  "ann@example.com",
+}
+
+func TestWebhook(t *testing.T) {
+	t.Run("signed json body", func(t *testing.T) {
+		var got Notification
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write(body)
+			require.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(SignatureHeader))
+			require.NoError(t, json.Unmarshal(body, &got))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		require.NoError(t, NewWebhook(srv.URL, "secret", time.Second).Notify(context.Background(), notification))
+		require.Equal(t, notification, got)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		require.Error(t, NewWebhook(srv.URL, "", time.Second).Notify(context.Background(), notification))
+	})
+}
+
+func TestSMTP(t *testing.T) {
+	s := NewSMTP(SMTPConfig{Host: "mail", Port: "25", From: "todo@example.com"})
+
+	t.Run("email to the recipient", func(t *testing.T) {
+		var to []string
+		var msg string
+		s.send = func(addr string, a smtp.Auth, from string, rcpt []string, m []byte) error {
+			require.Equal(t, "mail:25", addr)
+			require.Nil(t, a)
+			to, msg = rcpt, string(m)
+			return nil
+		}
+
+		require.NoError(t, s.Notify(context.Background(), notification))
+		require.Equal(t, []string{"ann@example.com"}, to)
+		require.Contains(t, msg, "Subject: =?utf-8?q?")
+		require.Contains(t, msg, "Due at 2026-10-19T09:00:00Z")
+	})
+
+	t.Run("mention", func(t *testing.T) {
+		var msg string
+		s.send = func(addr string, a smtp.Auth, from string, rcpt []string, m []byte) error {
+			msg = string(m)
+			return nil
+		}
+
+		n := Notification{
+			Kind:        KindMention,
+			CommentID:   3,
+			TodoID:      2,
+			Title:       "release",
+			Description: "@ann please review",
+			Author:      "Bob",
+			Recipient:   "ann@example.com",
+		}
+		require.NoError(t, s.Notify(context.Background(), n))
+		require.Contains(t, msg, "Bob mentioned you in a comment on release")
+		require.NotContains(t, msg, "Due at")
+	})
+
+	t.Run("no recipient", func(t *testing.T) {
+		s.send = func(string, smtp.Auth, string, []string, []byte) error {
+			t.Fatal("unexpected send")
+			return nil
+		}
+
+		n := notification
+		n.Recipient = ""
+		require.NoError(t, s.Notify(context.Background(), n))
+	})
+}
+
+type notifierFunc func(ctx context.Context, n Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+func TestMulti(t *testing.T) {
+	calls := 0
+	ok := notifierFunc(func(context.Context, Notification) error {
+		calls++
+		return nil
+	})
+	failed := notifierFunc(func(context.Context, Notification) error {
+		calls++
+		return errors.New("down")
+	})
+
+	err := Multi{failed, ok}.Notify(context.Background(), notification)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "down"))
+	require.Equal(t, 2, calls)
+}
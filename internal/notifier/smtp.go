@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTP emails notifications to their recipient. Notifications without a recipient are skipped.
+type SMTP struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	return &SMTP{
+		cfg:  cfg,
+		send: smtp.SendMail,
+	}
+}
+
+func (s *SMTP) Notify(ctx context.Context, n Notification) error {
+	if n.Recipient == "" {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var a smtp.Auth
+	if s.cfg.Username != "" {
+		a = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	if err := s.send(addr, a, s.cfg.From, []string{n.Recipient}, message(s.cfg.From, n)); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return nil
+}
+
+func subject(n Notification) string {
+	if n.Kind == KindMention {
+		return "Mentioned: " + n.Title
+	}
+	return "Reminder: " + n.Title
+}
+
+// message renders the email, the subject is MIME encoded as titles are not limited to ASCII.
+func message(from string, n Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", n.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject(n)))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	if n.Kind == KindMention {
+		fmt.Fprintf(&b, "%s mentioned you in a comment on %s\r\n", n.Author, n.Title)
+	} else {
+		fmt.Fprintf(&b, "%s\r\n", n.Title)
+	}
+	if n.DueAt != nil {
+		fmt.Fprintf(&b, "Due at %s\r\n", n.DueAt.UTC().Format(time.RFC3339))
+	}
+	if n.Description != "" {
+		fmt.Fprintf(&b, "\r\n%s\r\n", n.Description)
+	}
+
+	return b.Bytes()
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"strings"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// agendaMarginDays bounds how far the day of a todo in its own time zone, which the date
+// column stores, is from its day in the time zone of the caller. It lets the date index
+// narrow the todos before their local days are computed.
+const agendaMarginDays = 2
+
+// AgendaTodos returns in one query the todos matching the filter which fall between the days
+// from and to in the time zone of the filter, and the open recurring todos starting before to.
+// Pages of the filter are ignored.
+func (s *TodoRepository) AgendaTodos(ctx context.Context, filter dto.TodoFilter, from, to time.Time) (_ []dto.TodoItem, err error) {
+	tz := filter.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	first, last := from.Format(time.DateOnly), to.Format(time.DateOnly)
+	margin := func(day time.Time, days int) string {
+		return day.AddDate(0, 0, days).Format(time.DateOnly)
+	}
+
+	q := s.Builder().Select(
+		"id", strings.Join(model.TodoFields, ", "), "position", "created_at", "updated_at", todoBlocked, todoComments, todoTracked).
+		From("todos").
+		Where(sq.Or{
+			sq.And{
+				sq.Expr(model.TodoDateField+" BETWEEN ?::date AND ?::date", margin(from, -agendaMarginDays), margin(to, agendaMarginDays)),
+				sq.Expr(todoLocalDay+" BETWEEN ?::date AND ?::date", tz, first, last),
+			},
+			sq.And{
+				sq.NotEq{model.TodoRecurrenceField: ""},
+				sq.Expr(model.TodoDateField+" <= ?::date", margin(to, agendaMarginDays)),
+				sq.NotEq{model.TodoStatusField: []string{model.TodoStatusCompleted, model.TodoStatusCancelled}},
+			},
+		}).
+		OrderBy("position", "id")
+	q, err = filterTodos(q, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.AgendaTodos", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TodoItem, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/util/pointer"
+)
+
+func TestTodoRepository_AgendaTodos(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	day := func(d int) *time.Time {
+		return pointer.Pointer(time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC))
+	}
+	inside := dto.TodoItem{Title: "inside", Date: day(20), Status: "pending"}
+	mustCreateTodo(t, &inside)
+	// the 18th in UTC is the 19th in Moscow
+	late := dto.TodoItem{Title: "late", Date: day(18), DueAt: pointer.Pointer(time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)), Status: "pending"}
+	mustCreateTodo(t, &late)
+	recurring := dto.TodoItem{Title: "recurring", Date: day(1), Status: "pending", Recurrence: "FREQ=DAILY"}
+	mustCreateTodo(t, &recurring)
+	mustCreateTodo(t, &dto.TodoItem{Title: "closed recurring", Date: day(1), Status: "completed", Recurrence: "FREQ=DAILY"})
+	mustCreateTodo(t, &dto.TodoItem{Title: "outside", Date: day(25), Status: "pending"})
+
+	res, err := repo.AgendaTodos(ctx, dto.TodoFilter{TimeZone: "Europe/Moscow"}, *day(19), *day(21))
+	require.NoError(t, err)
+	ids := make([]int64, len(res))
+	for i, item := range res {
+		ids[i] = item.ID
+	}
+	require.ElementsMatch(t, []int64{inside.ID, late.ID, recurring.ID}, ids)
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+type APIKeyRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		DB: db,
+	}
+}
+
+func (s *APIKeyRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+func insertAPIKey(ctx context.Context, q sqlx.QueryerContext, b sq.StatementBuilderType, key *dto.APIKey) (err error) {
+	query, args, err := b.Insert("api_keys").SetMap(map[string]interface{}{
+		"name":    key.Name,
+		"prefix":  key.Prefix,
+		"hash":    key.Hash,
+		"scopes":  key.Scopes,
+		"user_id": key.UserID,
+	}).Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "APIKeyRepository.CreateKey", query)
+	defer func() { done(err) }()
+
+	err = missingReference(q.QueryRowxContext(ctx, query, args...).StructScan(key))
+	return err
+}
+
+// CreateKey stores a new key, sql.ErrNoRows is returned when the key is bound to an unknown user.
+func (s *APIKeyRepository) CreateKey(ctx context.Context, key *dto.APIKey) error {
+	return insertAPIKey(ctx, s.DB, s.Builder(), key)
+}
+
+func (s *APIKeyRepository) ListKeys(ctx context.Context) (_ []dto.APIKey, err error) {
+	query, args, err := s.Builder().Select("*").From("api_keys").OrderBy("id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "APIKeyRepository.ListKeys", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.APIKey, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *APIKeyRepository) getKey(ctx context.Context, operation string, where sq.Eq) (_ dto.APIKey, err error) {
+	query, args, err := s.Builder().Select("api_keys.*", "users.email AS user_email", "users.time_zone AS user_time_zone").
+		From("api_keys").
+		LeftJoin("users ON users.id = api_keys.user_id").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return dto.APIKey{}, err
+	}
+
+	ctx, done := instrument(ctx, operation, query)
+	defer func() { done(err) }()
+
+	var res dto.APIKey
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.APIKey{}, err
+	}
+
+	return res, nil
+}
+
+func (s *APIKeyRepository) GetKeyByID(ctx context.Context, id int64) (dto.APIKey, error) {
+	return s.getKey(ctx, "APIKeyRepository.GetKeyByID", sq.Eq{"api_keys.id": id})
+}
+
+func (s *APIKeyRepository) GetKeyByPrefix(ctx context.Context, prefix string) (dto.APIKey, error) {
+	return s.getKey(ctx, "APIKeyRepository.GetKeyByPrefix", sq.Eq{"prefix": prefix})
+}
+
+func revokeAPIKey(ctx context.Context, e sqlx.ExecerContext, b sq.StatementBuilderType, id int64) (err error) {
+	query, args, err := b.Update("api_keys").
+		Set("revoked_at", time.Now()).
+		Where(sq.Eq{"id": id, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "APIKeyRepository.RevokeKey", query)
+	defer func() { done(err) }()
+
+	res, err := e.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RevokeKey marks an active key revoked, sql.ErrNoRows is returned for unknown or already revoked keys.
+func (s *APIKeyRepository) RevokeKey(ctx context.Context, id int64) error {
+	return revokeAPIKey(ctx, s.DB, s.Builder(), id)
+}
+
+// RotateKey revokes the old key and stores the new one in a single transaction.
+func (s *APIKeyRepository) RotateKey(ctx context.Context, oldID int64, key *dto.APIKey) error {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+	if err = revokeAPIKey(ctx, tx, b, oldID); err != nil {
+		return err
+	}
+	if err = insertAPIKey(ctx, tx, b, key); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TouchKey records usage of the key. The timestamp is updated at most once a minute
+// to avoid a write per request.
+func (s *APIKeyRepository) TouchKey(ctx context.Context, id int64) (err error) {
+	query, args, err := s.Builder().Update("api_keys").
+		Set("last_used_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		Where("(last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "APIKeyRepository.TouchKey", query)
+	defer func() { done(err) }()
+
+	_, err = s.DB.ExecContext(ctx, query, args...)
+	return err
+}
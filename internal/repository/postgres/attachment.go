@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+type AttachmentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAttachmentRepository(db *sqlx.DB) *AttachmentRepository {
+	return &AttachmentRepository{
+		DB: db,
+	}
+}
+
+func (s *AttachmentRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateAttachment stores the metadata of an uploaded file, sql.ErrNoRows is returned when the todo does not exist.
+func (s *AttachmentRepository) CreateAttachment(ctx context.Context, a *dto.Attachment) (err error) {
+	query, args, err := s.Builder().Insert("attachments").SetMap(map[string]interface{}{
+		"todo_id":      a.TodoID,
+		"uploader_id":  a.UploaderID,
+		"file_name":    a.FileName,
+		"content_type": a.ContentType,
+		"size_bytes":   a.Size,
+		"storage_key":  a.StorageKey,
+	}).Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "AttachmentRepository.CreateAttachment", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(a)
+	return missingReference(err)
+}
+
+func (s *AttachmentRepository) GetAttachment(ctx context.Context, todoID, id int64) (_ dto.Attachment, err error) {
+	query, args, err := s.Builder().Select("*").
+		From("attachments").
+		Where(sq.Eq{"id": id, "todo_id": todoID}).
+		ToSql()
+	if err != nil {
+		return dto.Attachment{}, err
+	}
+
+	ctx, done := instrument(ctx, "AttachmentRepository.GetAttachment", query)
+	defer func() { done(err) }()
+
+	var res dto.Attachment
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.Attachment{}, err
+	}
+	return res, nil
+}
+
+// ListAttachments returns the attachments of the todo, oldest first.
+func (s *AttachmentRepository) ListAttachments(ctx context.Context, todoID int64) (_ []dto.Attachment, err error) {
+	query, args, err := s.Builder().Select("*").
+		From("attachments").
+		Where(sq.Eq{"todo_id": todoID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "AttachmentRepository.ListAttachments", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.Attachment, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteAttachment deletes the metadata, a trigger queues the blob for DeleteBlobs.
+func (s *AttachmentRepository) DeleteAttachment(ctx context.Context, todoID, id int64) error {
+	return execAffected(ctx, s.DB, "AttachmentRepository.DeleteAttachment",
+		s.Builder().Delete("attachments").Where(sq.Eq{"id": id, "todo_id": todoID}))
+}
+
+// UsedBytes returns the total size of the files uploaded by the user.
+func (s *AttachmentRepository) UsedBytes(ctx context.Context, userID int64) (_ int64, err error) {
+	query, args, err := s.Builder().Select("COALESCE(SUM(size_bytes), 0)").
+		From("attachments").
+		Where(sq.Eq{"uploader_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, done := instrument(ctx, "AttachmentRepository.UsedBytes", query)
+	defer func() { done(err) }()
+
+	var res int64
+	err = s.DB.QueryRowxContext(ctx, query, args...).Scan(&res)
+	return res, err
+}
+
+// DeleteBlobs locks a batch of blobs queued for deletion and passes each of them to del.
+// A blob that failed to be deleted stays queued and is retried with the next batch.
+func (s *AttachmentRepository) DeleteBlobs(ctx context.Context, batchSize uint64, del func(context.Context, string) error) (deleted int, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+
+	query, args, err := b.Select("storage_key").
+		From("attachment_blob_deletions").
+		OrderBy("created_at").
+		Limit(batchSize).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0)
+	selectCtx, done := instrument(ctx, "AttachmentRepository.SelectDeletedBlobs", query)
+	err = tx.SelectContext(selectCtx, &keys, query, args...)
+	done(err)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if delErr := del(ctx, key); delErr != nil {
+			slog.WarnContext(ctx, "delete attachment blob", slog.String("storage_key", key), slog.Any("error", delErr))
+			continue
+		}
+		removed = append(removed, key)
+	}
+
+	if len(removed) != 0 {
+		if err = execAffected(ctx, tx, "AttachmentRepository.DequeueDeletedBlobs",
+			b.Delete("attachment_blob_deletions").Where(sq.Eq{"storage_key": removed})); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(removed), nil
+}
+
+// RunBlobCleanup removes the blobs of deleted attachments from the storage every interval until ctx is done.
+func (s *AttachmentRepository) RunBlobCleanup(ctx context.Context, interval time.Duration, batchSize uint64, del func(context.Context, string) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.DeleteBlobs(ctx, batchSize, del)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.ErrorContext(ctx, "delete attachment blobs", slog.Any("error", err))
+				}
+				continue
+			}
+			if deleted > 0 {
+				slog.InfoContext(ctx, "attachment blobs deleted", slog.Int("count", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+func TestAttachmentRepository(t *testing.T) {
+	r := NewAttachmentRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM attachment_blob_deletions;")
+	require.NoError(t, err)
+	mustTruncate(t)
+
+	user := mustCreateUser(t, "uploader@example.com")
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todo := dto.TodoItem{Title: "release", Date: &date, Status: "pending"}
+	mustCreateTodo(t, &todo)
+
+	a := dto.Attachment{TodoID: todo.ID, UploaderID: &user.ID, FileName: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 5, StorageKey: "todos/1/a"}
+	require.NoError(t, r.CreateAttachment(ctx, &a))
+	require.NotZero(t, a.ID)
+	require.NoError(t, r.CreateAttachment(ctx, &dto.Attachment{TodoID: todo.ID, UploaderID: &user.ID, FileName: "b.png", ContentType: "image/png", Size: 7, StorageKey: "todos/1/b"}))
+
+	t.Run("unknown todo", func(t *testing.T) {
+		err := r.CreateAttachment(ctx, &dto.Attachment{TodoID: -1, FileName: "x", ContentType: "text/plain", StorageKey: "todos/-1/x"})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("list and get", func(t *testing.T) {
+		res, err := r.ListAttachments(ctx, todo.ID)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, "notes.txt", res[0].FileName)
+
+		got, err := r.GetAttachment(ctx, todo.ID, a.ID)
+		require.NoError(t, err)
+		require.Equal(t, "todos/1/a", got.StorageKey)
+	})
+
+	t.Run("used bytes", func(t *testing.T) {
+		used, err := r.UsedBytes(ctx, user.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), used)
+	})
+
+	t.Run("deleted blobs are queued", func(t *testing.T) {
+		require.NoError(t, r.DeleteAttachment(ctx, todo.ID, a.ID))
+		require.ErrorIs(t, r.DeleteAttachment(ctx, todo.ID, a.ID), sql.ErrNoRows)
+		// the other attachment goes with its todo
+		require.NoError(t, repo.DeleteTodo(ctx, todo.ID))
+
+		failed := errors.New("storage is down")
+		deleted, err := r.DeleteBlobs(ctx, 10, func(context.Context, string) error { return failed })
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		keys := make([]string, 0)
+		deleted, err = r.DeleteBlobs(ctx, 10, func(_ context.Context, key string) error {
+			keys = append(keys, key)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+		require.ElementsMatch(t, []string{"todos/1/a", "todos/1/b"}, keys)
+
+		deleted, err = r.DeleteBlobs(ctx, 10, func(context.Context, string) error { return nil })
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// ListChecklist returns the checklist items of the todo in their order.
+func (s *TodoRepository) ListChecklist(ctx context.Context, todoID int64) (_ []dto.ChecklistItem, err error) {
+	query, args, err := s.Builder().Select("*").
+		From("checklist_items").
+		Where(sq.Eq{"todo_id": todoID}).
+		OrderBy("position", "id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.ListChecklist", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.ChecklistItem, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddChecklistItem appends an item to the checklist of the todo, sql.ErrNoRows is returned
+// for an unknown todo.
+func (s *TodoRepository) AddChecklistItem(ctx context.Context, item *dto.ChecklistItem) (err error) {
+	var checkedAt interface{}
+	if item.Checked {
+		checkedAt = sq.Expr("NOW()")
+	}
+
+	query, args, err := s.Builder().Insert("checklist_items").SetMap(map[string]interface{}{
+		"todo_id":    item.TodoID,
+		"title":      item.Title,
+		"checked":    item.Checked,
+		"checked_at": checkedAt,
+		"position":   sq.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM checklist_items WHERE todo_id = ?)", item.TodoID),
+	}).Suffix("RETURNING *").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.AddChecklistItem", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(item)
+	return missingReference(err)
+}
+
+// UpdateChecklistItem updates the fields of the item. Checking an item records the time,
+// checking it again keeps the first one.
+func (s *TodoRepository) UpdateChecklistItem(ctx context.Context, item *dto.ChecklistItem, updatedFields []string) (err error) {
+	q := s.Builder().Update("checklist_items").
+		Where(sq.Eq{"id": item.ID, "todo_id": item.TodoID}).
+		Suffix("RETURNING *")
+	for _, field := range updatedFields {
+		switch field {
+		case model.ChecklistTitleField:
+			q = q.Set("title", item.Title)
+		case model.ChecklistCheckedField:
+			q = q.Set("checked", item.Checked).
+				Set("checked_at", sq.Expr("CASE WHEN ? THEN COALESCE(checked_at, NOW()) END", item.Checked))
+		}
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.UpdateChecklistItem", query)
+	defer func() { done(err) }()
+
+	return s.DB.QueryRowxContext(ctx, query, args...).StructScan(item)
+}
+
+func (s *TodoRepository) DeleteChecklistItem(ctx context.Context, todoID, id int64) error {
+	return execAffected(ctx, s.DB, "TodoRepository.DeleteChecklistItem",
+		s.Builder().Delete("checklist_items").Where(sq.Eq{"id": id, "todo_id": todoID}))
+}
+
+// ReorderChecklist numbers the items of the todo in the order of ids, sql.ErrNoRows is
+// returned and nothing is changed when some of the ids are not items of the todo.
+func (s *TodoRepository) ReorderChecklist(ctx context.Context, todoID int64, ids []int64) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `UPDATE checklist_items SET position = ordered.position
+		FROM unnest($1::bigint[]) WITH ORDINALITY AS ordered(id, position)
+		WHERE checklist_items.id = ordered.id AND checklist_items.todo_id = $2`
+	ctx, done := instrument(ctx, "TodoRepository.ReorderChecklist", query)
+	defer func() { done(err) }()
+
+	res, err := tx.ExecContext(ctx, query, pq.Array(ids), todoID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != int64(len(ids)) {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func TestTodoRepository_Checklist(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todo := dto.TodoItem{Title: "water the flowers", Date: &date, Status: "pending", AutoComplete: true}
+	mustCreateTodo(t, &todo)
+
+	got, err := repo.GetTodoByID(ctx, todo.ID)
+	require.NoError(t, err)
+	require.True(t, got.AutoComplete)
+
+	items := []dto.ChecklistItem{
+		{TodoID: todo.ID, Title: "take the watering can", Checked: true},
+		{TodoID: todo.ID, Title: "fill it"},
+		{TodoID: todo.ID, Title: "water"},
+	}
+	for i := range items {
+		require.NoError(t, repo.AddChecklistItem(ctx, &items[i]))
+		require.Equal(t, int64(i+1), items[i].Position)
+	}
+	require.NotNil(t, items[0].CheckedAt)
+	require.Nil(t, items[1].CheckedAt)
+
+	t.Run("unknown todo", func(t *testing.T) {
+		require.ErrorIs(t, repo.AddChecklistItem(ctx, &dto.ChecklistItem{TodoID: -1, Title: "x"}), sql.ErrNoRows)
+	})
+
+	t.Run("check keeps the first time", func(t *testing.T) {
+		item := dto.ChecklistItem{ID: items[0].ID, TodoID: todo.ID, Checked: true}
+		require.NoError(t, repo.UpdateChecklistItem(ctx, &item, []string{model.ChecklistCheckedField}))
+		require.True(t, item.CheckedAt.Equal(*items[0].CheckedAt))
+		require.Equal(t, "take the watering can", item.Title)
+
+		item = dto.ChecklistItem{ID: items[0].ID, TodoID: todo.ID}
+		require.NoError(t, repo.UpdateChecklistItem(ctx, &item, []string{model.ChecklistCheckedField}))
+		require.Nil(t, item.CheckedAt)
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		require.NoError(t, repo.ReorderChecklist(ctx, todo.ID, []int64{items[2].ID, items[0].ID, items[1].ID}))
+		res, err := repo.ListChecklist(ctx, todo.ID)
+		require.NoError(t, err)
+		require.Equal(t, "water", res[0].Title)
+
+		require.ErrorIs(t, repo.ReorderChecklist(ctx, todo.ID, []int64{items[0].ID, -1}), sql.ErrNoRows)
+		res, err = repo.ListChecklist(ctx, todo.ID)
+		require.NoError(t, err)
+		require.Equal(t, "water", res[0].Title)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteChecklistItem(ctx, todo.ID, items[1].ID))
+		require.ErrorIs(t, repo.DeleteChecklistItem(ctx, todo.ID, items[1].ID), sql.ErrNoRows)
+	})
+}
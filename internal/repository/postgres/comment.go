@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+// todoComments selects the number of comments on a todo.
+const todoComments = "(SELECT COUNT(*) FROM comments WHERE comments.todo_id = todos.id) AS comment_count"
+
+// commentAuthorName selects the name of the author of the returned comment.
+const commentAuthorName = "(SELECT name FROM users WHERE users.id = comments.author_id) AS author_name"
+
+type CommentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewCommentRepository(db *sqlx.DB) *CommentRepository {
+	return &CommentRepository{
+		DB: db,
+	}
+}
+
+func (s *CommentRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateComment stores a comment, sql.ErrNoRows is returned when the todo does not exist.
+func (s *CommentRepository) CreateComment(ctx context.Context, comment *dto.Comment) (err error) {
+	query, args, err := s.Builder().Insert("comments").SetMap(map[string]interface{}{
+		"todo_id":   comment.TodoID,
+		"author_id": comment.AuthorID,
+		"body":      comment.Body,
+	}).Suffix("RETURNING id, created_at, " + commentAuthorName).ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "CommentRepository.CreateComment", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(comment)
+	return missingReference(err)
+}
+
+func (s *CommentRepository) GetComment(ctx context.Context, todoID, id int64) (_ dto.Comment, err error) {
+	query, args, err := s.Builder().Select("*", commentAuthorName).
+		From("comments").
+		Where(sq.Eq{"id": id, "todo_id": todoID}).
+		ToSql()
+	if err != nil {
+		return dto.Comment{}, err
+	}
+
+	ctx, done := instrument(ctx, "CommentRepository.GetComment", query)
+	defer func() { done(err) }()
+
+	var res dto.Comment
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.Comment{}, err
+	}
+	return res, nil
+}
+
+// UpdateComment changes the body of the comment and records the edit time.
+func (s *CommentRepository) UpdateComment(ctx context.Context, comment *dto.Comment) (err error) {
+	query, args, err := s.Builder().Update("comments").
+		Set("body", comment.Body).
+		Set("edited_at", time.Now()).
+		Where(sq.Eq{"id": comment.ID, "todo_id": comment.TodoID}).
+		Suffix("RETURNING *, " + commentAuthorName).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "CommentRepository.UpdateComment", query)
+	defer func() { done(err) }()
+
+	return s.DB.QueryRowxContext(ctx, query, args...).StructScan(comment)
+}
+
+func (s *CommentRepository) DeleteComment(ctx context.Context, todoID, id int64) error {
+	return execAffected(ctx, s.DB, "CommentRepository.DeleteComment",
+		s.Builder().Delete("comments").Where(sq.Eq{"id": id, "todo_id": todoID}))
+}
+
+// ListComments returns a page of the comments on the todo, oldest first.
+func (s *CommentRepository) ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) (_ []dto.Comment, _ int64, err error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultLimit
+	}
+	if filter.Limit > MaxLimit {
+		filter.Limit = MaxLimit
+	}
+
+	query, args, err := s.Builder().Select("*", commentAuthorName, "COUNT(*) OVER() AS total_items").
+		From("comments").
+		Where(sq.Eq{"todo_id": todoID}).
+		OrderBy("id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64((filter.Page - 1) * filter.Limit)).
+		ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx, done := instrument(ctx, "CommentRepository.ListComments", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.Comment, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if len(res) != 0 {
+		total = res[0].TotalItems
+	}
+	return res, total, nil
+}
+
+// FindMentioned returns the users with the usernames who may read todos of the project,
+// everybody may read todos outside of projects.
+func (s *CommentRepository) FindMentioned(ctx context.Context, usernames []string, projectID *int64) (_ []dto.User, err error) {
+	q := s.Builder().Select("*").From("users").Where(sq.Eq{"username": usernames}).OrderBy("id")
+	if projectID != nil {
+		q = q.Where("id IN (SELECT user_id FROM project_members WHERE project_id = ?)", *projectID)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "CommentRepository.FindMentioned", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.User, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
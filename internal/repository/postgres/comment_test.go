@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/util/pointer"
+)
+
+func TestCommentRepository(t *testing.T) {
+	r := NewCommentRepository(repo.DB)
+	users := NewUserRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM projects; DELETE FROM users;")
+	require.NoError(t, err)
+	mustTruncate(t)
+
+	ann := dto.User{Email: "ann@example.com", Name: "Ann", Username: pointer.Pointer("ann")}
+	require.NoError(t, users.CreateUser(ctx, &ann))
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todo := dto.TodoItem{Title: "release", Date: &date, Status: "pending"}
+	mustCreateTodo(t, &todo)
+
+	t.Run("username is unique", func(t *testing.T) {
+		u := dto.User{Email: "other@example.com", Name: "Other", Username: pointer.Pointer("ann")}
+		require.True(t, errors.Is(users.CreateUser(ctx, &u), errs.ErrConflict))
+	})
+
+	comment := dto.Comment{TodoID: todo.ID, AuthorID: &ann.ID, Body: "first"}
+	require.NoError(t, r.CreateComment(ctx, &comment))
+	require.Equal(t, "Ann", *comment.AuthorName)
+	require.NoError(t, r.CreateComment(ctx, &dto.Comment{TodoID: todo.ID, Body: "second"}))
+
+	t.Run("unknown todo", func(t *testing.T) {
+		require.ErrorIs(t, r.CreateComment(ctx, &dto.Comment{TodoID: -1, Body: "x"}), sql.ErrNoRows)
+	})
+
+	t.Run("list with count", func(t *testing.T) {
+		res, total, err := r.ListComments(ctx, todo.ID, dto.CommentFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), total)
+		require.Len(t, res, 1)
+		require.Equal(t, "first", res[0].Body)
+
+		item, err := repo.GetTodoByID(ctx, todo.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), item.CommentCount)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		edited := dto.Comment{ID: comment.ID, TodoID: todo.ID, Body: "edited"}
+		require.NoError(t, r.UpdateComment(ctx, &edited))
+		require.NotNil(t, edited.EditedAt)
+		require.Equal(t, ann.ID, *edited.AuthorID)
+	})
+
+	t.Run("mentioned users", func(t *testing.T) {
+		res, err := r.FindMentioned(ctx, []string{"ann", "nobody"}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		// ann is not a member of the project
+		project := dto.Project{Name: "secret"}
+		require.NoError(t, NewProjectRepository(repo.DB).CreateProject(ctx, &project, mustCreateUser(t, "owner@example.com").ID))
+		res, err = r.FindMentioned(ctx, []string{"ann"}, &project.ID)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, r.DeleteComment(ctx, todo.ID, comment.ID))
+		require.ErrorIs(t, r.DeleteComment(ctx, todo.ID, comment.ID), sql.ErrNoRows)
+	})
+}
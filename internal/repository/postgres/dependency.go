@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// todoBlocked selects whether a todo has a blocker which is neither completed nor cancelled.
+const todoBlocked = `EXISTS (SELECT 1 FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id
+	WHERE d.todo_id = todos.id AND b.status NOT IN ('completed', 'cancelled')) AS blocked`
+
+// todoDependencyClosure selects the dependencies of the todos $1 and of all their
+// blockers in turn.
+const todoDependencyClosure = `WITH RECURSIVE closure AS (
+	SELECT todo_id, blocker_id FROM todo_dependencies WHERE todo_id = ANY($1)
+	UNION
+	SELECT d.todo_id, d.blocker_id FROM todo_dependencies d JOIN closure ON d.todo_id = closure.blocker_id
+)
+SELECT todo_id, blocker_id FROM closure ORDER BY todo_id, blocker_id`
+
+// dependenciesLock serializes adding dependencies, so concurrent links can not form a
+// cycle together.
+const dependenciesLock = 7370002
+
+// todoDependencyCycle selects whether the todo $2 is among the blockers of the todo $1,
+// directly or through other todos.
+const todoDependencyCycle = `WITH RECURSIVE closure AS (
+	SELECT todo_id, blocker_id FROM todo_dependencies WHERE todo_id = $1
+	UNION
+	SELECT d.todo_id, d.blocker_id FROM todo_dependencies d JOIN closure ON d.todo_id = closure.blocker_id
+)
+SELECT EXISTS (SELECT 1 FROM closure WHERE blocker_id = $2)`
+
+// AddDependency stores a dependency, adding an existing one has no effect. sql.ErrNoRows
+// is returned for an unknown todo and model.ErrDependencyCycle when the blocker already
+// depends on the todo.
+func (s *TodoRepository) AddDependency(ctx context.Context, dep dto.TodoDependency) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = lockDependencies(ctx, tx); err != nil {
+		return err
+	}
+	cycle, err := dependsOn(ctx, tx, dep.BlockerID, dep.TodoID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return model.ErrDependencyCycle
+	}
+
+	query, args, err := s.Builder().Insert("todo_dependencies").
+		Columns("todo_id", "blocker_id").
+		Values(dep.TodoID, dep.BlockerID).
+		Suffix("ON CONFLICT (todo_id, blocker_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	insertCtx, done := instrument(ctx, "TodoRepository.AddDependency", query)
+	_, err = tx.ExecContext(insertCtx, query, args...)
+	done(err)
+	if err != nil {
+		return missingReference(err)
+	}
+	return tx.Commit()
+}
+
+func lockDependencies(ctx context.Context, tx *sqlx.Tx) (err error) {
+	query := "SELECT pg_advisory_xact_lock($1)"
+	ctx, done := instrument(ctx, "TodoRepository.LockDependencies", query)
+	defer func() { done(err) }()
+
+	_, err = tx.ExecContext(ctx, query, dependenciesLock)
+	return err
+}
+
+// dependsOn reports whether the todo is blocked by the blocker directly or through other todos.
+func dependsOn(ctx context.Context, tx *sqlx.Tx, todoID, blockerID int64) (res bool, err error) {
+	ctx, done := instrument(ctx, "TodoRepository.DependsOn", todoDependencyCycle)
+	defer func() { done(err) }()
+
+	err = tx.GetContext(ctx, &res, todoDependencyCycle, todoID, blockerID)
+	return res, err
+}
+
+// DeleteDependency removes a dependency, sql.ErrNoRows is returned when there is none.
+func (s *TodoRepository) DeleteDependency(ctx context.Context, todoID, blockerID int64) error {
+	return execAffected(ctx, s.DB, "TodoRepository.DeleteDependency",
+		s.Builder().Delete("todo_dependencies").Where(sq.Eq{"todo_id": todoID, "blocker_id": blockerID}))
+}
+
+// ListDependencies returns the dependencies of the todos and, transitively, of their blockers.
+func (s *TodoRepository) ListDependencies(ctx context.Context, ids []int64) (_ []dto.TodoDependency, err error) {
+	ctx, done := instrument(ctx, "TodoRepository.ListDependencies", todoDependencyClosure)
+	defer func() { done(err) }()
+
+	res := make([]dto.TodoDependency, 0)
+	if err = s.DB.SelectContext(ctx, &res, todoDependencyClosure, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListLinkedTodos returns the todos the todo is blocked by and the todos it blocks.
+func (s *TodoRepository) ListLinkedTodos(ctx context.Context, id int64) (blockedBy, blocks []dto.TodoItem, err error) {
+	blockedBy, err = s.selectTodos(ctx, "TodoRepository.ListBlockers",
+		sq.Expr("id IN (SELECT blocker_id FROM todo_dependencies WHERE todo_id = ?)", id))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocks, err = s.selectTodos(ctx, "TodoRepository.ListDependents",
+		sq.Expr("id IN (SELECT todo_id FROM todo_dependencies WHERE blocker_id = ?)", id))
+	if err != nil {
+		return nil, nil, err
+	}
+	return blockedBy, blocks, nil
+}
+
+// GetTodosByIDs returns the todos found by id ordered by id.
+func (s *TodoRepository) GetTodosByIDs(ctx context.Context, ids []int64) ([]dto.TodoItem, error) {
+	return s.selectTodos(ctx, "TodoRepository.GetTodosByIDs", sq.Eq{"id": ids})
+}
+
+func (s *TodoRepository) selectTodos(ctx context.Context, operation string, where sq.Sqlizer) (_ []dto.TodoItem, err error) {
+	query, args, err := s.Builder().Select("id", strings.Join(model.TodoFields, ", "), "position", "created_at", "updated_at", todoBlocked, todoComments, todoTracked).
+		From("todos").
+		Where(where).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, operation, query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TodoItem, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
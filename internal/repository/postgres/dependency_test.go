@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func TestTodoRepository_Dependencies(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todos := []dto.TodoItem{
+		{Title: "design", Date: &date, Status: "pending"},
+		{Title: "build", Date: &date, Status: "pending"},
+		{Title: "ship", Date: &date, Status: "pending"},
+	}
+	mustCreateTodos(t, todos)
+	design, build, ship := todos[0].ID, todos[1].ID, todos[2].ID
+
+	require.NoError(t, repo.AddDependency(ctx, dto.TodoDependency{TodoID: build, BlockerID: design}))
+	require.NoError(t, repo.AddDependency(ctx, dto.TodoDependency{TodoID: ship, BlockerID: build}))
+	// adding a dependency twice has no effect
+	require.NoError(t, repo.AddDependency(ctx, dto.TodoDependency{TodoID: ship, BlockerID: build}))
+
+	t.Run("unknown todo", func(t *testing.T) {
+		err := repo.AddDependency(ctx, dto.TodoDependency{TodoID: ship, BlockerID: -1})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		err := repo.AddDependency(ctx, dto.TodoDependency{TodoID: design, BlockerID: ship})
+		require.ErrorIs(t, err, model.ErrDependencyCycle)
+	})
+
+	t.Run("closure", func(t *testing.T) {
+		res, err := repo.ListDependencies(ctx, []int64{ship})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+	})
+
+	t.Run("blocked until the blocker is done", func(t *testing.T) {
+		item, err := repo.GetTodoByID(ctx, build)
+		require.NoError(t, err)
+		require.True(t, item.Blocked)
+
+		done := dto.TodoItem{ID: design, Status: "completed"}
+		require.NoError(t, repo.UpdateTodo(ctx, &done, []string{"status"}))
+		require.False(t, done.Blocked)
+
+		item, err = repo.GetTodoByID(ctx, build)
+		require.NoError(t, err)
+		require.False(t, item.Blocked)
+	})
+
+	t.Run("linked todos", func(t *testing.T) {
+		blockedBy, blocks, err := repo.ListLinkedTodos(ctx, build)
+		require.NoError(t, err)
+		require.Len(t, blockedBy, 1)
+		require.Equal(t, design, blockedBy[0].ID)
+		require.Len(t, blocks, 1)
+		require.Equal(t, ship, blocks[0].ID)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteDependency(ctx, ship, build))
+		require.ErrorIs(t, repo.DeleteDependency(ctx, ship, build), sql.ErrNoRows)
+	})
+}
+
+func TestTodoRepository_AddDependency_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todos := []dto.TodoItem{
+		{Title: "a", Date: &date, Status: "pending"},
+		{Title: "b", Date: &date, Status: "pending"},
+	}
+	mustCreateTodos(t, todos)
+	a, b := todos[0].ID, todos[1].ID
+
+	errs := make(chan error, 2)
+	for _, dep := range []dto.TodoDependency{{TodoID: a, BlockerID: b}, {TodoID: b, BlockerID: a}} {
+		go func(dep dto.TodoDependency) {
+			errs <- repo.AddDependency(ctx, dep)
+		}(dep)
+	}
+
+	var failed int
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			require.ErrorIs(t, err, model.ErrDependencyCycle)
+			failed++
+		}
+	}
+	require.Equal(t, 1, failed)
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"todo-list/internal/domain/errs"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// missingReference reports sql.ErrNoRows instead of a foreign key violation, so services
+// treat a write referencing an unknown row like a lookup of that row.
+func missingReference(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+// duplicateKey reports errs.ErrConflict instead of a unique violation.
+func duplicateKey(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: %s", errs.ErrConflict, pqErr.Constraint)
+	}
+	return err
+}
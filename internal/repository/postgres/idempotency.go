@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+type IdempotencyRepository struct {
+	DB *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		DB: db,
+	}
+}
+
+func (s *IdempotencyRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// Reserve stores a new key. When a live key already exists it is returned with created=false.
+// An expired key is replaced as if it never existed.
+func (s *IdempotencyRepository) Reserve(ctx context.Context, key *dto.IdempotencyKey) (_ dto.IdempotencyKey, created bool, err error) {
+	q := s.Builder().Insert("idempotency_keys").SetMap(map[string]interface{}{
+		"client_key":  key.ClientKey,
+		"key":         key.Key,
+		"fingerprint": key.Fingerprint,
+		"expires_at":  key.ExpiresAt,
+	}).Suffix(`ON CONFLICT (client_key, key) DO UPDATE SET
+		fingerprint = EXCLUDED.fingerprint,
+		status_code = NULL,
+		content_type = NULL,
+		response_body = NULL,
+		created_at = NOW(),
+		expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		RETURNING *`)
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return dto.IdempotencyKey{}, false, err
+	}
+
+	ctx, done := instrument(ctx, "IdempotencyRepository.Reserve", query)
+	defer func() { done(err) }()
+
+	var res dto.IdempotencyKey
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res)
+	if err == nil {
+		return res, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dto.IdempotencyKey{}, false, err
+	}
+
+	// conflict with a live key: nothing was returned, read the stored one
+	err = s.DB.QueryRowxContext(ctx, "SELECT * FROM idempotency_keys WHERE client_key = $1 AND key = $2",
+		key.ClientKey, key.Key).StructScan(&res)
+	if err != nil {
+		return dto.IdempotencyKey{}, false, err
+	}
+
+	return res, false, nil
+}
+
+// Complete stores the response of the request reserved the key of the client.
+func (s *IdempotencyRepository) Complete(ctx context.Context, clientKey, key string, statusCode int, contentType string, body []byte) (err error) {
+	q := s.Builder().Update("idempotency_keys").
+		Set("status_code", statusCode).
+		Set("content_type", contentType).
+		Set("response_body", body).
+		Where(sq.Eq{"client_key": clientKey, "key": key})
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "IdempotencyRepository.Complete", query)
+	defer func() { done(err) }()
+
+	_, err = s.DB.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Release removes a key of the client, so the request may be retried with it.
+func (s *IdempotencyRepository) Release(ctx context.Context, clientKey, key string) (err error) {
+	query, args, err := s.Builder().Delete("idempotency_keys").Where(sq.Eq{"client_key": clientKey, "key": key}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "IdempotencyRepository.Release", query)
+	defer func() { done(err) }()
+
+	_, err = s.DB.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteExpired purges keys which TTL has passed.
+func (s *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (_ int64, err error) {
+	query, args, err := s.Builder().Delete("idempotency_keys").Where(sq.Lt{"expires_at": now}).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, done := instrument(ctx, "IdempotencyRepository.DeleteExpired", query)
+	defer func() { done(err) }()
+
+	res, err := s.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// RunCleanup periodically purges expired keys until ctx is done.
+func (s *IdempotencyRepository) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := s.DeleteExpired(ctx, now)
+			if err != nil {
+				slog.ErrorContext(ctx, "delete expired idempotency keys", slog.Any("error", err))
+				continue
+			}
+			if deleted > 0 {
+				slog.InfoContext(ctx, "expired idempotency keys deleted", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	r := NewIdempotencyRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM idempotency_keys;")
+	require.NoError(t, err)
+
+	t.Run("reserve, complete and replay", func(t *testing.T) {
+		_, created, err := r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:1",
+			Key:         "key-1",
+			Fingerprint: "fp-1",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.True(t, created)
+
+		stored, created, err := r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:1",
+			Key:         "key-1",
+			Fingerprint: "fp-2",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.False(t, created)
+		require.Equal(t, "fp-1", stored.Fingerprint)
+		require.False(t, stored.Completed())
+
+		require.NoError(t, r.Complete(ctx, "key:1", "key-1", 200, "application/json", []byte(`{"id":1}`)))
+
+		stored, created, err = r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:1",
+			Key:         "key-1",
+			Fingerprint: "fp-1",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.False(t, created)
+		require.True(t, stored.Completed())
+		require.Equal(t, 200, *stored.StatusCode)
+		require.Equal(t, []byte(`{"id":1}`), stored.ResponseBody)
+	})
+
+	t.Run("keys are per client", func(t *testing.T) {
+		stored, created, err := r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:2",
+			Key:         "key-1",
+			Fingerprint: "fp-3",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Equal(t, "fp-3", stored.Fingerprint)
+
+		require.NoError(t, r.Release(ctx, "key:2", "key-1"))
+	})
+
+	t.Run("expired key is replaced", func(t *testing.T) {
+		_, created, err := r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:1",
+			Key:         "key-2",
+			Fingerprint: "fp-1",
+			ExpiresAt:   time.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+		require.True(t, created)
+
+		stored, created, err := r.Reserve(ctx, &dto.IdempotencyKey{
+			ClientKey:   "key:1",
+			Key:         "key-2",
+			Fingerprint: "fp-2",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Equal(t, "fp-2", stored.Fingerprint)
+	})
+
+	t.Run("delete expired", func(t *testing.T) {
+		deleted, err := r.DeleteExpired(ctx, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+	})
+}
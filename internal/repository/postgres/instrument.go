@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+var tracer = otel.Tracer("todo-list/internal/repository/postgres")
+
+// instrument opens a client span named after the repository method for a single query
+// and logs it. The returned function ends the span and must be deferred with the final
+// error of the query.
+func instrument(ctx context.Context, operation, query string) (context.Context, func(err error)) {
+	ctx, span := tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(query),
+			attribute.String("db.operation", operation),
+		),
+	)
+	start := time.Now()
+
+	return ctx, func(err error) {
+		attrs := []slog.Attr{
+			slog.String("operation", operation),
+			slog.String("query", query),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			slog.LogAttrs(ctx, slog.LevelError, "query failed", append(attrs, slog.Any("error", err))...)
+		} else {
+			slog.LogAttrs(ctx, slog.LevelDebug, "query", attrs...)
+		}
+		span.End()
+	}
+}
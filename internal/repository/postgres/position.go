@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"log/slog"
+	"time"
+	"todo-list/internal/util/rank"
+)
+
+// positionsLock serializes moves and rebalancing of todo positions.
+const positionsLock = 7370001
+
+func lockPositions(ctx context.Context, tx *sqlx.Tx) (err error) {
+	query := "SELECT pg_advisory_xact_lock($1)"
+	ctx, done := instrument(ctx, "TodoRepository.LockPositions", query)
+	defer func() { done(err) }()
+
+	_, err = tx.ExecContext(ctx, query, positionsLock)
+	return err
+}
+
+// queryPosition returns the position selected by q, an empty string when there is no row.
+func queryPosition(ctx context.Context, q sqlx.QueryerContext, operation string, b sq.SelectBuilder) (_ string, err error) {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, done := instrument(ctx, operation, query)
+	defer func() { done(err) }()
+
+	var position string
+	err = q.QueryRowxContext(ctx, query, args...).Scan(&position)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return position, err
+}
+
+// positionNear returns a rank right before or after the target todo, the moved todo
+// itself is not taken as a neighbour.
+func positionNear(ctx context.Context, tx *sqlx.Tx, id, targetID int64, after bool) (_ string, err error) {
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+
+	query, args, err := b.Select("position").From("todos").Where(sq.Eq{"id": targetID}).ToSql()
+	if err != nil {
+		return "", err
+	}
+	var target string
+	targetCtx, done := instrument(ctx, "TodoRepository.GetPosition", query)
+	err = tx.QueryRowxContext(targetCtx, query, args...).Scan(&target)
+	done(err)
+	if err != nil {
+		return "", err
+	}
+
+	neighbour := b.Select("position").From("todos").Where(sq.NotEq{"id": id}).Limit(1)
+	if after {
+		neighbour = neighbour.Where("(position, id) > (?, ?)", target, targetID).OrderBy("position", "id")
+	} else {
+		neighbour = neighbour.Where("(position, id) < (?, ?)", target, targetID).OrderBy("position DESC", "id DESC")
+	}
+	near, err := queryPosition(ctx, tx, "TodoRepository.GetNeighbourPosition", neighbour)
+	if err != nil {
+		return "", err
+	}
+
+	if after {
+		return rank.Between(target, near)
+	}
+	return rank.Between(near, target)
+}
+
+// MoveTodo places the todo right before or after the target todo, only the moved row
+// is updated. When the neighbours leave no room between them (equal positions) all
+// positions are rebalanced first. sql.ErrNoRows is returned for unknown todos.
+func (s *TodoRepository) MoveTodo(ctx context.Context, id, targetID int64, after bool) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = lockPositions(ctx, tx); err != nil {
+		return err
+	}
+
+	position, err := positionNear(ctx, tx, id, targetID, after)
+	if errors.Is(err, rank.ErrOrder) {
+		if _, err = rebalancePositions(ctx, tx); err != nil {
+			return err
+		}
+		position, err = positionNear(ctx, tx, id, targetID, after)
+	}
+	if err != nil {
+		return err
+	}
+
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+	err = execAffected(ctx, tx, "TodoRepository.MoveTodo",
+		b.Update("todos").Set("position", position).Where(sq.Eq{"id": id}))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func rebalancePositions(ctx context.Context, tx *sqlx.Tx) (_ int64, err error) {
+	query := "SELECT id FROM todos ORDER BY position, id"
+	selectCtx, done := instrument(ctx, "TodoRepository.ListPositions", query)
+	var ids []int64
+	err = tx.SelectContext(selectCtx, &ids, query)
+	done(err)
+	if err != nil {
+		return 0, err
+	}
+
+	query = `UPDATE todos SET position = ranked.position
+		FROM unnest($1::bigint[], $2::varchar[]) AS ranked(id, position)
+		WHERE todos.id = ranked.id`
+	ctx, done = instrument(ctx, "TodoRepository.RebalancePositions", query)
+	defer func() { done(err) }()
+
+	res, err := tx.ExecContext(ctx, query, pq.Array(ids), pq.Array(rank.Spread(len(ids))))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RebalancePositions rewrites positions of all todos to short evenly spaced ranks
+// keeping their order.
+func (s *TodoRepository) RebalancePositions(ctx context.Context) (_ int64, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = lockPositions(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	updated, err := rebalancePositions(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, tx.Commit()
+}
+
+func (s *TodoRepository) MaxPositionLength(ctx context.Context) (_ int64, err error) {
+	query := "SELECT COALESCE(MAX(LENGTH(position)), 0) FROM todos"
+	ctx, done := instrument(ctx, "TodoRepository.MaxPositionLength", query)
+	defer func() { done(err) }()
+
+	var length int64
+	err = s.DB.QueryRowxContext(ctx, query).Scan(&length)
+	return length, err
+}
+
+// RunRebalance periodically rebalances positions once the longest one exceeds maxLength,
+// until ctx is done.
+func (s *TodoRepository) RunRebalance(ctx context.Context, interval time.Duration, maxLength int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			length, err := s.MaxPositionLength(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "get max todo position length", slog.Any("error", err))
+				continue
+			}
+			if length <= maxLength {
+				continue
+			}
+
+			updated, err := s.RebalancePositions(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "rebalance todo positions", slog.Any("error", err))
+				continue
+			}
+			slog.InfoContext(ctx, "todo positions rebalanced", slog.Int64("count", updated), slog.Int64("max_length", length))
+		}
+	}
+}
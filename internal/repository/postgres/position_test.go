@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/util/pointer"
+)
+
+func manualOrder(t *testing.T) []int64 {
+	items, _, err := repo.ListTodos(context.Background(), dto.TodoFilter{Sort: dto.TodoSortManual})
+	require.NoError(t, err)
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestTodoRepository_MoveTodo(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	date := pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+	input := []dto.TodoItem{
+		{Title: "a", Date: date, Status: "pending"},
+		{Title: "b", Date: date, Status: "pending"},
+		{Title: "c", Date: date, Status: "pending"},
+	}
+	mustCreateTodos(t, input)
+	a, b, c := input[0].ID, input[1].ID, input[2].ID
+	require.Equal(t, []int64{a, b, c}, manualOrder(t))
+
+	t.Run("move before", func(t *testing.T) {
+		require.NoError(t, repo.MoveTodo(ctx, c, a, false))
+		require.Equal(t, []int64{c, a, b}, manualOrder(t))
+	})
+
+	t.Run("move after", func(t *testing.T) {
+		require.NoError(t, repo.MoveTodo(ctx, c, b, true))
+		require.Equal(t, []int64{a, b, c}, manualOrder(t))
+	})
+
+	t.Run("equal positions are rebalanced", func(t *testing.T) {
+		_, err := repo.DB.Exec("UPDATE todos SET position = 'i'")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.MoveTodo(ctx, c, a, true))
+		require.Equal(t, []int64{a, c, b}, manualOrder(t))
+	})
+
+	t.Run("rebalance keeps order", func(t *testing.T) {
+		for i := 0; i < 40; i++ {
+			require.NoError(t, repo.MoveTodo(ctx, b, c, false))
+			require.NoError(t, repo.MoveTodo(ctx, c, b, false))
+		}
+		before := manualOrder(t)
+		length, err := repo.MaxPositionLength(ctx)
+		require.NoError(t, err)
+
+		updated, err := repo.RebalancePositions(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), updated)
+		require.Equal(t, before, manualOrder(t))
+
+		shorter, err := repo.MaxPositionLength(ctx)
+		require.NoError(t, err)
+		require.Less(t, shorter, length)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		require.ErrorIs(t, repo.MoveTodo(ctx, a, 1<<30, true), sql.ErrNoRows)
+		require.ErrorIs(t, repo.MoveTodo(ctx, 1<<30, a, true), sql.ErrNoRows)
+	})
+
+	mustTruncate(t)
+}
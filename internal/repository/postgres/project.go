@@ -0,0 +1,401 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+type ProjectRepository struct {
+	DB *sqlx.DB
+}
+
+func NewProjectRepository(db *sqlx.DB) *ProjectRepository {
+	return &ProjectRepository{
+		DB: db,
+	}
+}
+
+func (s *ProjectRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+func execAffected(ctx context.Context, e sqlx.ExecerContext, operation string, q sq.Sqlizer) (err error) {
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, operation, query)
+	defer func() { done(err) }()
+
+	res, err := e.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func insertMember(ctx context.Context, e sqlx.ExecerContext, b sq.StatementBuilderType, projectID, userID int64, role string) (err error) {
+	query, args, err := b.Insert("project_members").SetMap(map[string]interface{}{
+		"project_id": projectID,
+		"user_id":    userID,
+		"role":       role,
+	}).Suffix("ON CONFLICT (project_id, user_id) DO NOTHING").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.AddMember", query)
+	defer func() { done(err) }()
+
+	_, err = e.ExecContext(ctx, query, args...)
+	err = missingReference(err)
+	return err
+}
+
+// CreateProject stores the project with ownerID as its owner in a single transaction,
+// sql.ErrNoRows is returned for an unknown owner. Without a position the project is put last.
+func (s *ProjectRepository) CreateProject(ctx context.Context, project *dto.Project, ownerID int64) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var position interface{} = project.Position
+	if project.Position == 0 {
+		position = sq.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM projects)")
+	}
+
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+	query, args, err := b.Insert("projects").SetMap(map[string]interface{}{
+		model.ProjectNameField:     project.Name,
+		model.ProjectColorField:    project.Color,
+		model.ProjectArchivedField: project.Archived,
+		model.ProjectPositionField: position,
+	}).Suffix("RETURNING id, position, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	insertCtx, done := instrument(ctx, "ProjectRepository.CreateProject", query)
+	err = tx.QueryRowxContext(insertCtx, query, args...).StructScan(project)
+	done(err)
+	if err != nil {
+		return err
+	}
+
+	owner := string(model.ProjectRoleOwner)
+	if err = insertMember(ctx, tx, b, project.ID, ownerID, owner); err != nil {
+		return err
+	}
+	project.Role = &owner
+
+	return tx.Commit()
+}
+
+// selectProjects returns projects with counts of their todos and the role of userID,
+// a zero userID selects all projects without a role.
+func (s *ProjectRepository) selectProjects(userID int64) sq.SelectBuilder {
+	counts := `LEFT JOIN (
+		SELECT project_id,
+			COUNT(*) FILTER (WHERE status NOT IN (?, ?)) AS pending_todos,
+			COUNT(*) FILTER (WHERE status = ?) AS completed_todos
+		FROM todos
+		WHERE project_id IS NOT NULL
+		GROUP BY project_id
+	) counts ON counts.project_id = projects.id`
+	columns := []string{
+		"projects.*",
+		"COALESCE(counts.pending_todos, 0) AS pending_todos",
+		"COALESCE(counts.completed_todos, 0) AS completed_todos",
+	}
+
+	if userID == 0 {
+		return s.Builder().Select(append(columns, "NULL AS role")...).
+			From("projects").
+			JoinClause(counts, model.TodoStatusCompleted, model.TodoStatusCancelled, model.TodoStatusCompleted)
+	}
+	return s.Builder().Select(append(columns, "project_members.role")...).
+		From("projects").
+		Join("project_members ON project_members.project_id = projects.id AND project_members.user_id = ?", userID).
+		JoinClause(counts, model.TodoStatusCompleted, model.TodoStatusCancelled, model.TodoStatusCompleted)
+}
+
+// GetProject returns the project, for a non-zero userID only when the user is a member.
+func (s *ProjectRepository) GetProject(ctx context.Context, id, userID int64) (_ dto.Project, err error) {
+	query, args, err := s.selectProjects(userID).Where(sq.Eq{"projects.id": id}).ToSql()
+	if err != nil {
+		return dto.Project{}, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.GetProject", query)
+	defer func() { done(err) }()
+
+	var res dto.Project
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.Project{}, err
+	}
+
+	return res, nil
+}
+
+// ListProjects returns projects ordered by position. Archived projects are skipped unless
+// requested, a zero MemberID lists projects of all users.
+func (s *ProjectRepository) ListProjects(ctx context.Context, filter dto.ProjectFilter) (_ []dto.Project, err error) {
+	q := s.selectProjects(filter.MemberID).OrderBy("projects.position", "projects.id")
+	if !filter.IncludeArchived {
+		q = q.Where(sq.Eq{"projects.archived": false})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.ListProjects", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.Project, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+var projectCol = map[string]func(p *dto.Project) interface{}{
+	model.ProjectNameField:     func(p *dto.Project) interface{} { return p.Name },
+	model.ProjectColorField:    func(p *dto.Project) interface{} { return p.Color },
+	model.ProjectArchivedField: func(p *dto.Project) interface{} { return p.Archived },
+	model.ProjectPositionField: func(p *dto.Project) interface{} { return p.Position },
+}
+
+func (s *ProjectRepository) UpdateProject(ctx context.Context, project *dto.Project, updatedFields []string) error {
+	q := s.Builder().Update("projects").
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": project.ID})
+
+	for _, field := range updatedFields {
+		getter, ok := projectCol[field]
+		if !ok {
+			return fmt.Errorf("field %s not found", field)
+		}
+		q = q.Set(field, getter(project))
+	}
+
+	return execAffected(ctx, s.DB, "ProjectRepository.UpdateProject", q)
+}
+
+// DeleteProject removes the project together with its todos, members and invitations.
+func (s *ProjectRepository) DeleteProject(ctx context.Context, id int64) error {
+	return execAffected(ctx, s.DB, "ProjectRepository.DeleteProject",
+		s.Builder().Delete("projects").Where(sq.Eq{"id": id}))
+}
+
+func getMemberRole(ctx context.Context, q sqlx.QueryerContext, b sq.StatementBuilderType, operation string, projectID, userID int64) (_ string, err error) {
+	query, args, err := b.Select("role").
+		From("project_members").
+		Where(sq.Eq{"project_id": projectID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, done := instrument(ctx, operation, query)
+	defer func() { done(err) }()
+
+	var role string
+	if err = q.QueryRowxContext(ctx, query, args...).Scan(&role); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// GetMemberRole returns the role of the user in the project, sql.ErrNoRows is returned for non-members.
+func (s *ProjectRepository) GetMemberRole(ctx context.Context, projectID, userID int64) (string, error) {
+	return getMemberRole(ctx, s.DB, s.Builder(), "ProjectRepository.GetMemberRole", projectID, userID)
+}
+
+func (s *ProjectRepository) ListMembers(ctx context.Context, projectID int64) (_ []dto.ProjectMember, err error) {
+	query, args, err := s.Builder().
+		Select("project_members.*", "users.email", "users.name").
+		From("project_members").
+		Join("users ON users.id = project_members.user_id").
+		Where(sq.Eq{"project_members.project_id": projectID}).
+		OrderBy("project_members.created_at", "project_members.user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.ListMembers", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.ProjectMember, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *ProjectRepository) CountOwners(ctx context.Context, projectID int64) (_ int64, err error) {
+	query, args, err := s.Builder().Select("COUNT(*)").
+		From("project_members").
+		Where(sq.Eq{"project_id": projectID, "role": string(model.ProjectRoleOwner)}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.CountOwners", query)
+	defer func() { done(err) }()
+
+	var count int64
+	if err = s.DB.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *ProjectRepository) UpdateMemberRole(ctx context.Context, projectID, userID int64, role string) error {
+	return execAffected(ctx, s.DB, "ProjectRepository.UpdateMemberRole",
+		s.Builder().Update("project_members").
+			Set("role", role).
+			Where(sq.Eq{"project_id": projectID, "user_id": userID}))
+}
+
+func (s *ProjectRepository) RemoveMember(ctx context.Context, projectID, userID int64) error {
+	return execAffected(ctx, s.DB, "ProjectRepository.RemoveMember",
+		s.Builder().Delete("project_members").
+			Where(sq.Eq{"project_id": projectID, "user_id": userID}))
+}
+
+// CreateInvitation stores a pending invitation, sql.ErrNoRows is returned when the address
+// already has a pending invitation to the project.
+func (s *ProjectRepository) CreateInvitation(ctx context.Context, inv *dto.Invitation) (err error) {
+	query, args, err := s.Builder().Insert("project_invitations").SetMap(map[string]interface{}{
+		"project_id": inv.ProjectID,
+		"email":      inv.Email,
+		"role":       inv.Role,
+		"invited_by": inv.InvitedBy,
+	}).Suffix("ON CONFLICT (project_id, email) WHERE status = 'pending' DO NOTHING RETURNING id, status, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.CreateInvitation", query)
+	defer func() { done(err) }()
+
+	return s.DB.QueryRowxContext(ctx, query, args...).StructScan(inv)
+}
+
+func (s *ProjectRepository) selectInvitations() sq.SelectBuilder {
+	return s.Builder().Select("project_invitations.*", "projects.name AS project_name").
+		From("project_invitations").
+		Join("projects ON projects.id = project_invitations.project_id")
+}
+
+func (s *ProjectRepository) GetInvitation(ctx context.Context, id int64) (_ dto.Invitation, err error) {
+	query, args, err := s.selectInvitations().Where(sq.Eq{"project_invitations.id": id}).ToSql()
+	if err != nil {
+		return dto.Invitation{}, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.GetInvitation", query)
+	defer func() { done(err) }()
+
+	var res dto.Invitation
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.Invitation{}, err
+	}
+
+	return res, nil
+}
+
+func (s *ProjectRepository) ListInvitations(ctx context.Context, f dto.InvitationFilter) (_ []dto.Invitation, err error) {
+	q := s.selectInvitations().OrderBy("project_invitations.id")
+	if f.ProjectID != 0 {
+		q = q.Where(sq.Eq{"project_invitations.project_id": f.ProjectID})
+	}
+	if f.Email != "" {
+		q = q.Where(sq.Eq{"project_invitations.email": f.Email})
+	}
+	if f.Status != "" {
+		q = q.Where(sq.Eq{"project_invitations.status": f.Status})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "ProjectRepository.ListInvitations", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.Invitation, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RespondInvitation closes a pending invitation with the status, an accepted invitation makes
+// userID a member of the project. Existing memberships are kept as they are.
+// sql.ErrNoRows is returned when the invitation is not pending anymore.
+func (s *ProjectRepository) RespondInvitation(ctx context.Context, id int64, status string, userID int64) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+	query, args, err := b.Update("project_invitations").
+		Set("status", status).
+		Set("responded_at", time.Now()).
+		Where(sq.Eq{"id": id, "status": string(model.InvitationPending)}).
+		Suffix("RETURNING project_id, role").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	var (
+		projectID int64
+		role      string
+	)
+	updateCtx, done := instrument(ctx, "ProjectRepository.RespondInvitation", query)
+	err = tx.QueryRowxContext(updateCtx, query, args...).Scan(&projectID, &role)
+	done(err)
+	if err != nil {
+		return err
+	}
+
+	if status == string(model.InvitationAccepted) {
+		if err = insertMember(ctx, tx, b, projectID, userID, role); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
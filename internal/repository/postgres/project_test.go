@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/util/pointer"
+)
+
+func mustCreateUser(t *testing.T, email string) dto.User {
+	u := dto.User{Email: email, Name: email}
+	require.NoError(t, NewUserRepository(repo.DB).CreateUser(context.Background(), &u))
+	return u
+}
+
+func TestProjectRepository(t *testing.T) {
+	r := NewProjectRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM projects; DELETE FROM users;")
+	require.NoError(t, err)
+	mustTruncate(t)
+
+	ann := mustCreateUser(t, "ann@example.com")
+	bob := mustCreateUser(t, "bob@example.com")
+
+	p := dto.Project{Name: "team"}
+	require.NoError(t, r.CreateProject(ctx, &p, ann.ID))
+	require.Equal(t, "owner", *p.Role)
+
+	t.Run("invitation makes a member", func(t *testing.T) {
+		inv := dto.Invitation{ProjectID: p.ID, Email: bob.Email, Role: "viewer", InvitedBy: &ann.ID}
+		require.NoError(t, r.CreateInvitation(ctx, &inv))
+		require.Equal(t, "pending", inv.Status)
+
+		dup := dto.Invitation{ProjectID: p.ID, Email: bob.Email, Role: "editor"}
+		require.ErrorIs(t, r.CreateInvitation(ctx, &dup), sql.ErrNoRows)
+
+		require.NoError(t, r.RespondInvitation(ctx, inv.ID, "accepted", bob.ID))
+		require.ErrorIs(t, r.RespondInvitation(ctx, inv.ID, "declined", bob.ID), sql.ErrNoRows)
+
+		role, err := r.GetMemberRole(ctx, p.ID, bob.ID)
+		require.NoError(t, err)
+		require.Equal(t, "viewer", role)
+
+		members, err := r.ListMembers(ctx, p.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+	})
+
+	t.Run("projects of a member", func(t *testing.T) {
+		other := dto.Project{Name: "private"}
+		require.NoError(t, r.CreateProject(ctx, &other, ann.ID))
+
+		projects, err := r.ListProjects(ctx, dto.ProjectFilter{MemberID: bob.ID})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		require.Equal(t, "viewer", *projects[0].Role)
+
+		_, err = r.GetProject(ctx, other.ID, bob.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("todos are visible to members only", func(t *testing.T) {
+		other := dto.Project{Name: "ann only"}
+		require.NoError(t, r.CreateProject(ctx, &other, ann.ID))
+
+		date := pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+		mustCreateTodos(t, []dto.TodoItem{
+			{Title: "shared", Date: date, Status: "pending", ProjectID: &p.ID},
+			{Title: "hidden", Date: date, Status: "pending", ProjectID: &other.ID},
+			{Title: "unassigned", Date: date, Status: "pending"},
+		})
+
+		_, total, err := repo.ListTodos(ctx, dto.TodoFilter{VisibleTo: bob.ID})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), total)
+
+		_, total, err = repo.ListTodos(ctx, dto.TodoFilter{})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+		mustTruncate(t)
+	})
+
+	t.Run("counts and archiving", func(t *testing.T) {
+		date := pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+		mustCreateTodos(t, []dto.TodoItem{
+			{Title: "one", Date: date, Status: "pending", ProjectID: &p.ID},
+			{Title: "two", Date: date, Status: "completed", ProjectID: &p.ID},
+			{Title: "three", Date: date, Status: "completed", ProjectID: &p.ID},
+		})
+
+		res, err := r.GetProject(ctx, p.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.PendingTodos)
+		require.Equal(t, int64(2), res.CompletedTodos)
+
+		res.Archived = true
+		require.NoError(t, r.UpdateProject(ctx, &res, []string{"archived"}))
+
+		_, total, err := repo.ListTodos(ctx, dto.TodoFilter{})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), total)
+
+		_, total, err = repo.ListTodos(ctx, dto.TodoFilter{ProjectID: &p.ID})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+
+		projects, err := r.ListProjects(ctx, dto.ProjectFilter{MemberID: bob.ID})
+		require.NoError(t, err)
+		require.Empty(t, projects)
+
+		projects, err = r.ListProjects(ctx, dto.ProjectFilter{MemberID: bob.ID, IncludeArchived: true})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		mustTruncate(t)
+	})
+
+	t.Run("unknown owner", func(t *testing.T) {
+		require.ErrorIs(t, r.CreateProject(ctx, &dto.Project{Name: "x"}, 1<<30), sql.ErrNoRows)
+	})
+}
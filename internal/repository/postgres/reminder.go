@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+// reminderDueAt is the moment a reminder fires, offsets follow changes of the todo due
+// time. All-day todos are due at the start of their date in the time zone of the todo.
+const reminderDueAt = `COALESCE(reminders.remind_at,
+	COALESCE(todos.due_at, todos.date::timestamp AT TIME ZONE todos.time_zone) + make_interval(secs => reminders.offset_seconds))`
+
+// SchedulerOptions controls sending of due reminders.
+type SchedulerOptions struct {
+	Interval  time.Duration
+	BatchSize uint64
+	// MaxAttempts to send a reminder before it is marked failed.
+	MaxAttempts int
+	// Backoff before the first retry, doubled on every next one.
+	Backoff time.Duration
+	// Lease keeps claimed reminders from other replicas, it must cover sending a batch.
+	Lease time.Duration
+}
+
+type ReminderRepository struct {
+	DB *sqlx.DB
+}
+
+func NewReminderRepository(db *sqlx.DB) *ReminderRepository {
+	return &ReminderRepository{
+		DB: db,
+	}
+}
+
+func (s *ReminderRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateReminder stores a reminder, sql.ErrNoRows is returned when the todo does not exist.
+func (s *ReminderRepository) CreateReminder(ctx context.Context, reminder *dto.Reminder) (err error) {
+	query, args, err := s.Builder().Insert("reminders").SetMap(map[string]interface{}{
+		"todo_id":        reminder.TodoID,
+		"user_id":        reminder.UserID,
+		"remind_at":      reminder.RemindAt,
+		"offset_seconds": reminder.OffsetSeconds,
+	}).Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "ReminderRepository.CreateReminder", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(reminder)
+	return missingReference(err)
+}
+
+func (s *ReminderRepository) selectReminders() sq.SelectBuilder {
+	return s.Builder().Select("reminders.*", reminderDueAt+" AS due_at").
+		From("reminders").
+		Join("todos ON todos.id = reminders.todo_id")
+}
+
+// ListReminders returns reminders of the todo, userID limits them to the ones of the user
+// unless it is 0.
+func (s *ReminderRepository) ListReminders(ctx context.Context, todoID, userID int64) (_ []dto.Reminder, err error) {
+	q := s.selectReminders().Where(sq.Eq{"reminders.todo_id": todoID}).OrderBy("due_at", "reminders.id")
+	if userID != 0 {
+		q = q.Where(sq.Eq{"reminders.user_id": userID})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "ReminderRepository.ListReminders", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.Reminder, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteReminder removes a reminder of the todo, userID limits it to the reminders of the
+// user unless it is 0. sql.ErrNoRows is returned when nothing was deleted.
+func (s *ReminderRepository) DeleteReminder(ctx context.Context, todoID, id, userID int64) error {
+	q := s.Builder().Delete("reminders").Where(sq.Eq{"id": id, "todo_id": todoID})
+	if userID != 0 {
+		q = q.Where(sq.Eq{"user_id": userID})
+	}
+
+	return execAffected(ctx, s.DB, "ReminderRepository.DeleteReminder", q)
+}
+
+// ProcessDue claims a batch of due reminders and passes each of them to send. Claimed
+// reminders are leased to the caller until Lease passes, so other replicas skip them and a
+// reminder is sent by one replica only. Sending happens outside of any transaction and every
+// reminder is marked on its own: a crash in the middle resends only the reminders which were
+// not marked yet, once their lease is over. A failed reminder is retried after a backoff
+// doubled per attempt, until MaxAttempts is reached.
+func (s *ReminderRepository) ProcessDue(ctx context.Context, now time.Time, opts SchedulerOptions, send func(context.Context, dto.DueReminder) error) (int, error) {
+	due, err := s.claimDue(ctx, now, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, r := range due {
+		if ctx.Err() != nil {
+			// the rest is sent again once the lease is over
+			return i, ctx.Err()
+		}
+
+		q := s.Builder().Update("reminders").Where(sq.Eq{"id": r.ID})
+		if sendErr := send(ctx, r); sendErr != nil {
+			slog.WarnContext(ctx, "send reminder", slog.Int64("reminder_id", r.ID), slog.Int("attempt", r.Attempts+1), slog.Any("error", sendErr))
+			q = q.Set("attempts", r.Attempts+1).Set("last_error", sendErr.Error())
+			if r.Attempts+1 >= opts.MaxAttempts {
+				q = q.Set("failed_at", now)
+			} else {
+				q = q.Set("next_attempt_at", now.Add(opts.Backoff<<r.Attempts))
+			}
+		} else {
+			q = q.Set("sent_at", now).Set("last_error", nil)
+		}
+
+		// the reminder may be deleted meanwhile, other ones are marked anyway
+		if err = execAffected(context.WithoutCancel(ctx), s.DB, "ReminderRepository.MarkReminder", q); err != nil {
+			slog.ErrorContext(ctx, "mark reminder", slog.Int64("reminder_id", r.ID), slog.Any("error", err))
+		}
+	}
+
+	return len(due), nil
+}
+
+// claimDue selects a batch of due reminders and leases them until now plus opts.Lease in
+// one short transaction. Rows locked by other replicas are skipped.
+func (s *ReminderRepository) claimDue(ctx context.Context, now time.Time, opts SchedulerOptions) (_ []dto.DueReminder, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	b := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(tx)
+
+	query, args, err := b.Select(
+		"reminders.id",
+		"reminders.todo_id",
+		"reminders.attempts",
+		"todos.title",
+		"COALESCE(todos.description, '') AS description",
+		reminderDueAt+" AS due_at",
+		"users.email",
+	).From("reminders").
+		Join("todos ON todos.id = reminders.todo_id").
+		LeftJoin("users ON users.id = reminders.user_id").
+		Where("reminders.sent_at IS NULL AND reminders.failed_at IS NULL").
+		Where(sq.Or{sq.Eq{"reminders.next_attempt_at": nil}, sq.LtOrEq{"reminders.next_attempt_at": now}}).
+		Where(sq.LtOrEq{reminderDueAt: now}).
+		OrderBy("due_at", "reminders.id").
+		Limit(opts.BatchSize).
+		Suffix("FOR UPDATE OF reminders SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]dto.DueReminder, 0)
+	selectCtx, done := instrument(ctx, "ReminderRepository.SelectDue", query)
+	err = tx.SelectContext(selectCtx, &due, query, args...)
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return due, nil
+	}
+
+	ids := make([]int64, len(due))
+	for i, r := range due {
+		ids[i] = r.ID
+	}
+	err = execAffected(ctx, tx, "ReminderRepository.ClaimDue",
+		b.Update("reminders").Set("next_attempt_at", now.Add(opts.Lease)).Where(sq.Eq{"id": ids}))
+	if err != nil {
+		return nil, fmt.Errorf("claim reminders: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// RunScheduler sends due reminders every interval until ctx is done. A full batch is
+// followed by the next one right away.
+func (s *ReminderRepository) RunScheduler(ctx context.Context, opts SchedulerOptions, send func(context.Context, dto.DueReminder) error) {
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for {
+				processed, err := s.ProcessDue(ctx, now, opts, send)
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.ErrorContext(ctx, "process due reminders", slog.Any("error", err))
+					}
+					break
+				}
+				if processed > 0 {
+					slog.InfoContext(ctx, "due reminders processed", slog.Int("count", processed))
+				}
+				if uint64(processed) < opts.BatchSize {
+					break
+				}
+				now = time.Now()
+			}
+		}
+	}
+}
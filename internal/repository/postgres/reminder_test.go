@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/util/pointer"
+)
+
+func TestReminderRepository(t *testing.T) {
+	r := NewReminderRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM users;")
+	require.NoError(t, err)
+	mustTruncate(t)
+
+	ann := mustCreateUser(t, "ann@example.com")
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todo := dto.TodoItem{Title: "call", Date: &date}
+	mustCreateTodo(t, &todo)
+
+	past := time.Now().Add(-time.Minute)
+	absolute := dto.Reminder{TodoID: todo.ID, UserID: &ann.ID, RemindAt: &past}
+	require.NoError(t, r.CreateReminder(ctx, &absolute))
+	// fires a day after the todo date, not due yet
+	relative := dto.Reminder{TodoID: todo.ID, OffsetSeconds: pointer.Pointer(int64(48 * 3600))}
+	require.NoError(t, r.CreateReminder(ctx, &relative))
+
+	t.Run("unknown todo", func(t *testing.T) {
+		require.ErrorIs(t, r.CreateReminder(ctx, &dto.Reminder{TodoID: -1, RemindAt: &past}), sql.ErrNoRows)
+	})
+
+	t.Run("list computes due time", func(t *testing.T) {
+		res, err := r.ListReminders(ctx, todo.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.WithinDuration(t, date.Add(48*time.Hour), *res[1].DueAt, time.Second)
+
+		res, err = r.ListReminders(ctx, todo.ID, ann.ID)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+	})
+
+	opts := SchedulerOptions{BatchSize: 10, MaxAttempts: 2, Backoff: time.Minute, Lease: time.Hour}
+
+	t.Run("failed send is retried later", func(t *testing.T) {
+		processed, err := r.ProcessDue(ctx, time.Now(), opts, func(ctx context.Context, due dto.DueReminder) error {
+			require.Equal(t, absolute.ID, due.ID)
+			require.Equal(t, ann.Email, *due.Email)
+			return errors.New("down")
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+
+		processed, err = r.ProcessDue(ctx, time.Now(), opts, func(context.Context, dto.DueReminder) error {
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, processed)
+	})
+
+	t.Run("sent once", func(t *testing.T) {
+		processed, err := r.ProcessDue(ctx, time.Now().Add(2*time.Minute), opts, func(context.Context, dto.DueReminder) error {
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+
+		processed, err = r.ProcessDue(ctx, time.Now().Add(3*time.Minute), opts, func(context.Context, dto.DueReminder) error {
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, processed)
+	})
+
+	t.Run("claimed reminders are skipped by other replicas", func(t *testing.T) {
+		soon := time.Now().Add(-time.Second)
+		reminder := dto.Reminder{TodoID: todo.ID, RemindAt: &soon}
+		require.NoError(t, r.CreateReminder(ctx, &reminder))
+
+		processed, err := r.ProcessDue(ctx, time.Now().Add(4*time.Minute), opts, func(ctx context.Context, due dto.DueReminder) error {
+			require.Equal(t, reminder.ID, due.ID)
+			other, err := r.ProcessDue(ctx, time.Now().Add(4*time.Minute), opts, func(context.Context, dto.DueReminder) error {
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, 0, other)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	})
+}
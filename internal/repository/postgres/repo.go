@@ -8,12 +8,15 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 	"todo-list/internal/config"
 	"todo-list/internal/domain/dto"
 	"todo-list/internal/domain/model"
+	"todo-list/internal/logger"
+	"todo-list/internal/util/rank"
+	"todo-list/internal/util/search"
 )
 
 type TodoRepository struct {
@@ -22,21 +25,24 @@ type TodoRepository struct {
 
 const (
 	DefaultLimit = 100
+	// MaxLimit is a safety net, the HTTP layer enforces a configurable lower page size
+	MaxLimit      = 1000
+	MigrationsDir = "migrations"
 )
 
 func NewPostgresTodoRepository() *TodoRepository {
 	connect, err := sqlx.Connect(config.Config.DBConfig.Driver, config.Config.DBConfig.ConnectionString())
 	if err != nil {
-		log.Fatalf("database connection error: %v", err)
+		logger.Fatal("database connection error", slog.Any("error", err))
 	}
 
 	if config.Config.AppLevel != "test" {
 		if err := goose.SetDialect(config.Config.DBConfig.Driver); err != nil {
-			log.Fatalf("goose set dialect error: %v", err)
+			logger.Fatal("goose set dialect error", slog.Any("error", err))
 		}
 
-		if err := goose.Up(connect.DB, "migrations"); err != nil {
-			log.Fatalf("goose up :%s", err)
+		if err := goose.Up(connect.DB, MigrationsDir); err != nil {
+			logger.Fatal("goose up error", slog.Any("error", err))
 		}
 	}
 
@@ -45,37 +51,95 @@ func NewPostgresTodoRepository() *TodoRepository {
 	}
 }
 
+func (s *TodoRepository) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+// CheckMigrations reports an error when the database schema version differs from
+// the latest migration shipped with the binary.
+func (s *TodoRepository) CheckMigrations(ctx context.Context) error {
+	migrations, err := goose.CollectMigrations(MigrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return err
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return err
+	}
+
+	current, err := goose.GetDBVersionContext(ctx, s.DB.DB)
+	if err != nil {
+		return err
+	}
+	if current != last.Version {
+		return fmt.Errorf("database version %d, expected %d", current, last.Version)
+	}
+
+	return nil
+}
+
 func (s *TodoRepository) Builder() sq.StatementBuilderType {
 	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
 }
 
-func (s *TodoRepository) CreateTodo(ctx context.Context, item *dto.TodoItem) error {
-	q := s.Builder().Insert("todos").SetMap(map[string]interface{}{
-		model.TodoTitleField:       item.Title,
-		model.TodoDescriptionField: item.Description,
-		model.TodoDateField:        item.Date,
-		model.TodoStatusField:      item.Status,
-	}).Suffix("RETURNING id, created_at")
+// CreateTodo stores a new todo at the end of the manual order, sql.ErrNoRows is returned
+// for an unknown project. Todos created concurrently may get equal positions, such ties
+// are ordered by id and resolved by the next rebalancing.
+func (s *TodoRepository) CreateTodo(ctx context.Context, item *dto.TodoItem) (err error) {
+	last, err := queryPosition(ctx, s.DB, "TodoRepository.LastPosition",
+		s.Builder().Select("position").From("todos").OrderBy("position DESC", "id DESC").Limit(1))
+	if err != nil {
+		return err
+	}
+	if item.Position, err = rank.Between(last, ""); err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{
+		model.TodoTitleField:        item.Title,
+		model.TodoDescriptionField:  item.Description,
+		model.TodoDateField:         item.Date,
+		model.TodoDueAtField:        item.DueAt,
+		model.TodoStatusField:       item.Status,
+		model.TodoProjectIDField:    item.ProjectID,
+		model.TodoCompletedAtField:  item.CompletedAt,
+		model.TodoAutoCompleteField: item.AutoComplete,
+		model.TodoEstimateField:     item.EstimateMinutes,
+		model.TodoRecurrenceField:   item.Recurrence,
+		"position":                  item.Position,
+	}
+	// the column defaults to UTC
+	if item.TimeZone != "" {
+		values[model.TodoTimeZoneField] = item.TimeZone
+	}
+	q := s.Builder().Insert("todos").SetMap(values).Suffix("RETURNING id, time_zone, created_at")
 
 	query, args, err := q.ToSql()
 	if err != nil {
 		return err
 	}
 
+	ctx, done := instrument(ctx, "TodoRepository.CreateTodo", query)
+	defer func() { done(err) }()
+
 	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(item); err != nil {
+		err = missingReference(err)
 		return err
 	}
 
 	return nil
 }
 
-func (s *TodoRepository) GetTodoByID(ctx context.Context, id int64) (dto.TodoItem, error) {
-	q := s.Builder().Select("*").From("todos").Where(sq.Eq{"id": id})
+func (s *TodoRepository) GetTodoByID(ctx context.Context, id int64) (_ dto.TodoItem, err error) {
+	q := s.Builder().Select("*", todoBlocked, todoComments, todoTracked).From("todos").Where(sq.Eq{"id": id})
 	query, args, err := q.ToSql()
 	if err != nil {
 		return dto.TodoItem{}, err
 	}
 
+	ctx, done := instrument(ctx, "TodoRepository.GetTodoByID", query)
+	defer func() { done(err) }()
+
 	var res dto.TodoItem
 	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
 		return res, err
@@ -85,16 +149,25 @@ func (s *TodoRepository) GetTodoByID(ctx context.Context, id int64) (dto.TodoIte
 }
 
 var col = map[string]func(item *dto.TodoItem) interface{}{
-	model.TodoTitleField:       func(item *dto.TodoItem) interface{} { return item.Title },
-	model.TodoDescriptionField: func(item *dto.TodoItem) interface{} { return item.Description },
-	model.TodoDateField:        func(item *dto.TodoItem) interface{} { return item.Date },
-	model.TodoStatusField:      func(item *dto.TodoItem) interface{} { return item.Status },
+	model.TodoTitleField:        func(item *dto.TodoItem) interface{} { return item.Title },
+	model.TodoDescriptionField:  func(item *dto.TodoItem) interface{} { return item.Description },
+	model.TodoDateField:         func(item *dto.TodoItem) interface{} { return item.Date },
+	model.TodoStatusField:       func(item *dto.TodoItem) interface{} { return item.Status },
+	model.TodoProjectIDField:    func(item *dto.TodoItem) interface{} { return item.ProjectID },
+	model.TodoDueAtField:        func(item *dto.TodoItem) interface{} { return item.DueAt },
+	model.TodoTimeZoneField:     func(item *dto.TodoItem) interface{} { return item.TimeZone },
+	model.TodoCompletedAtField:  func(item *dto.TodoItem) interface{} { return item.CompletedAt },
+	model.TodoAutoCompleteField: func(item *dto.TodoItem) interface{} { return item.AutoComplete },
+	model.TodoEstimateField:     func(item *dto.TodoItem) interface{} { return item.EstimateMinutes },
+	model.TodoRecurrenceField:   func(item *dto.TodoItem) interface{} { return item.Recurrence },
 }
 
-func (s *TodoRepository) UpdateTodo(ctx context.Context, item *dto.TodoItem, updatedFields []string) error {
+// UpdateTodo updates the fields of the todo, sql.ErrNoRows is returned for an unknown
+// todo or project.
+func (s *TodoRepository) UpdateTodo(ctx context.Context, item *dto.TodoItem, updatedFields []string) (err error) {
 	query := s.Builder().Update("todos").
 		Set("updated_at", time.Now()).
-		Where(sq.Eq{"id": item.ID}).Suffix("RETURNING id, title, description, date, status, created_at, updated_at")
+		Where(sq.Eq{"id": item.ID}).Suffix("RETURNING id, title, description, date, due_at, time_zone, status, project_id, completed_at, auto_complete, estimate_minutes, recurrence, position, created_at, updated_at, " + todoBlocked + ", " + todoComments + ", " + todoTracked)
 
 	for _, fieldToUpdate := range updatedFields {
 		getter, ok := col[fieldToUpdate]
@@ -109,17 +182,29 @@ func (s *TodoRepository) UpdateTodo(ctx context.Context, item *dto.TodoItem, upd
 		return err
 	}
 
+	ctx, done := instrument(ctx, "TodoRepository.UpdateTodo", q)
+	defer func() { done(err) }()
+
 	err = s.DB.QueryRowxContext(ctx, q, args...).StructScan(item)
 	if err != nil {
+		err = missingReference(err)
 		return err
 	}
 
 	return nil
 }
 
-func (s *TodoRepository) DeleteTodo(ctx context.Context, id int64) error {
+func (s *TodoRepository) DeleteTodo(ctx context.Context, id int64) (err error) {
 	q := s.Builder().Delete("todos").Where(sq.Eq{"id": id})
-	res, err := q.ExecContext(ctx)
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.DeleteTodo", query)
+	defer func() { done(err) }()
+
+	res, err := s.DB.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -136,44 +221,137 @@ func (s *TodoRepository) DeleteTodo(ctx context.Context, id int64) error {
 	return nil
 }
 
-func applyTodoFilter(s sq.SelectBuilder, f dto.TodoFilter) sq.SelectBuilder {
+// todoLocalDay is the day a todo falls on in the time zone of the caller: the day of the
+// due time or the date of all-day todos.
+const todoLocalDay = "COALESCE((due_at AT TIME ZONE ?)::date, date)"
+
+// filterTodos selects the todos matching the filter, pages are left to the caller.
+// An invalid search expression is returned as *search.Error.
+func filterTodos(s sq.SelectBuilder, f dto.TodoFilter) (sq.SelectBuilder, error) {
+	tz := f.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+
 	if f.Date != nil {
-		s = s.Where(sq.Eq{model.TodoDateField: f.Date})
+		s = s.Where(todoLocalDay+" = ?::date", tz, f.Date.Format(time.DateOnly))
+	}
+
+	if f.Today {
+		s = s.Where(todoLocalDay+" = (NOW() AT TIME ZONE ?)::date", tz, tz)
+	}
+
+	if f.Overdue {
+		s = s.Where(sq.NotEq{model.TodoStatusField: []string{model.TodoStatusCompleted, model.TodoStatusCancelled}}).
+			Where("COALESCE(due_at < NOW(), date < (NOW() AT TIME ZONE ?)::date)", tz)
+	}
+
+	if f.Open {
+		s = s.Where(sq.NotEq{model.TodoStatusField: []string{model.TodoStatusCompleted, model.TodoStatusCancelled}})
+	}
+
+	if f.DueWithinDays > 0 {
+		s = s.Where(todoLocalDay+" BETWEEN (NOW() AT TIME ZONE ?)::date AND (NOW() AT TIME ZONE ?)::date + ?::int",
+			tz, tz, tz, f.DueWithinDays-1)
+	}
+
+	if f.CompletedWithinDays > 0 {
+		s = s.Where("completed_at >= NOW() - ?::int * interval '1 day'", f.CompletedWithinDays)
 	}
 
 	if f.Status != "" {
 		s = s.Where(sq.Eq{model.TodoStatusField: f.Status})
 	}
 
+	if f.ProjectID != nil {
+		s = s.Where(sq.Eq{model.TodoProjectIDField: *f.ProjectID})
+	} else if !f.IncludeArchived {
+		s = s.Where(sq.Or{
+			sq.Eq{model.TodoProjectIDField: nil},
+			sq.Expr(model.TodoProjectIDField + " NOT IN (SELECT id FROM projects WHERE archived)"),
+		})
+	}
+
+	if f.VisibleTo != 0 {
+		s = s.Where(sq.Or{
+			sq.Eq{model.TodoProjectIDField: nil},
+			sq.Expr(model.TodoProjectIDField+" IN (SELECT project_id FROM project_members WHERE user_id = ?)", f.VisibleTo),
+		})
+	}
+
+	e, err := search.Parse(f.Query)
+	if err != nil {
+		return s, err
+	}
+	if e != nil {
+		cond, err := compileSearch(e, tz)
+		if err != nil {
+			return s, err
+		}
+		s = s.Where(cond)
+	}
+
+	return s, nil
+}
+
+func applyTodoFilter(s sq.SelectBuilder, f dto.TodoFilter) (sq.SelectBuilder, error) {
+	s, err := filterTodos(s, f)
+	if err != nil {
+		return s, err
+	}
+
 	if f.Page <= 0 {
 		f.Page = 1
 	}
-	if f.Limit <= 0 || f.Limit > 10_000 {
+	if f.Limit <= 0 {
 		f.Limit = DefaultLimit
 	}
+	if f.Limit > MaxLimit {
+		f.Limit = MaxLimit
+	}
 
 	s = s.Limit(uint64(f.Limit)).Offset(uint64((f.Page - 1) * f.Limit))
-	return s
+	return s, nil
 }
 
-func (s *TodoRepository) ListTodos(ctx context.Context, filter dto.TodoFilter) ([]dto.TodoItem, int64, error) {
+func (s *TodoRepository) ListTodos(ctx context.Context, filter dto.TodoFilter) (_ []dto.TodoItem, _ int64, err error) {
 	q := s.Builder().Select(
-		"id", strings.Join(model.TodoFields, ", "), "created_at", "updated_at",
+		"id", strings.Join(model.TodoFields, ", "), "position", "created_at", "updated_at", todoBlocked, todoComments, todoTracked,
 		"COUNT(*) OVER() as total_items").
-		From("todos").
-		OrderBy("id")
+		From("todos")
+
+	switch filter.Sort {
+	case dto.TodoSortManual:
+		q = q.OrderBy("position", "id")
+	case dto.TodoSortDate:
+		tz := filter.TimeZone
+		if tz == "" {
+			tz = "UTC"
+		}
+		// all-day todos come before the timed ones of the same day
+		q = q.OrderByClause(todoLocalDay+", due_at NULLS FIRST, id", tz)
+	default:
+		q = q.OrderBy("id")
+	}
 
-	q = applyTodoFilter(q, filter)
+	q, err = applyTodoFilter(q, filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	query, args, err := q.ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
 
+	ctx, done := instrument(ctx, "TodoRepository.ListTodos", query)
+	defer func() { done(err) }()
+
 	rows, err := s.DB.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
+	defer rows.Close()
 
 	todos := make([]dto.TodoItem, 0)
 
@@ -193,3 +371,42 @@ func (s *TodoRepository) ListTodos(ctx context.Context, filter dto.TodoFilter) (
 
 	return todos, totalItems, nil
 }
+
+func (s *TodoRepository) CountTodosByStatus(ctx context.Context) (_ map[string]int64, err error) {
+	q := s.Builder().Select(model.TodoStatusField, "COUNT(*)").
+		From("todos").
+		GroupBy(model.TodoStatusField)
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.CountTodosByStatus", query)
+	defer func() { done(err) }()
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int64)
+	for rows.Next() {
+		var (
+			status string
+			count  int64
+		)
+		if err = rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		res[status] = count
+	}
+
+	return res, rows.Err()
+}
+
+// GetMemberRole returns the role of the user in the project, sql.ErrNoRows is returned for non-members.
+func (s *TodoRepository) GetMemberRole(ctx context.Context, projectID, userID int64) (string, error) {
+	return getMemberRole(ctx, s.DB, s.Builder(), "TodoRepository.GetMemberRole", projectID, userID)
+}
@@ -11,6 +11,7 @@ import (
 	"todo-list/internal/domain/dto"
 	"todo-list/internal/domain/model"
 	"todo-list/internal/util/pointer"
+	"todo-list/internal/util/search"
 )
 
 func mustTruncate(t *testing.T) {
@@ -260,6 +261,57 @@ func TestTodoRepository_ListTodos(t *testing.T) {
 	mustTruncate(t)
 }
 
+func TestTodoRepository_ListTodos_TimeZone(t *testing.T) {
+	mustTruncate(t)
+
+	now := time.Now().UTC()
+	late := dto.TodoItem{
+		Title:  "late evening",
+		Date:   pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)),
+		DueAt:  pointer.Pointer(time.Date(2023, 12, 1, 22, 0, 0, 0, time.UTC)),
+		Status: "pending",
+	}
+	today := dto.TodoItem{
+		Title:  "today",
+		Date:   pointer.Pointer(now.Truncate(24 * time.Hour)),
+		Status: "pending",
+	}
+	mustCreateTodos(t, []dto.TodoItem{late, today})
+
+	count := func(filter dto.TodoFilter) int64 {
+		_, total, err := repo.ListTodos(context.Background(), filter)
+		require.NoError(t, err)
+		return total
+	}
+
+	day := pointer.Pointer(time.Date(2023, 12, 2, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, int64(0), count(dto.TodoFilter{Date: day}))
+	require.Equal(t, int64(1), count(dto.TodoFilter{Date: day, TimeZone: "Europe/Moscow"}))
+
+	require.Equal(t, int64(1), count(dto.TodoFilter{Overdue: true}))
+	require.Equal(t, int64(1), count(dto.TodoFilter{Today: true}))
+	require.Equal(t, int64(1), count(dto.TodoFilter{DueWithinDays: 7}))
+	require.Equal(t, int64(2), count(dto.TodoFilter{Open: true}))
+	require.Equal(t, int64(0), count(dto.TodoFilter{CompletedWithinDays: 7}))
+
+	require.Equal(t, int64(1), count(dto.TodoFilter{Query: "due<2023-12-02"}))
+	require.Equal(t, int64(0), count(dto.TodoFilter{Query: "due<2023-12-02", TimeZone: "Europe/Moscow"}))
+	require.Equal(t, int64(2), count(dto.TodoFilter{Query: "status:pending (is:today or EVENING)"}))
+	require.Equal(t, int64(1), count(dto.TodoFilter{Query: "not is:overdue and project:none"}))
+	require.Equal(t, int64(0), count(dto.TodoFilter{Query: `"'; DROP TABLE todos; --"`}))
+
+	_, _, err := repo.ListTodos(context.Background(), dto.TodoFilter{Query: "tag:work"})
+	var serr *search.Error
+	require.ErrorAs(t, err, &serr)
+	require.Equal(t, 1, serr.Pos)
+
+	list, _, err := repo.ListTodos(context.Background(), dto.TodoFilter{Sort: dto.TodoSortDate})
+	require.NoError(t, err)
+	require.Equal(t, "late evening", list[0].Title)
+
+	mustTruncate(t)
+}
+
 func TestTodoRepository_UpdateTodo(t *testing.T) {
 	mustTruncate(t)
 
@@ -351,3 +403,31 @@ func TestTodoRepository_UpdateTodo(t *testing.T) {
 
 	mustTruncate(t)
 }
+
+func TestTodoRepository_CountTodosByStatus(t *testing.T) {
+	mustTruncate(t)
+
+	mustCreateTodos(t, []dto.TodoItem{
+		{
+			Title:  "title 1",
+			Date:   pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.FixedZone("", 0))),
+			Status: "completed",
+		},
+		{
+			Title:  "title 2",
+			Date:   pointer.Pointer(time.Date(2023, 12, 1, 0, 0, 0, 0, time.FixedZone("", 0))),
+			Status: "pending",
+		},
+		{
+			Title:  "title 3",
+			Date:   pointer.Pointer(time.Date(2023, 12, 2, 0, 0, 0, 0, time.FixedZone("", 0))),
+			Status: "pending",
+		},
+	})
+
+	res, err := repo.CountTodosByStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"completed": 1, "pending": 2}, res)
+
+	mustTruncate(t)
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+type SavedFilterRepository struct {
+	DB *sqlx.DB
+}
+
+func NewSavedFilterRepository(db *sqlx.DB) *SavedFilterRepository {
+	return &SavedFilterRepository{
+		DB: db,
+	}
+}
+
+func (s *SavedFilterRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateFilter stores a filter of the user, errs.ErrConflict is returned when the user
+// has a filter with the name.
+func (s *SavedFilterRepository) CreateFilter(ctx context.Context, filter *dto.SavedFilter) (err error) {
+	query, args, err := s.Builder().Insert("saved_filters").SetMap(map[string]interface{}{
+		"user_id": filter.UserID,
+		"name":    filter.Name,
+		"filter":  filter.Filter,
+	}).Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "SavedFilterRepository.CreateFilter", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(filter)
+	return duplicateKey(err)
+}
+
+func (s *SavedFilterRepository) GetFilter(ctx context.Context, userID, id int64) (_ dto.SavedFilter, err error) {
+	query, args, err := s.Builder().Select("*").
+		From("saved_filters").
+		Where(sq.Eq{"id": id, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return dto.SavedFilter{}, err
+	}
+
+	ctx, done := instrument(ctx, "SavedFilterRepository.GetFilter", query)
+	defer func() { done(err) }()
+
+	var res dto.SavedFilter
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.SavedFilter{}, err
+	}
+	return res, nil
+}
+
+// ListFilters returns the filters of the user by name.
+func (s *SavedFilterRepository) ListFilters(ctx context.Context, userID int64) (_ []dto.SavedFilter, err error) {
+	query, args, err := s.Builder().Select("*").
+		From("saved_filters").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("name", "id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "SavedFilterRepository.ListFilters", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.SavedFilter, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateFilter replaces the name and the query of the filter, sql.ErrNoRows is returned for
+// an unknown filter and errs.ErrConflict when the user has another filter with the name.
+func (s *SavedFilterRepository) UpdateFilter(ctx context.Context, filter *dto.SavedFilter) (err error) {
+	query, args, err := s.Builder().Update("saved_filters").
+		Set("name", filter.Name).
+		Set("filter", filter.Filter).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": filter.ID, "user_id": filter.UserID}).
+		Suffix("RETURNING *").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "SavedFilterRepository.UpdateFilter", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(filter)
+	return duplicateKey(err)
+}
+
+func (s *SavedFilterRepository) DeleteFilter(ctx context.Context, userID, id int64) error {
+	return execAffected(ctx, s.DB, "SavedFilterRepository.DeleteFilter",
+		s.Builder().Delete("saved_filters").Where(sq.Eq{"id": id, "user_id": userID}))
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+)
+
+func TestSavedFilterRepository(t *testing.T) {
+	r := NewSavedFilterRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM saved_filters; DELETE FROM users;")
+	require.NoError(t, err)
+
+	ann := mustCreateUser(t, "ann@example.com")
+	bob := mustCreateUser(t, "bob@example.com")
+
+	work := dto.SavedFilter{UserID: ann.ID, Name: "work", Filter: []byte(`{"open":true}`)}
+	require.NoError(t, r.CreateFilter(ctx, &work))
+	require.NotZero(t, work.ID)
+	require.ErrorIs(t, r.CreateFilter(ctx, &dto.SavedFilter{UserID: ann.ID, Name: "work", Filter: []byte(`{}`)}), errs.ErrConflict)
+	require.NoError(t, r.CreateFilter(ctx, &dto.SavedFilter{UserID: bob.ID, Name: "work", Filter: []byte(`{}`)}))
+
+	home := dto.SavedFilter{UserID: ann.ID, Name: "home", Filter: []byte(`{}`)}
+	require.NoError(t, r.CreateFilter(ctx, &home))
+
+	list, err := r.ListFilters(ctx, ann.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "home", list[0].Name)
+
+	_, err = r.GetFilter(ctx, bob.ID, work.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	work.Name = "home"
+	require.ErrorIs(t, r.UpdateFilter(ctx, &work), errs.ErrConflict)
+
+	work.Name = "office"
+	work.Filter = []byte(`{"today": true}`)
+	require.NoError(t, r.UpdateFilter(ctx, &work))
+	require.NotNil(t, work.UpdatedAt)
+
+	got, err := r.GetFilter(ctx, ann.ID, work.ID)
+	require.NoError(t, err)
+	require.Equal(t, "office", got.Name)
+	require.JSONEq(t, `{"today": true}`, string(got.Filter))
+
+	require.ErrorIs(t, r.DeleteFilter(ctx, bob.ID, work.ID), sql.ErrNoRows)
+	require.NoError(t, r.DeleteFilter(ctx, ann.ID, work.ID))
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/search"
+)
+
+// searchFields are the fields of search expressions, in the order they are listed in errors.
+var searchFields = []string{"status", "project", "title", "due", "created", "completed", "is"}
+
+// searchFlags are the values of the "is" field.
+var searchFlags = map[string]string{
+	"open":      "status NOT IN ('completed', 'cancelled')",
+	"closed":    "status IN ('completed', 'cancelled')",
+	"overdue":   "status NOT IN ('completed', 'cancelled') AND COALESCE(due_at < NOW(), date < (NOW() AT TIME ZONE ?)::date)",
+	"today":     todoLocalDay + " = (NOW() AT TIME ZONE ?)::date",
+	"recurring": "recurrence <> ''",
+}
+
+// searchCompare maps the operators of terms to SQL, ":" compares for equality like "=".
+var searchCompare = map[string]string{
+	search.OpHas: "=",
+	search.OpEq:  "=",
+	search.OpNe:  "<>",
+	search.OpLt:  "<",
+	search.OpLe:  "<=",
+	search.OpGt:  ">",
+	search.OpGe:  ">=",
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// compileSearch translates the parsed search expression to a condition on todos with
+// days in the time zone tz. Only fixed SQL is produced, values of the expression are
+// always passed as arguments. Unknown fields and invalid values are reported as
+// *search.Error at their position.
+func compileSearch(e search.Expr, tz string) (sq.Sqlizer, error) {
+	switch e := e.(type) {
+	case *search.Binary:
+		left, err := compileSearch(e.Left, tz)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileSearch(e.Right, tz)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == search.Or {
+			return sq.Or{left, right}, nil
+		}
+		return sq.And{left, right}, nil
+	case *search.Not:
+		x, err := compileSearch(e.X, tz)
+		if err != nil {
+			return nil, err
+		}
+		// NULL of a missing date or project counts as false, so "not" selects such todos
+		return sq.Expr("NOT COALESCE((?), false)", x), nil
+	case *search.Term:
+		return compileTerm(e, tz)
+	}
+	return nil, fmt.Errorf("unknown search expression %T", e)
+}
+
+func compileTerm(t *search.Term, tz string) (sq.Sqlizer, error) {
+	switch t.Field {
+	case "":
+		pattern := "%" + likeEscaper.Replace(t.Value) + "%"
+		return sq.Expr("(title ILIKE ? OR COALESCE(description, '') ILIKE ?)", pattern, pattern), nil
+	case "title":
+		if err := searchOps(t, search.OpHas, search.OpEq, search.OpNe); err != nil {
+			return nil, err
+		}
+		switch t.Op {
+		case search.OpHas:
+			return sq.Expr("title ILIKE ?", "%"+likeEscaper.Replace(t.Value)+"%"), nil
+		case search.OpNe:
+			return sq.Expr("title <> ?", t.Value), nil
+		}
+		return sq.Expr("title = ?", t.Value), nil
+	case "status":
+		if err := searchOps(t, search.OpHas, search.OpEq, search.OpNe); err != nil {
+			return nil, err
+		}
+		if !model.TodoStatus(t.Value).Valid() {
+			statuses := make([]string, len(model.TodoStatuses))
+			for i, s := range model.TodoStatuses {
+				statuses[i] = string(s)
+			}
+			return nil, search.Errorf(t.ValuePos, "status must be one of %s", strings.Join(statuses, ", "))
+		}
+		return sq.Expr("status "+searchCompare[t.Op]+" ?", t.Value), nil
+	case "project":
+		if err := searchOps(t, search.OpHas, search.OpEq, search.OpNe); err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(t.Value, "none") {
+			if t.Op == search.OpNe {
+				return sq.Expr("project_id IS NOT NULL"), nil
+			}
+			return sq.Expr("project_id IS NULL"), nil
+		}
+		id, err := strconv.ParseInt(t.Value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, search.Errorf(t.ValuePos, "project must be a project id or none")
+		}
+		if t.Op == search.OpNe {
+			return sq.Expr("project_id IS DISTINCT FROM ?", id), nil
+		}
+		return sq.Expr("project_id = ?", id), nil
+	case "due":
+		return compileDay(t, todoLocalDay, tz)
+	case "created":
+		return compileDay(t, "("+todoCreatedAt+" AT TIME ZONE ?)::date", tz)
+	case "completed":
+		return compileDay(t, "(completed_at AT TIME ZONE ?)::date", tz)
+	case "is":
+		if err := searchOps(t, search.OpHas, search.OpEq); err != nil {
+			return nil, err
+		}
+		cond, ok := searchFlags[strings.ToLower(t.Value)]
+		if !ok {
+			return nil, search.Errorf(t.ValuePos, "is must be one of open, closed, overdue, today and recurring")
+		}
+		args := make([]interface{}, strings.Count(cond, "?"))
+		for i := range args {
+			args[i] = tz
+		}
+		return sq.Expr(cond, args...), nil
+	}
+	return nil, search.Errorf(t.FieldPos, "unknown field %q, expected one of %s", t.Field, strings.Join(searchFields, ", "))
+}
+
+// compileDay compares the local day of a todo selected by day, which has a placeholder
+// for the time zone, with a date, "today" or "none".
+func compileDay(t *search.Term, day, tz string) (sq.Sqlizer, error) {
+	if err := searchOps(t, search.OpHas, search.OpEq, search.OpNe, search.OpLt, search.OpLe, search.OpGt, search.OpGe); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(t.Value) {
+	case "none":
+		switch t.Op {
+		case search.OpHas, search.OpEq:
+			return sq.Expr(day+" IS NULL", tz), nil
+		case search.OpNe:
+			return sq.Expr(day+" IS NOT NULL", tz), nil
+		}
+		return nil, search.Errorf(t.ValuePos, "none can only be compared with : = and !=")
+	case "today":
+		return sq.Expr(day+" "+searchCompare[t.Op]+" (NOW() AT TIME ZONE ?)::date", tz, tz), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, t.Value)
+	if err != nil {
+		return nil, search.Errorf(t.ValuePos, "%s must be a date in YYYY-MM-DD format, today or none", t.Field)
+	}
+	return sq.Expr(day+" "+searchCompare[t.Op]+" ?::date", tz, date.Format(time.DateOnly)), nil
+}
+
+// searchOps checks that the operator of the term is one of ops.
+func searchOps(t *search.Term, ops ...string) error {
+	for _, op := range ops {
+		if t.Op == op {
+			return nil
+		}
+	}
+	return search.Errorf(t.OpPos, "%s can not be compared with %s, use one of %s", t.Field, t.Op, strings.Join(ops, " "))
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"testing"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/util/search"
+	"unicode/utf8"
+)
+
+func compileSearchSQL(s string) (string, []interface{}, error) {
+	e, err := search.Parse(s)
+	if err != nil || e == nil {
+		return "", nil, err
+	}
+	cond, err := compileSearch(e, "Europe/Moscow")
+	if err != nil {
+		return "", nil, err
+	}
+	return cond.ToSql()
+}
+
+func TestCompileSearch(t *testing.T) {
+	query, args, err := compileSearchSQL(`status:pending and (project:3 or "50%_off") and not due<2026-11-01`)
+	require.NoError(t, err)
+	require.Equal(t, "((status = ? AND (project_id = ? OR (title ILIKE ? OR COALESCE(description, '') ILIKE ?))) AND "+
+		"NOT COALESCE((COALESCE((due_at AT TIME ZONE ?)::date, date) < ?::date), false))", query)
+	require.Equal(t, []interface{}{"pending", int64(3), `%50\%\_off%`, `%50\%\_off%`, "Europe/Moscow", "2026-11-01"}, args)
+
+	query, args, err = compileSearchSQL("is:today project!=none completed>=today")
+	require.NoError(t, err)
+	require.Equal(t, "((COALESCE((due_at AT TIME ZONE ?)::date, date) = (NOW() AT TIME ZONE ?)::date AND project_id IS NOT NULL) AND "+
+		"(completed_at AT TIME ZONE ?)::date >= (NOW() AT TIME ZONE ?)::date)", query)
+	require.Len(t, args, 4)
+}
+
+func TestTodoRepository_ListTodos_SearchDays(t *testing.T) {
+	mustTruncate(t)
+
+	late := dto.TodoItem{Title: "late", Status: "completed"}
+	mustCreateTodo(t, &late)
+	// created_at is UTC without a time zone, completed_at has one
+	_, err := repo.DB.Exec("UPDATE todos SET created_at = '2026-10-19 22:30:00', completed_at = '2026-10-19 22:30:00+00' WHERE id = $1", late.ID)
+	require.NoError(t, err)
+
+	count := func(q, tz string) int64 {
+		_, total, err := repo.ListTodos(context.Background(), dto.TodoFilter{Query: q, TimeZone: tz})
+		require.NoError(t, err)
+		return total
+	}
+
+	for _, field := range []string{"created", "completed"} {
+		require.Equal(t, int64(1), count(field+":2026-10-19", "UTC"), field)
+		require.Equal(t, int64(0), count(field+":2026-10-20", "UTC"), field)
+		require.Equal(t, int64(0), count(field+":2026-10-19", "Europe/Moscow"), field)
+		require.Equal(t, int64(1), count(field+":2026-10-20", "Europe/Moscow"), field)
+		require.Equal(t, int64(1), count(field+":2026-10-19", "America/New_York"), field)
+	}
+
+	mustTruncate(t)
+}
+
+func TestCompileSearch_Errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{in: "status:pending and (tag:work or tag:urgent)", pos: 21, msg: `unknown field "tag", expected one of status, project, title, due, created, completed, is`},
+		{in: "status:later", pos: 8, msg: "status must be one of pending, in_progress, blocked, completed, cancelled"},
+		{in: "status<done", pos: 7, msg: "status can not be compared with <, use one of : = !="},
+		{in: "project:abc", pos: 9, msg: "project must be a project id or none"},
+		{in: "due<2026-13-01", pos: 5, msg: "due must be a date in YYYY-MM-DD format, today or none"},
+		{in: "due<none", pos: 5, msg: "none can only be compared with : = and !="},
+		{in: "is:someday", pos: 4, msg: "is must be one of open, closed, overdue, today and recurring"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, _, err := compileSearchSQL(tt.in)
+			var e *search.Error
+			require.True(t, errors.As(err, &e), err)
+			require.Equal(t, tt.pos, e.Pos)
+			require.Equal(t, tt.msg, e.Msg)
+		})
+	}
+}
+
+// searchToken splits SQL into quoted literals, identifiers and single characters.
+var searchToken = regexp.MustCompile(`'[^']*'|[A-Za-z_][A-Za-z_0-9]*|\S`)
+
+// FuzzCompileSearch checks that the SQL of any expression is made only of the fixed
+// fragments of the compiler and that all values are passed as arguments.
+func FuzzCompileSearch(f *testing.F) {
+	vocabulary := map[string]bool{}
+	query, _, err := compileSearchSQL(`a title:a title=a title!=a status:pending status!=completed project:1 project!=1
+		project:none project!=none due:none due!=none due<today due<=2026-01-01 due>2026-01-01 due>=2026-01-01
+		created=2026-01-01 completed:none is:open is:closed is:overdue is:today is:recurring not (a or b)`)
+	require.NoError(f, err)
+	for _, tok := range searchToken.FindAllString(query, -1) {
+		vocabulary[tok] = true
+	}
+
+	for _, s := range []string{
+		"status:pending and (tag:work or tag:urgent) and due<2026-11-01",
+		`title:"'; DROP TABLE todos; --"`,
+		`"%' OR '1'='1" or project!=none`,
+		`not (due>=today or created<2026-01-01) is:overdue`,
+		`project:1;DELETE completed:none`,
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		query, args, err := compileSearchSQL(s)
+		if err != nil {
+			var e *search.Error
+			require.True(t, errors.As(err, &e), err)
+			require.True(t, e.Pos >= 1 && e.Pos <= utf8.RuneCountInString(s)+1, "position %d of %q", e.Pos, s)
+			return
+		}
+		for _, tok := range searchToken.FindAllString(query, -1) {
+			require.True(t, vocabulary[tok], "unexpected %q in %q of %q", tok, query, s)
+		}
+		require.Equal(t, strings.Count(query, "?"), len(args))
+	})
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// todoCreatedAt is the creation time of a todo, the column stores UTC without a time zone.
+const todoCreatedAt = "(created_at AT TIME ZONE 'UTC')"
+
+// TodoStats aggregates the todos matching the filter by status in one pass, pages of the filter are ignored.
+func (s *TodoRepository) TodoStats(ctx context.Context, filter dto.TodoFilter) (_ []dto.TodoStatusStats, err error) {
+	tz := filter.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	q := s.Builder().Select(model.TodoStatusField+" AS status", "COUNT(*) AS total").
+		Column("COUNT(*) FILTER (WHERE status NOT IN ('completed', 'cancelled') AND COALESCE(due_at < NOW(), date < (NOW() AT TIME ZONE ?)::date)) AS overdue", tz).
+		Columns(
+			"COUNT(completed_at) AS completed",
+			"COALESCE(SUM(EXTRACT(EPOCH FROM completed_at - "+todoCreatedAt+")), 0)::bigint AS completion_seconds").
+		From("todos").
+		GroupBy(model.TodoStatusField).
+		OrderBy(model.TodoStatusField)
+	q, err = filterTodos(q, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.TodoStats", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TodoStatusStats, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TodoSeries counts the todos matching the filter created and completed in each day, week or month
+// between start and end, periods are truncated in the time zone of the filter. Every todo yields a
+// creation and a completion event, so the table is scanned once. Events before start are counted in
+// the row without a period, periods without events are left out.
+func (s *TodoRepository) TodoSeries(ctx context.Context, filter dto.TodoSeriesFilter, start, end time.Time) (_ []dto.TodoSeriesCount, err error) {
+	tz := filter.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	q := s.Builder().Select().
+		Column("CASE WHEN ev.at < ? THEN NULL ELSE to_char(date_trunc(?, ev.at AT TIME ZONE ?), 'YYYY-MM-DD') END AS period",
+			start, filter.Interval, tz).
+		Columns("SUM(ev.created) AS created", "SUM(ev.completed) AS completed").
+		From("todos").
+		JoinClause("CROSS JOIN LATERAL (VALUES ("+todoCreatedAt+", 1, 0), (completed_at, 0, 1)) AS ev (at, created, completed)").
+		Where("ev.at < ?", end).
+		GroupBy("1").
+		OrderBy("1 NULLS FIRST")
+	q, err = filterTodos(q, filter.TodoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TodoRepository.TodoSeries", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TodoSeriesCount, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+)
+
+func TestTodoRepository_TodoStats(t *testing.T) {
+	ctx := context.Background()
+	mustTruncate(t)
+
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	mustCreateTodo(t, &dto.TodoItem{Title: "late", Date: &yesterday, Status: "pending"})
+	mustCreateTodo(t, &dto.TodoItem{Title: "open", Status: "pending"})
+	done := dto.TodoItem{Title: "done", Date: &yesterday, Status: "completed"}
+	mustCreateTodo(t, &done)
+	_, err := repo.DB.Exec("UPDATE todos SET completed_at = created_at AT TIME ZONE 'UTC' + interval '1 hour' WHERE id = $1", done.ID)
+	require.NoError(t, err)
+
+	res, err := repo.TodoStats(ctx, dto.TodoFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []dto.TodoStatusStats{
+		{Status: "completed", Total: 1, Completed: 1, CompletionSeconds: 3600},
+		{Status: "pending", Total: 2, Overdue: 1},
+	}, res)
+
+	series, err := repo.TodoSeries(ctx, dto.TodoSeriesFilter{Interval: "day"}, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Nil(t, series[0].Period)
+	require.Equal(t, int64(3), series[0].Created)
+	require.Equal(t, int64(1), series[0].Completed)
+
+	series, err = repo.TodoSeries(ctx, dto.TodoSeriesFilter{Interval: "week"}, time.Now().AddDate(0, 0, -7), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, series[0].Period)
+}
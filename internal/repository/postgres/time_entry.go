@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// todoTracked selects the time tracked on a todo in seconds, running timers count up to now.
+const todoTracked = `(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)), 0)::bigint
+	FROM time_entries WHERE time_entries.todo_id = todos.id) AS tracked_seconds`
+
+// timeEntryDuration selects the duration of the returned time entry.
+const timeEntryDuration = "EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)::bigint AS duration_seconds"
+
+type TimeEntryRepository struct {
+	DB *sqlx.DB
+}
+
+func NewTimeEntryRepository(db *sqlx.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{
+		DB: db,
+	}
+}
+
+func (s *TimeEntryRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateEntry stores a time entry, a running timer when ended_at is not set. sql.ErrNoRows is
+// returned when the todo does not exist and errs.ErrConflict when the user already runs a timer.
+func (s *TimeEntryRepository) CreateEntry(ctx context.Context, entry *dto.TimeEntry) (err error) {
+	query, args, err := s.Builder().Insert("time_entries").SetMap(map[string]interface{}{
+		"todo_id":    entry.TodoID,
+		"user_id":    entry.UserID,
+		"started_at": entry.StartedAt,
+		"ended_at":   entry.EndedAt,
+		"note":       entry.Note,
+	}).Suffix("RETURNING id, created_at, " + timeEntryDuration).ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.CreateEntry", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(entry)
+	return duplicateKey(missingReference(err))
+}
+
+func (s *TimeEntryRepository) GetEntry(ctx context.Context, todoID, id int64) (_ dto.TimeEntry, err error) {
+	query, args, err := s.Builder().Select("*", timeEntryDuration).
+		From("time_entries").
+		Where(sq.Eq{"id": id, "todo_id": todoID}).
+		ToSql()
+	if err != nil {
+		return dto.TimeEntry{}, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.GetEntry", query)
+	defer func() { done(err) }()
+
+	var res dto.TimeEntry
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.TimeEntry{}, err
+	}
+	return res, nil
+}
+
+// RunningEntry returns the running timer of the user, sql.ErrNoRows is returned when there is none.
+func (s *TimeEntryRepository) RunningEntry(ctx context.Context, userID *int64) (_ dto.TimeEntry, err error) {
+	query, args, err := s.Builder().Select("*", timeEntryDuration).
+		From("time_entries").
+		Where(sq.Eq{"user_id": userID, "ended_at": nil}).
+		ToSql()
+	if err != nil {
+		return dto.TimeEntry{}, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.RunningEntry", query)
+	defer func() { done(err) }()
+
+	var res dto.TimeEntry
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.TimeEntry{}, err
+	}
+	return res, nil
+}
+
+// StopTimer ends the running timer of the user on the todo now, sql.ErrNoRows is returned when
+// the user runs no timer on the todo.
+func (s *TimeEntryRepository) StopTimer(ctx context.Context, todoID int64, userID *int64) (_ dto.TimeEntry, err error) {
+	query, args, err := s.Builder().Update("time_entries").
+		Set("ended_at", sq.Expr("GREATEST(NOW(), started_at)")).
+		Where(sq.Eq{"todo_id": todoID, "user_id": userID, "ended_at": nil}).
+		Suffix("RETURNING *, " + timeEntryDuration).
+		ToSql()
+	if err != nil {
+		return dto.TimeEntry{}, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.StopTimer", query)
+	defer func() { done(err) }()
+
+	var res dto.TimeEntry
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.TimeEntry{}, err
+	}
+	return res, nil
+}
+
+// UpdateEntry replaces the times and the note of the entry.
+func (s *TimeEntryRepository) UpdateEntry(ctx context.Context, entry *dto.TimeEntry) (err error) {
+	query, args, err := s.Builder().Update("time_entries").
+		Set("started_at", entry.StartedAt).
+		Set("ended_at", entry.EndedAt).
+		Set("note", entry.Note).
+		Where(sq.Eq{"id": entry.ID, "todo_id": entry.TodoID}).
+		Suffix("RETURNING *, " + timeEntryDuration).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.UpdateEntry", query)
+	defer func() { done(err) }()
+
+	err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(entry)
+	return duplicateKey(err)
+}
+
+func (s *TimeEntryRepository) DeleteEntry(ctx context.Context, todoID, id int64) error {
+	return execAffected(ctx, s.DB, "TimeEntryRepository.DeleteEntry",
+		s.Builder().Delete("time_entries").Where(sq.Eq{"id": id, "todo_id": todoID}))
+}
+
+// ListEntries returns the time entries of the todo, oldest first.
+func (s *TimeEntryRepository) ListEntries(ctx context.Context, todoID int64) (_ []dto.TimeEntry, err error) {
+	query, args, err := s.Builder().Select("*", timeEntryDuration).
+		From("time_entries").
+		Where(sq.Eq{"todo_id": todoID}).
+		OrderBy("started_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.ListEntries", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TimeEntry, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Overlapping returns the first entry of the user overlapping the span, a running timer
+// overlaps everything after its start. The entry excludeID is ignored, so an edited entry
+// does not overlap itself. sql.ErrNoRows is returned when no entry overlaps.
+func (s *TimeEntryRepository) Overlapping(ctx context.Context, userID int64, start, end time.Time, excludeID int64) (_ dto.TimeEntry, err error) {
+	query, args, err := s.Builder().Select("*", timeEntryDuration).
+		From("time_entries").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.NotEq{"id": excludeID}).
+		Where("tstzrange(started_at, COALESCE(ended_at, 'infinity')) && tstzrange(?, ?)", start, end).
+		OrderBy("started_at").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return dto.TimeEntry{}, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.Overlapping", query)
+	defer func() { done(err) }()
+
+	var res dto.TimeEntry
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.TimeEntry{}, err
+	}
+	return res, nil
+}
+
+// Report sums the time tracked in entries started between the first and the last day of the
+// filter in its time zone, grouped by day, project, status or todo. Most tracked groups come first,
+// days are ordered by date.
+func (s *TimeEntryRepository) Report(ctx context.Context, filter dto.TimeReportFilter) (_ []dto.TimeReportRow, err error) {
+	tz := filter.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	entries := func(q sq.SelectBuilder) sq.SelectBuilder {
+		q = q.From("time_entries e").
+			Join("todos ON todos.id = e.todo_id").
+			Where("e.started_at >= (?::date)::timestamp AT TIME ZONE ?", filter.From.Format(time.DateOnly), tz).
+			Where("e.started_at < (?::date + 1)::timestamp AT TIME ZONE ?", filter.To.Format(time.DateOnly), tz)
+		if filter.ProjectID != nil {
+			q = q.Where(sq.Eq{"todos.project_id": *filter.ProjectID})
+		}
+		if filter.UserID != nil {
+			q = q.Where(sq.Eq{"e.user_id": *filter.UserID})
+		}
+		if filter.VisibleTo != 0 {
+			q = q.Where(sq.Or{
+				sq.Eq{"todos.project_id": nil},
+				sq.Expr("todos.project_id IN (SELECT project_id FROM project_members WHERE user_id = ?)", filter.VisibleTo),
+			})
+		}
+		return q
+	}
+	const tracked = "SUM(EXTRACT(EPOCH FROM COALESCE(e.ended_at, NOW()) - e.started_at))"
+
+	var q sq.SelectBuilder
+	if filter.GroupBy == model.TimeReportByDay {
+		q = entries(s.Builder().
+			Select().
+			Column(sq.Expr("to_char((e.started_at AT TIME ZONE ?)::date, 'YYYY-MM-DD') AS key", tz)).
+			Columns("'' AS label", tracked+"::bigint AS tracked_seconds", "NULL::bigint AS estimate_minutes")).
+			GroupBy("1").
+			OrderBy("1")
+	} else {
+		var key, label string
+		switch filter.GroupBy {
+		case model.TimeReportByProject:
+			key, label = "COALESCE(todos.project_id::text, '')", "COALESCE(projects.name, '')"
+		case model.TimeReportByStatus:
+			key, label = "todos.status", "''"
+		default:
+			key, label = "todos.id::text", "todos.title"
+		}
+		// tracked time is summed per todo first, so the estimate of a todo is counted once
+		perTodo := entries(sq.Select("e.todo_id", tracked+" AS tracked")).GroupBy("e.todo_id")
+		q = s.Builder().
+			Select(key+" AS key", label+" AS label",
+				"SUM(t.tracked)::bigint AS tracked_seconds", "SUM(todos.estimate_minutes)::bigint AS estimate_minutes").
+			FromSelect(perTodo, "t").
+			Join("todos ON todos.id = t.todo_id").
+			LeftJoin("projects ON projects.id = todos.project_id").
+			GroupBy("1", "2").
+			OrderBy("tracked_seconds DESC", "1")
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "TimeEntryRepository.Report", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.TimeReportRow, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
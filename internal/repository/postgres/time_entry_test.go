@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+)
+
+func TestTimeEntryRepository(t *testing.T) {
+	r := NewTimeEntryRepository(repo.DB)
+	ctx := context.Background()
+	_, err := repo.DB.Exec("DELETE FROM projects; DELETE FROM users;")
+	require.NoError(t, err)
+	mustTruncate(t)
+
+	ann := mustCreateUser(t, "ann@example.com")
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	todo := dto.TodoItem{Title: "release", Date: &date, Status: "pending", EstimateMinutes: pointer.Pointer(int64(60))}
+	mustCreateTodo(t, &todo)
+
+	hourAgo := time.Now().Add(-time.Hour).Truncate(time.Second)
+	halfHourAgo := hourAgo.Add(30 * time.Minute)
+	manual := dto.TimeEntry{TodoID: todo.ID, UserID: &ann.ID, StartedAt: hourAgo, EndedAt: &halfHourAgo, Note: "review"}
+	require.NoError(t, r.CreateEntry(ctx, &manual))
+	require.Equal(t, int64(30*60), manual.DurationSeconds)
+
+	t.Run("unknown todo", func(t *testing.T) {
+		require.ErrorIs(t, r.CreateEntry(ctx, &dto.TimeEntry{TodoID: -1, StartedAt: hourAgo}), sql.ErrNoRows)
+	})
+
+	t.Run("one running timer per user", func(t *testing.T) {
+		require.NoError(t, r.CreateEntry(ctx, &dto.TimeEntry{TodoID: todo.ID, UserID: &ann.ID, StartedAt: time.Now()}))
+		require.ErrorIs(t, r.CreateEntry(ctx, &dto.TimeEntry{TodoID: todo.ID, UserID: &ann.ID, StartedAt: time.Now()}), errs.ErrConflict)
+
+		running, err := r.RunningEntry(ctx, &ann.ID)
+		require.NoError(t, err)
+		require.Nil(t, running.EndedAt)
+
+		stopped, err := r.StopTimer(ctx, todo.ID, &ann.ID)
+		require.NoError(t, err)
+		require.Equal(t, running.ID, stopped.ID)
+		require.NotNil(t, stopped.EndedAt)
+
+		_, err = r.StopTimer(ctx, todo.ID, &ann.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		res, err := r.Overlapping(ctx, ann.ID, hourAgo.Add(10*time.Minute), hourAgo.Add(20*time.Minute), 0)
+		require.NoError(t, err)
+		require.Equal(t, manual.ID, res.ID)
+
+		_, err = r.Overlapping(ctx, ann.ID, hourAgo.Add(10*time.Minute), hourAgo.Add(20*time.Minute), manual.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("tracked time on todo", func(t *testing.T) {
+		item, err := repo.GetTodoByID(ctx, todo.ID)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, item.TrackedSeconds, int64(30*60))
+		require.Equal(t, int64(60), *item.EstimateMinutes)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		from, to := time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1)
+		filter := dto.TimeReportFilter{From: &from, To: &to, GroupBy: model.TimeReportByStatus}
+		res, err := r.Report(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, "pending", res[0].Key)
+		require.Equal(t, int64(60), *res[0].EstimateMinutes)
+
+		filter.GroupBy = model.TimeReportByDay
+		res, err = r.Report(ctx, filter)
+		require.NoError(t, err)
+		require.NotEmpty(t, res)
+		require.Nil(t, res[0].EstimateMinutes)
+
+		filter.GroupBy = model.TimeReportByTodo
+		res, err = r.Report(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, "release", res[0].Label)
+	})
+
+	t.Run("edit and delete", func(t *testing.T) {
+		manual.Note = "code review"
+		require.NoError(t, r.UpdateEntry(ctx, &manual))
+		require.Equal(t, "code review", manual.Note)
+
+		require.NoError(t, r.DeleteEntry(ctx, todo.ID, manual.ID))
+		require.ErrorIs(t, r.DeleteEntry(ctx, todo.ID, manual.ID), sql.ErrNoRows)
+	})
+}
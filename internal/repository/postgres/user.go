@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"todo-list/internal/domain/dto"
+)
+
+type UserRepository struct {
+	DB *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{
+		DB: db,
+	}
+}
+
+func (s *UserRepository) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).RunWith(s.DB)
+}
+
+// CreateUser stores a new user, sql.ErrNoRows is returned when the email is already taken
+// and errs.ErrConflict when the username is.
+func (s *UserRepository) CreateUser(ctx context.Context, user *dto.User) (err error) {
+	query, args, err := s.Builder().Insert("users").SetMap(map[string]interface{}{
+		"email":     user.Email,
+		"name":      user.Name,
+		"time_zone": user.TimeZone,
+		"username":  user.Username,
+	}).Suffix("ON CONFLICT (email) DO NOTHING RETURNING id, created_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "UserRepository.CreateUser", query)
+	defer func() { done(err) }()
+
+	err = duplicateKey(s.DB.QueryRowxContext(ctx, query, args...).StructScan(user))
+	return err
+}
+
+func (s *UserRepository) GetUserByID(ctx context.Context, id int64) (_ dto.User, err error) {
+	query, args, err := s.Builder().Select("*").From("users").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return dto.User{}, err
+	}
+
+	ctx, done := instrument(ctx, "UserRepository.GetUserByID", query)
+	defer func() { done(err) }()
+
+	var res dto.User
+	if err = s.DB.QueryRowxContext(ctx, query, args...).StructScan(&res); err != nil {
+		return dto.User{}, err
+	}
+
+	return res, nil
+}
+
+// UpdateUser changes the name, the username and the time zone of the user, empty values
+// are kept. errs.ErrConflict is returned when the username is taken.
+func (s *UserRepository) UpdateUser(ctx context.Context, user *dto.User) (err error) {
+	q := s.Builder().Update("users").Where(sq.Eq{"id": user.ID}).Suffix("RETURNING *")
+	if user.Name != "" {
+		q = q.Set("name", user.Name)
+	}
+	if user.TimeZone != "" {
+		q = q.Set("time_zone", user.TimeZone)
+	}
+	if user.Username != nil {
+		q = q.Set("username", *user.Username)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "UserRepository.UpdateUser", query)
+	defer func() { done(err) }()
+
+	err = duplicateKey(s.DB.QueryRowxContext(ctx, query, args...).StructScan(user))
+	return err
+}
+
+func (s *UserRepository) ListUsers(ctx context.Context) (_ []dto.User, err error) {
+	query, args, err := s.Builder().Select("*").From("users").OrderBy("id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "UserRepository.ListUsers", query)
+	defer func() { done(err) }()
+
+	res := make([]dto.User, 0)
+	if err = s.DB.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
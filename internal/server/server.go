@@ -2,23 +2,28 @@ package server
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
 	"time"
-	http2 "todo-list/internal/controller/http"
-	"todo-list/internal/service/todo"
+	"todo-list/internal/health"
 )
 
+// Worker is a background job running until its context is cancelled on shutdown.
+type Worker func(ctx context.Context)
+
 type Server struct {
-	httpServer  *http.Server
-	todoService todo.Service
+	httpServer    *http.Server
+	health        *health.Health
+	shutdownDelay time.Duration
+	workers       []Worker
 }
 
-func NewServer(s todo.Service) Server {
-	r := http2.NewHandler(s).NewRouter()
-
+func NewServer(r http.Handler, h *health.Health, shutdownDelay time.Duration) Server {
 	srv := &http.Server{
 		Addr:         ":8080",
 		ReadTimeout:  5 * time.Second,
@@ -27,30 +32,58 @@ func NewServer(s todo.Service) Server {
 	}
 
 	return Server{
-		httpServer:  srv,
-		todoService: s,
+		httpServer:    srv,
+		health:        h,
+		shutdownDelay: shutdownDelay,
 	}
 }
 
+// AddWorker registers a background job started by Run.
+func (s *Server) AddWorker(w Worker) {
+	s.workers = append(s.workers, w)
+}
+
 func (s *Server) Run() error {
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, w := range s.workers {
+		wg.Add(1)
+		go func(w Worker) {
+			defer wg.Done()
+			w(workersCtx)
+		}(w)
+	}
+	defer wg.Wait()
+	defer stopWorkers()
+
 	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 	go func(ch chan os.Signal) {
-		if err := s.httpServer.ListenAndServe(); err != nil {
-			log.Println(err.Error())
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server error", slog.Any("error", err))
 			done <- os.Interrupt
 			return
 		}
 	}(done)
 
-	log.Printf("Server started on %s port", ":8080")
+	slog.Info("server started", slog.String("addr", s.httpServer.Addr))
 
 	<-done
-	defer close(done)
+	signal.Stop(done)
+
+	// readiness fails first so that load balancers stop routing new requests
+	// before the listener is closed
+	s.health.SetReady(false)
+	slog.Info("server is shutting down", slog.Duration("delay", s.shutdownDelay))
+	time.Sleep(s.shutdownDelay)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
-	log.Println("Server gracefully closed")
 
-	return s.httpServer.Shutdown(ctx)
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return err
+	}
+	slog.Info("server gracefully closed")
+
+	return nil
 }
@@ -0,0 +1,227 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+// Keys look like todo_<prefix>_<secret>. The prefix is stored in clear text to find
+// the key, the whole key is stored as a SHA-256 hash only.
+const (
+	keyPrefix    = "todo"
+	prefixBytes  = 6
+	secretBytes  = 32
+	keySeparator = "_"
+)
+
+// touchInterval limits how often the last use of a key is written.
+const touchInterval = time.Minute
+
+type APIKeyService struct {
+	KeyRepo Repository
+
+	mu      sync.Mutex
+	touched map[int64]time.Time
+}
+
+func NewAPIKeyService(kr Repository) *APIKeyService {
+	return &APIKeyService{
+		KeyRepo: kr,
+		touched: make(map[int64]time.Time),
+	}
+}
+
+func invalidID() error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   "id",
+		Code:    errs.ViolationInvalid,
+		Message: "id must be positive",
+	})
+}
+
+func (s *APIKeyService) CreateKey(ctx context.Context, key model.APIKey) (model.IssuedAPIKey, error) {
+	if err := key.Validate(); err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+
+	plain, keyDto, err := generate(key)
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+
+	if err = s.KeyRepo.CreateKey(ctx, &keyDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.IssuedAPIKey{}, errs.Validation(errs.FieldViolation{
+				Field:   "user_id",
+				Code:    errs.ViolationInvalid,
+				Message: "user not found",
+			})
+		}
+		return model.IssuedAPIKey{}, err
+	}
+
+	return model.IssuedAPIKey{
+		APIKey: converter.ConvertAPIKeyToModel(keyDto),
+		Key:    plain,
+	}, nil
+}
+
+func (s *APIKeyService) ListKeys(ctx context.Context) ([]model.APIKey, error) {
+	keys, err := s.KeyRepo.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertAPIKeysToModels(keys), nil
+}
+
+func (s *APIKeyService) RevokeKey(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return invalidID()
+	}
+
+	err := s.KeyRepo.RevokeKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// RotateKey issues a new secret with the same name and scopes and revokes the old key.
+func (s *APIKeyService) RotateKey(ctx context.Context, id int64) (model.IssuedAPIKey, error) {
+	if id <= 0 {
+		return model.IssuedAPIKey{}, invalidID()
+	}
+
+	old, err := s.KeyRepo.GetKeyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.IssuedAPIKey{}, ErrNotFound
+		}
+		return model.IssuedAPIKey{}, err
+	}
+	if old.RevokedAt != nil {
+		return model.IssuedAPIKey{}, errs.Conflict("revoked key can not be rotated")
+	}
+
+	plain, keyDto, err := generate(converter.ConvertAPIKeyToModel(old))
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+
+	if err = s.KeyRepo.RotateKey(ctx, id, &keyDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.IssuedAPIKey{}, ErrNotFound
+		}
+		return model.IssuedAPIKey{}, err
+	}
+
+	return model.IssuedAPIKey{
+		APIKey: converter.ConvertAPIKeyToModel(keyDto),
+		Key:    plain,
+	}, nil
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (model.Principal, error) {
+	prefix, ok := parse(key)
+	if !ok {
+		return model.Principal{}, ErrUnauthorized
+	}
+
+	stored, err := s.KeyRepo.GetKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Principal{}, ErrUnauthorized
+		}
+		return model.Principal{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash(key)), []byte(stored.Hash)) != 1 || stored.RevokedAt != nil {
+		return model.Principal{}, ErrUnauthorized
+	}
+
+	s.touch(ctx, stored.ID)
+
+	k := converter.ConvertAPIKeyToModel(stored)
+	p := model.Principal{
+		KeyID:  k.ID,
+		Name:   k.Name,
+		Scopes: k.Scopes,
+	}
+	if stored.UserID != nil {
+		p.UserID = *stored.UserID
+	}
+	if stored.UserEmail != nil {
+		p.Email = *stored.UserEmail
+	}
+	if stored.UserTZ != nil {
+		p.TimeZone = *stored.UserTZ
+	}
+
+	return p, nil
+}
+
+// touch records the use of the key at most once per touchInterval. Failures are only
+// logged, the last use is informational and must not fail the request.
+func (s *APIKeyService) touch(ctx context.Context, id int64) {
+	now := time.Now()
+	s.mu.Lock()
+	if now.Sub(s.touched[id]) < touchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[id] = now
+	s.mu.Unlock()
+
+	if err := s.KeyRepo.TouchKey(ctx, id); err != nil {
+		slog.WarnContext(ctx, "record api key use", slog.Int64("key_id", id), slog.Any("error", err))
+	}
+}
+
+func generate(key model.APIKey) (string, dto.APIKey, error) {
+	prefix := make([]byte, prefixBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", dto.APIKey{}, err
+	}
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", dto.APIKey{}, err
+	}
+
+	key.Prefix = hex.EncodeToString(prefix)
+	plain := strings.Join([]string{keyPrefix, key.Prefix, base64.RawURLEncoding.EncodeToString(secret)}, keySeparator)
+
+	res := converter.ConvertAPIKeyToDTO(key)
+	res.ID = 0
+	res.Hash = hash(plain)
+
+	return plain, res, nil
+}
+
+// parse extracts the lookup prefix of a key.
+func parse(key string) (string, bool) {
+	parts := strings.SplitN(key, keySeparator, 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || len(parts[1]) != prefixBytes*2 || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,160 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+	mock_apikey "todo-list/pkg/mocks/service/apikey"
+)
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_apikey.NewMockRepository(ctrl)
+	s := NewAPIKeyService(repo)
+
+	t.Run("key is stored hashed", func(t *testing.T) {
+		var stored dto.APIKey
+		repo.EXPECT().CreateKey(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, k *dto.APIKey) error {
+			k.ID = 7
+			stored = *k
+			return nil
+		})
+
+		res, err := s.CreateKey(context.Background(), model.APIKey{
+			Name:   "ci",
+			Scopes: []model.Scope{model.ScopeRead},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(7), res.ID)
+		require.True(t, strings.HasPrefix(res.Key, "todo_"+res.Prefix+"_"))
+		require.Equal(t, hash(res.Key), stored.Hash)
+		require.NotContains(t, stored.Hash, res.Key)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := s.CreateKey(context.Background(), model.APIKey{
+			Name:   "ci",
+			Scopes: []model.Scope{"root"},
+		})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_apikey.NewMockRepository(ctrl)
+	s := NewAPIKeyService(repo)
+
+	plain, key, err := generate(model.APIKey{Name: "ci", Scopes: []model.Scope{model.ScopeWrite}})
+	require.NoError(t, err)
+	key.ID = 3
+
+	t.Run("valid key", func(t *testing.T) {
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(key, nil)
+		repo.EXPECT().TouchKey(gomock.Any(), int64(3)).Return(nil)
+
+		p, err := s.Authenticate(context.Background(), plain)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), p.KeyID)
+		require.True(t, p.Can(model.ScopeRead))
+		require.True(t, p.Can(model.ScopeWrite))
+		require.False(t, p.Can(model.ScopeAdmin))
+	})
+
+	t.Run("key of a user", func(t *testing.T) {
+		owned := key
+		owned.UserID = pointer.Pointer(int64(7))
+		owned.UserEmail = pointer.Pointer("ann@example.com")
+		// the use was recorded by the previous request
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(owned, nil)
+
+		p, err := s.Authenticate(context.Background(), plain)
+		require.NoError(t, err)
+		require.Equal(t, int64(7), p.UserID)
+		require.Equal(t, "ann@example.com", p.Email)
+	})
+
+	t.Run("failed touch", func(t *testing.T) {
+		s := NewAPIKeyService(repo)
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(key, nil)
+		repo.EXPECT().TouchKey(gomock.Any(), int64(3)).Return(sql.ErrConnDone)
+
+		p, err := s.Authenticate(context.Background(), plain)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), p.KeyID)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(key, nil)
+
+		_, err := s.Authenticate(context.Background(), plain+"x")
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		revoked := key
+		revoked.RevokedAt = pointer.Pointer(time.Now())
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(revoked, nil)
+
+		_, err := s.Authenticate(context.Background(), plain)
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		repo.EXPECT().GetKeyByPrefix(gomock.Any(), key.Prefix).Return(dto.APIKey{}, sql.ErrNoRows)
+
+		_, err := s.Authenticate(context.Background(), plain)
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		_, err := s.Authenticate(context.Background(), "not-a-key")
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestAPIKeyService_RotateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_apikey.NewMockRepository(ctrl)
+	s := NewAPIKeyService(repo)
+
+	t.Run("rotate keeps name and scopes", func(t *testing.T) {
+		repo.EXPECT().GetKeyByID(gomock.Any(), int64(5)).Return(dto.APIKey{
+			ID:     5,
+			Name:   "ci",
+			Prefix: "aaaaaaaaaaaa",
+			Scopes: []string{"write"},
+		}, nil)
+		repo.EXPECT().RotateKey(gomock.Any(), int64(5), gomock.Any()).DoAndReturn(func(ctx context.Context, id int64, k *dto.APIKey) error {
+			k.ID = 6
+			return nil
+		})
+
+		res, err := s.RotateKey(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), res.ID)
+		require.Equal(t, "ci", res.Name)
+		require.Equal(t, []model.Scope{model.ScopeWrite}, res.Scopes)
+		require.NotEqual(t, "aaaaaaaaaaaa", res.Prefix)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo.EXPECT().GetKeyByID(gomock.Any(), int64(404)).Return(dto.APIKey{}, sql.ErrNoRows)
+
+		_, err := s.RotateKey(context.Background(), 404)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
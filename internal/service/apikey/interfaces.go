@@ -0,0 +1,34 @@
+package apikey
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateKey(ctx context.Context, key model.APIKey) (model.IssuedAPIKey, error)
+		ListKeys(ctx context.Context) ([]model.APIKey, error)
+		RevokeKey(ctx context.Context, id int64) error
+		RotateKey(ctx context.Context, id int64) (model.IssuedAPIKey, error)
+		Authenticate(ctx context.Context, key string) (model.Principal, error)
+	}
+
+	Repository interface {
+		CreateKey(ctx context.Context, key *dto.APIKey) error
+		ListKeys(ctx context.Context) ([]dto.APIKey, error)
+		GetKeyByID(ctx context.Context, id int64) (dto.APIKey, error)
+		GetKeyByPrefix(ctx context.Context, prefix string) (dto.APIKey, error)
+		RevokeKey(ctx context.Context, id int64) error
+		RotateKey(ctx context.Context, oldID int64, key *dto.APIKey) error
+		TouchKey(ctx context.Context, id int64) error
+	}
+)
+
+var (
+	ErrValidation   = errs.ErrValidation
+	ErrNotFound     = errs.ErrNotFound
+	ErrUnauthorized = errs.ErrUnauthorized
+)
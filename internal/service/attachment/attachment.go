@@ -0,0 +1,247 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"todo-list/internal/auth"
+	"todo-list/internal/blob"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+// sniffLength is the number of leading bytes the content type is detected from.
+const sniffLength = 512
+
+type Limits struct {
+	// MaxFileBytes is the maximum size of one file
+	MaxFileBytes int64
+	// UserQuotaBytes is the maximum total size of the files of a user, 0 is unlimited
+	UserQuotaBytes int64
+}
+
+type AttachmentService struct {
+	AttachmentRepo Repository
+	Todos          Todos
+	Store          blob.Store
+	Limits         Limits
+}
+
+func NewAttachmentService(ar Repository, todos Todos, store blob.Store, limits Limits) *AttachmentService {
+	return &AttachmentService{
+		AttachmentRepo: ar,
+		Todos:          todos,
+		Store:          store,
+		Limits:         limits,
+	}
+}
+
+func invalidID(field string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   field,
+		Code:    errs.ViolationInvalid,
+		Message: field + " must be positive",
+	})
+}
+
+func attachmentNotFound() error {
+	return errs.NotFound("attachment not found")
+}
+
+// errLimitExceeded is returned by limitedReader when the contents grow past the limit.
+var errLimitExceeded = errors.New("limit exceeded")
+
+// limitedReader counts the bytes read and fails once more than limit bytes are read.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, errLimitExceeded
+	}
+	return n, err
+}
+
+// limit returns the maximum size of the next file of the caller and the message to
+// report when it is exceeded.
+func (s *AttachmentService) limit(ctx context.Context) (int64, string, error) {
+	limit := s.Limits.MaxFileBytes
+	message := fmt.Sprintf("file must not be larger than %d bytes", limit)
+
+	p, ok := auth.PrincipalFromContext(ctx)
+	if s.Limits.UserQuotaBytes <= 0 || !ok || p.UserID == 0 {
+		return limit, message, nil
+	}
+
+	used, err := s.AttachmentRepo.UsedBytes(ctx, p.UserID)
+	if err != nil {
+		return 0, "", err
+	}
+	remaining := s.Limits.UserQuotaBytes - used
+	if remaining <= 0 {
+		return 0, "", errs.TooLarge("attachment quota is exhausted")
+	}
+	if remaining < limit {
+		return remaining, fmt.Sprintf("file exceeds the remaining attachment quota of %d bytes", remaining), nil
+	}
+	return limit, message, nil
+}
+
+func storageKey(todoID int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("todos/%d/%s", todoID, hex.EncodeToString(b)), nil
+}
+
+// CreateAttachment streams the file to the blob storage and stores its metadata. The
+// content type is sniffed from the contents. Editors of the todo may attach files to it,
+// within the file size limit and the quota of the user.
+func (s *AttachmentService) CreateAttachment(ctx context.Context, a *model.Attachment, r io.Reader) error {
+	a.FileName = model.NormalizeFileName(a.FileName)
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.Todos.AuthorizeTodo(ctx, a.TodoID, model.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	limit, message, err := s.limit(ctx)
+	if err != nil {
+		return err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if n == 0 {
+		return errs.Validation(errs.FieldViolation{Field: "file", Code: errs.ViolationRequired, Message: "file must not be empty"})
+	}
+	head = head[:n]
+
+	key, err := storageKey(a.TodoID)
+	if err != nil {
+		return err
+	}
+
+	a.ContentType = http.DetectContentType(head)
+	a.StorageKey = key
+	a.UploaderID = nil
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		a.UploaderID = &p.UserID
+	}
+
+	lr := &limitedReader{r: io.MultiReader(bytes.NewReader(head), r), limit: limit}
+	if err = s.Store.Put(ctx, key, lr, -1, a.ContentType); err != nil {
+		if errors.Is(err, errLimitExceeded) {
+			return errs.TooLarge(message)
+		}
+		return fmt.Errorf("store attachment: %w", err)
+	}
+	a.Size = lr.read
+
+	attachmentDto := converter.ConvertAttachmentToDTO(*a)
+	if err = s.AttachmentRepo.CreateAttachment(ctx, &attachmentDto); err != nil {
+		if delErr := s.Store.Delete(context.WithoutCancel(ctx), key); delErr != nil {
+			slog.WarnContext(ctx, "delete orphaned attachment blob", slog.String("storage_key", key), slog.Any("error", delErr))
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	*a = converter.ConvertAttachmentToModel(attachmentDto)
+	return nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, todoID int64) ([]model.Attachment, error) {
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	res, err := s.AttachmentRepo.ListAttachments(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ConvertAttachmentsToModels(res), nil
+}
+
+func (s *AttachmentService) get(ctx context.Context, todoID, id int64) (model.Attachment, error) {
+	if id <= 0 {
+		return model.Attachment{}, invalidID("attachment_id")
+	}
+
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return model.Attachment{}, err
+	}
+
+	res, err := s.AttachmentRepo.GetAttachment(ctx, todoID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Attachment{}, attachmentNotFound()
+		}
+		return model.Attachment{}, err
+	}
+	return converter.ConvertAttachmentToModel(res), nil
+}
+
+// OpenAttachment returns the metadata and the contents of the attachment, the caller closes the contents.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, todoID, id int64) (model.Attachment, io.ReadCloser, error) {
+	a, err := s.get(ctx, todoID, id)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	rc, err := s.Store.Get(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return model.Attachment{}, nil, attachmentNotFound()
+		}
+		return model.Attachment{}, nil, err
+	}
+	return a, rc, nil
+}
+
+// DeleteAttachment deletes an attachment the caller uploaded as an editor of the todo, keys
+// which are not restricted to a user may delete any attachment. The blob is removed in the
+// background.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, todoID, id int64) error {
+	if err := s.Todos.AuthorizeTodo(ctx, todoID, model.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	a, err := s.get(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+
+	userID, restricted := auth.Member(ctx)
+	if restricted && (a.UploaderID == nil || *a.UploaderID != userID) {
+		return errs.Forbidden("only the uploader may delete the attachment")
+	}
+
+	if err = s.AttachmentRepo.DeleteAttachment(ctx, todoID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return attachmentNotFound()
+		}
+		return err
+	}
+	return nil
+}
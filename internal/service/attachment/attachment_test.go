@@ -0,0 +1,169 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"todo-list/internal/auth"
+	"todo-list/internal/blob"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+	mock_attachment "todo-list/pkg/mocks/service/attachment"
+)
+
+func asUser(id int64) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+}
+
+func newService(t *testing.T, limits Limits) (*AttachmentService, *mock_attachment.MockRepository, *mock_attachment.MockTodos, *blob.Local) {
+	ctrl := gomock.NewController(t)
+	store, err := blob.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	repo := mock_attachment.NewMockRepository(ctrl)
+	todos := mock_attachment.NewMockTodos(ctrl)
+	return NewAttachmentService(repo, todos, store, limits), repo, todos, store
+}
+
+func read(t *testing.T, store blob.Store, key string) string {
+	rc, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestAttachmentService_CreateAttachment(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 100)
+
+	t.Run("sniffed type and size", func(t *testing.T) {
+		s, repo, todos, store := newService(t, Limits{MaxFileBytes: 1000, UserQuotaBytes: 10000})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil)
+		repo.EXPECT().UsedBytes(gomock.Any(), int64(5)).Return(int64(100), nil)
+		repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *dto.Attachment) error {
+			require.Equal(t, "image/png", a.ContentType)
+			require.Equal(t, int64(len(png)), a.Size)
+			require.Equal(t, int64(5), *a.UploaderID)
+			require.True(t, strings.HasPrefix(a.StorageKey, "todos/1/"))
+			a.ID = 3
+			return nil
+		})
+
+		a := &model.Attachment{TodoID: 1, FileName: `C:\Users\ann\photo.png`}
+		require.NoError(t, s.CreateAttachment(asUser(5), a, strings.NewReader(png)))
+		require.Equal(t, int64(3), a.ID)
+		require.Equal(t, "photo.png", a.FileName)
+		require.Equal(t, png, read(t, store, a.StorageKey))
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		s, _, todos, _ := newService(t, Limits{MaxFileBytes: 50})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil)
+
+		err := s.CreateAttachment(asUser(5), &model.Attachment{TodoID: 1, FileName: "a.png"}, strings.NewReader(png))
+		var e *errs.Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, errs.CodeTooLarge, e.Code)
+	})
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		s, repo, todos, _ := newService(t, Limits{MaxFileBytes: 1000, UserQuotaBytes: 150})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil)
+		repo.EXPECT().UsedBytes(gomock.Any(), int64(5)).Return(int64(100), nil)
+
+		err := s.CreateAttachment(asUser(5), &model.Attachment{TodoID: 1, FileName: "a.png"}, strings.NewReader(png))
+		require.ErrorContains(t, err, "remaining attachment quota of 50 bytes")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		s, _, todos, _ := newService(t, Limits{MaxFileBytes: 1000})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil)
+
+		err := s.CreateAttachment(asUser(5), &model.Attachment{TodoID: 1, FileName: "a.txt"}, strings.NewReader(""))
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("viewers may not attach", func(t *testing.T) {
+		s, _, todos, _ := newService(t, Limits{MaxFileBytes: 1000})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(errs.Forbidden("project role editor is required"))
+
+		err := s.CreateAttachment(asUser(5), &model.Attachment{TodoID: 1, FileName: "a.png"}, strings.NewReader(png))
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("missing file name", func(t *testing.T) {
+		s, _, _, _ := newService(t, Limits{MaxFileBytes: 1000})
+		err := s.CreateAttachment(asUser(5), &model.Attachment{TodoID: 1, FileName: "../"}, strings.NewReader("x"))
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("blob is removed when the todo is gone", func(t *testing.T) {
+		s, repo, todos, store := newService(t, Limits{MaxFileBytes: 1000})
+		todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil)
+		var key string
+		repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *dto.Attachment) error {
+			key = a.StorageKey
+			return sql.ErrNoRows
+		})
+
+		err := s.CreateAttachment(context.Background(), &model.Attachment{TodoID: 1, FileName: "a.txt"}, strings.NewReader("hello"))
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = store.Get(context.Background(), key)
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+}
+
+func TestAttachmentService_OpenAttachment(t *testing.T) {
+	s, repo, todos, store := newService(t, Limits{MaxFileBytes: 1000})
+	require.NoError(t, store.Put(context.Background(), "todos/1/abc", strings.NewReader("hello"), 5, ""))
+
+	todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil).Times(2)
+	repo.EXPECT().GetAttachment(gomock.Any(), int64(1), int64(3)).Return(dto.Attachment{ID: 3, TodoID: 1, FileName: "a.txt", StorageKey: "todos/1/abc"}, nil)
+	repo.EXPECT().GetAttachment(gomock.Any(), int64(1), int64(4)).Return(dto.Attachment{}, sql.ErrNoRows)
+
+	a, rc, err := s.OpenAttachment(asUser(5), 1, 3)
+	require.NoError(t, err)
+	defer rc.Close()
+	require.Equal(t, "a.txt", a.FileName)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+
+	_, _, err = s.OpenAttachment(asUser(5), 1, 4)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, _, err = s.OpenAttachment(asUser(5), 1, 0)
+	require.ErrorIs(t, err, ErrValidation)
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	s, repo, todos, _ := newService(t, Limits{MaxFileBytes: 1000})
+	todos.EXPECT().AuthorizeTodo(gomock.Any(), int64(1), model.ProjectRoleEditor).Return(nil).AnyTimes()
+	todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil).AnyTimes()
+	repo.EXPECT().GetAttachment(gomock.Any(), int64(1), int64(3)).Return(dto.Attachment{ID: 3, TodoID: 1, UploaderID: pointer.Pointer(int64(5))}, nil).AnyTimes()
+
+	t.Run("other users may not delete", func(t *testing.T) {
+		require.ErrorIs(t, s.DeleteAttachment(asUser(6), 1, 3), ErrForbidden)
+	})
+
+	t.Run("uploader", func(t *testing.T) {
+		repo.EXPECT().DeleteAttachment(gomock.Any(), int64(1), int64(3)).Return(nil)
+		require.NoError(t, s.DeleteAttachment(asUser(5), 1, 3))
+	})
+
+	t.Run("unrestricted key", func(t *testing.T) {
+		repo.EXPECT().DeleteAttachment(gomock.Any(), int64(1), int64(3)).Return(nil)
+		require.NoError(t, s.DeleteAttachment(context.Background(), 1, 3))
+	})
+}
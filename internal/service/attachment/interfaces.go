@@ -0,0 +1,38 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateAttachment(ctx context.Context, a *model.Attachment, r io.Reader) error
+		ListAttachments(ctx context.Context, todoID int64) ([]model.Attachment, error)
+		OpenAttachment(ctx context.Context, todoID, id int64) (model.Attachment, io.ReadCloser, error)
+		DeleteAttachment(ctx context.Context, todoID, id int64) error
+	}
+
+	Repository interface {
+		CreateAttachment(ctx context.Context, a *dto.Attachment) error
+		GetAttachment(ctx context.Context, todoID, id int64) (dto.Attachment, error)
+		ListAttachments(ctx context.Context, todoID int64) ([]dto.Attachment, error)
+		DeleteAttachment(ctx context.Context, todoID, id int64) error
+		UsedBytes(ctx context.Context, userID int64) (int64, error)
+	}
+
+	// Todos gives access to todos with the permissions of the caller.
+	Todos interface {
+		GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error)
+		AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) error
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrForbidden  = errs.ErrForbidden
+)
@@ -0,0 +1,206 @@
+package comment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/metrics"
+	"todo-list/internal/notifier"
+	"todo-list/internal/util/converter"
+)
+
+type CommentService struct {
+	CommentRepo Repository
+	Todos       Todos
+	Notifier    notifier.Notifier
+}
+
+func NewCommentService(cr Repository, todos Todos, n notifier.Notifier) *CommentService {
+	return &CommentService{
+		CommentRepo: cr,
+		Todos:       todos,
+		Notifier:    n,
+	}
+}
+
+func invalidID(field string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   field,
+		Code:    errs.ViolationInvalid,
+		Message: field + " must be positive",
+	})
+}
+
+func commentNotFound() error {
+	return errs.NotFound("comment not found")
+}
+
+// CreateComment adds a comment to a todo visible to the caller and notifies the users
+// mentioned in it. Everybody who may read a todo may discuss it.
+func (s *CommentService) CreateComment(ctx context.Context, comment *model.Comment) error {
+	if err := comment.Validate(); err != nil {
+		return err
+	}
+
+	todo, err := s.Todos.GetTodoByID(ctx, comment.TodoID)
+	if err != nil {
+		return err
+	}
+
+	comment.AuthorID = nil
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		comment.AuthorID = &p.UserID
+	}
+
+	commentDto := converter.ConvertCommentToDTO(*comment)
+	if err = s.CommentRepo.CreateComment(ctx, &commentDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	*comment = converter.ConvertCommentToModel(commentDto)
+
+	s.notify(ctx, todo, *comment, comment.Mentions())
+	return nil
+}
+
+// ListComments returns a page of the comments on the todo, oldest first.
+func (s *CommentService) ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) (model.CommentPagination, error) {
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return model.CommentPagination{}, err
+	}
+
+	comments, total, err := s.CommentRepo.ListComments(ctx, todoID, filter)
+	if err != nil {
+		return model.CommentPagination{}, err
+	}
+	return model.CommentPagination{
+		Item:       converter.ConvertCommentsToModels(comments),
+		TotalItems: total,
+	}, nil
+}
+
+// authorized returns the comment if the caller wrote it. Keys which are not restricted
+// to a user may change any comment.
+func (s *CommentService) authorized(ctx context.Context, todoID, id int64) (model.TodoItem, dto.Comment, error) {
+	if id <= 0 {
+		return model.TodoItem{}, dto.Comment{}, invalidID("comment_id")
+	}
+
+	todo, err := s.Todos.GetTodoByID(ctx, todoID)
+	if err != nil {
+		return model.TodoItem{}, dto.Comment{}, err
+	}
+
+	current, err := s.CommentRepo.GetComment(ctx, todoID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TodoItem{}, dto.Comment{}, commentNotFound()
+		}
+		return model.TodoItem{}, dto.Comment{}, err
+	}
+
+	userID, restricted := auth.Member(ctx)
+	if restricted && (current.AuthorID == nil || *current.AuthorID != userID) {
+		return model.TodoItem{}, dto.Comment{}, errs.Forbidden("only the author may change the comment")
+	}
+	return todo, current, nil
+}
+
+// UpdateComment changes the body of a comment of the caller, users mentioned for the
+// first time are notified.
+func (s *CommentService) UpdateComment(ctx context.Context, comment *model.Comment) error {
+	if err := comment.Validate(); err != nil {
+		return err
+	}
+
+	todo, current, err := s.authorized(ctx, comment.TodoID, comment.ID)
+	if err != nil {
+		return err
+	}
+	previous := converter.ConvertCommentToModel(current)
+
+	commentDto := converter.ConvertCommentToDTO(*comment)
+	if err = s.CommentRepo.UpdateComment(ctx, &commentDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return commentNotFound()
+		}
+		return err
+	}
+	*comment = converter.ConvertCommentToModel(commentDto)
+
+	s.notify(ctx, todo, *comment, added(previous.Mentions(), comment.Mentions()))
+	return nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, todoID, id int64) error {
+	if _, _, err := s.authorized(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	if err := s.CommentRepo.DeleteComment(ctx, todoID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return commentNotFound()
+		}
+		return err
+	}
+	return nil
+}
+
+// added returns the names of next missing in prev.
+func added(prev, next []string) []string {
+	seen := make(map[string]bool, len(prev))
+	for _, name := range prev {
+		seen[name] = true
+	}
+
+	res := make([]string, 0)
+	for _, name := range next {
+		if !seen[name] {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// notify tells the mentioned users who may read the todo about the comment, the author
+// is not notified. Failures are logged, they do not fail the comment.
+func (s *CommentService) notify(ctx context.Context, todo model.TodoItem, comment model.Comment, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+
+	users, err := s.CommentRepo.FindMentioned(ctx, usernames, todo.ProjectID)
+	if err != nil {
+		slog.WarnContext(ctx, "find mentioned users", slog.Int64("comment_id", comment.ID), slog.Any("error", err))
+		return
+	}
+
+	for _, u := range users {
+		if comment.AuthorID != nil && *comment.AuthorID == u.ID {
+			continue
+		}
+
+		err = s.Notifier.Notify(ctx, notifier.Notification{
+			Kind:        notifier.KindMention,
+			CommentID:   comment.ID,
+			TodoID:      todo.ID,
+			Title:       todo.Title,
+			Description: comment.Body,
+			Author:      comment.AuthorName,
+			Recipient:   u.Email,
+		})
+		outcome := "sent"
+		if err != nil {
+			outcome = "error"
+			slog.WarnContext(ctx, "send mention", slog.Int64("comment_id", comment.ID), slog.Int64("user_id", u.ID), slog.Any("error", err))
+		}
+		metrics.MentionsTotal.WithLabelValues(outcome).Inc()
+	}
+}
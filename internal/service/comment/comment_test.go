@@ -0,0 +1,137 @@
+package comment
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/notifier"
+	"todo-list/internal/util/pointer"
+	mock_comment "todo-list/pkg/mocks/service/comment"
+)
+
+func asUser(id int64) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+}
+
+type notifierFunc func(ctx context.Context, n notifier.Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, n notifier.Notification) error {
+	return f(ctx, n)
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_comment.NewMockRepository(ctrl)
+	todos := mock_comment.NewMockTodos(ctrl)
+	sent := make([]notifier.Notification, 0)
+	s := NewCommentService(repo, todos, notifierFunc(func(ctx context.Context, n notifier.Notification) error {
+		sent = append(sent, n)
+		return nil
+	}))
+	project := pointer.Pointer(int64(7))
+
+	t.Run("mentions are notified", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1, Title: "release", ProjectID: project}, nil)
+		repo.EXPECT().CreateComment(gomock.Any(), &dto.Comment{
+			TodoID:   1,
+			AuthorID: pointer.Pointer(int64(5)),
+			Body:     "@Ann and @bob, see bob@example.com",
+		}).DoAndReturn(func(ctx context.Context, c *dto.Comment) error {
+			c.ID, c.AuthorName = 3, pointer.Pointer("Bob")
+			return nil
+		})
+		repo.EXPECT().FindMentioned(gomock.Any(), []string{"ann", "bob"}, project).Return([]dto.User{
+			{ID: 4, Email: "ann@example.com"},
+			{ID: 5, Email: "bob@example.com"},
+		}, nil)
+
+		c := &model.Comment{TodoID: 1, Body: "@Ann and @bob, see bob@example.com"}
+		require.NoError(t, s.CreateComment(asUser(5), c))
+		require.Equal(t, int64(3), c.ID)
+		require.Len(t, sent, 1)
+		require.Equal(t, notifier.KindMention, sent[0].Kind)
+		require.Equal(t, "ann@example.com", sent[0].Recipient)
+		require.Equal(t, "Bob", sent[0].Author)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		err := s.CreateComment(asUser(5), &model.Comment{TodoID: 1})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(404)).Return(model.TodoItem{}, ErrNotFound)
+
+		err := s.CreateComment(asUser(5), &model.Comment{TodoID: 404, Body: "hi"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_comment.NewMockRepository(ctrl)
+	todos := mock_comment.NewMockTodos(ctrl)
+	sent := 0
+	s := NewCommentService(repo, todos, notifierFunc(func(ctx context.Context, n notifier.Notification) error {
+		sent++
+		return nil
+	}))
+
+	t.Run("only new mentions are notified", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetComment(gomock.Any(), int64(1), int64(3)).
+			Return(dto.Comment{ID: 3, TodoID: 1, AuthorID: pointer.Pointer(int64(5)), Body: "@ann"}, nil)
+		repo.EXPECT().UpdateComment(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().FindMentioned(gomock.Any(), []string{"carl"}, nil).Return([]dto.User{{ID: 6}}, nil)
+
+		require.NoError(t, s.UpdateComment(asUser(5), &model.Comment{ID: 3, TodoID: 1, Body: "@ann @carl"}))
+		require.Equal(t, 1, sent)
+	})
+
+	t.Run("comment of another user", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetComment(gomock.Any(), int64(1), int64(3)).
+			Return(dto.Comment{ID: 3, TodoID: 1, AuthorID: pointer.Pointer(int64(5))}, nil)
+
+		err := s.UpdateComment(asUser(6), &model.Comment{ID: 3, TodoID: 1, Body: "edited"})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_comment.NewMockRepository(ctrl)
+	todos := mock_comment.NewMockTodos(ctrl)
+	s := NewCommentService(repo, todos, notifier.NewLog())
+
+	t.Run("unknown comment", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetComment(gomock.Any(), int64(1), int64(404)).Return(dto.Comment{}, sql.ErrNoRows)
+
+		err := s.DeleteComment(asUser(5), 1, 404)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("key without a user", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetComment(gomock.Any(), int64(1), int64(3)).
+			Return(dto.Comment{ID: 3, TodoID: 1, AuthorID: pointer.Pointer(int64(5))}, nil)
+		repo.EXPECT().DeleteComment(gomock.Any(), int64(1), int64(3)).Return(nil)
+
+		require.NoError(t, s.DeleteComment(context.Background(), 1, 3))
+	})
+}
@@ -0,0 +1,37 @@
+package comment
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateComment(ctx context.Context, comment *model.Comment) error
+		ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) (model.CommentPagination, error)
+		UpdateComment(ctx context.Context, comment *model.Comment) error
+		DeleteComment(ctx context.Context, todoID, id int64) error
+	}
+
+	Repository interface {
+		CreateComment(ctx context.Context, comment *dto.Comment) error
+		GetComment(ctx context.Context, todoID, id int64) (dto.Comment, error)
+		UpdateComment(ctx context.Context, comment *dto.Comment) error
+		DeleteComment(ctx context.Context, todoID, id int64) error
+		ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) ([]dto.Comment, int64, error)
+		FindMentioned(ctx context.Context, usernames []string, projectID *int64) ([]dto.User, error)
+	}
+
+	// Todos gives access to todos with the permissions of the caller.
+	Todos interface {
+		GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error)
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrForbidden  = errs.ErrForbidden
+)
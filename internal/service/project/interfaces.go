@@ -0,0 +1,54 @@
+package project
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateProject(ctx context.Context, project *model.Project) error
+		GetProject(ctx context.Context, id int64) (model.Project, error)
+		ListProjects(ctx context.Context, filter dto.ProjectFilter) ([]model.Project, error)
+		UpdateProject(ctx context.Context, project *model.Project) error
+		DeleteProject(ctx context.Context, id int64) error
+
+		ListMembers(ctx context.Context, projectID int64) ([]model.ProjectMember, error)
+		UpdateMember(ctx context.Context, member model.ProjectMember) error
+		RemoveMember(ctx context.Context, projectID, userID int64) error
+
+		Invite(ctx context.Context, inv *model.Invitation) error
+		ListProjectInvitations(ctx context.Context, projectID int64) ([]model.Invitation, error)
+		ListMyInvitations(ctx context.Context) ([]model.Invitation, error)
+		AcceptInvitation(ctx context.Context, id int64) error
+		DeclineInvitation(ctx context.Context, id int64) error
+	}
+
+	Repository interface {
+		CreateProject(ctx context.Context, project *dto.Project, ownerID int64) error
+		GetProject(ctx context.Context, id, userID int64) (dto.Project, error)
+		ListProjects(ctx context.Context, filter dto.ProjectFilter) ([]dto.Project, error)
+		UpdateProject(ctx context.Context, project *dto.Project, updatedFields []string) error
+		DeleteProject(ctx context.Context, id int64) error
+
+		GetMemberRole(ctx context.Context, projectID, userID int64) (string, error)
+		ListMembers(ctx context.Context, projectID int64) ([]dto.ProjectMember, error)
+		CountOwners(ctx context.Context, projectID int64) (int64, error)
+		UpdateMemberRole(ctx context.Context, projectID, userID int64, role string) error
+		RemoveMember(ctx context.Context, projectID, userID int64) error
+
+		CreateInvitation(ctx context.Context, inv *dto.Invitation) error
+		GetInvitation(ctx context.Context, id int64) (dto.Invitation, error)
+		ListInvitations(ctx context.Context, filter dto.InvitationFilter) ([]dto.Invitation, error)
+		RespondInvitation(ctx context.Context, id int64, status string, userID int64) error
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrConflict   = errs.ErrConflict
+	ErrForbidden  = errs.ErrForbidden
+)
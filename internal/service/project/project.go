@@ -0,0 +1,349 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+type ProjectService struct {
+	ProjectRepo Repository
+}
+
+func NewProjectService(pr Repository) *ProjectService {
+	return &ProjectService{
+		ProjectRepo: pr,
+	}
+}
+
+func invalidID(field string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   field,
+		Code:    errs.ViolationInvalid,
+		Message: field + " must be positive",
+	})
+}
+
+func projectNotFound() error {
+	return errs.NotFound("project not found")
+}
+
+// authorize checks that the caller has at least the role in the project. Projects the
+// caller is not a member of are reported as not found to keep them private.
+func (s *ProjectService) authorize(ctx context.Context, projectID int64, role model.ProjectRole) error {
+	if projectID <= 0 {
+		return invalidID("id")
+	}
+
+	userID, restricted := auth.Member(ctx)
+	if !restricted {
+		_, err := s.ProjectRepo.GetProject(ctx, projectID, 0)
+		if errors.Is(err, sql.ErrNoRows) {
+			return projectNotFound()
+		}
+		return err
+	}
+
+	got, err := s.ProjectRepo.GetMemberRole(ctx, projectID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return projectNotFound()
+		}
+		return err
+	}
+
+	if !model.ProjectRole(got).Can(role) {
+		return errs.Forbidden("project role " + string(role) + " is required")
+	}
+	return nil
+}
+
+// CreateProject makes the calling user the owner of the project. Callers not bound
+// to a user have to name the owner explicitly.
+func (s *ProjectService) CreateProject(ctx context.Context, project *model.Project) error {
+	if err := project.Validate(); err != nil {
+		return err
+	}
+
+	ownerID := project.OwnerID
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		ownerID = p.UserID
+	}
+	if ownerID <= 0 {
+		return errs.Validation(errs.FieldViolation{
+			Field:   "owner_id",
+			Code:    errs.ViolationRequired,
+			Message: "owner_id must be set when the caller is not a user",
+		})
+	}
+
+	projectDto := converter.ConvertProjectToDTO(*project)
+	if err := s.ProjectRepo.CreateProject(ctx, &projectDto, ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.Validation(errs.FieldViolation{
+				Field:   "owner_id",
+				Code:    errs.ViolationInvalid,
+				Message: "user not found",
+			})
+		}
+		return err
+	}
+
+	*project = converter.ConvertProjectToModel(projectDto)
+	project.OwnerID = ownerID
+	return nil
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, id int64) (model.Project, error) {
+	if id <= 0 {
+		return model.Project{}, invalidID("id")
+	}
+
+	userID, _ := auth.Member(ctx)
+	p, err := s.ProjectRepo.GetProject(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Project{}, projectNotFound()
+		}
+		return model.Project{}, err
+	}
+
+	return converter.ConvertProjectToModel(p), nil
+}
+
+// ListProjects returns projects the caller is a member of, unrestricted callers see all projects.
+func (s *ProjectService) ListProjects(ctx context.Context, filter dto.ProjectFilter) ([]model.Project, error) {
+	filter.MemberID, _ = auth.Member(ctx)
+	projects, err := s.ProjectRepo.ListProjects(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertProjectsToModels(projects), nil
+}
+
+// UpdateProject changes the fields set in project and returns the stored project.
+func (s *ProjectService) UpdateProject(ctx context.Context, project *model.Project) error {
+	if err := project.ValidateUpdate(); err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, project.ID, model.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	projectDto := converter.ConvertProjectToDTO(*project)
+	if err := s.ProjectRepo.UpdateProject(ctx, &projectDto, project.EditableFields()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return projectNotFound()
+		}
+		return err
+	}
+
+	res, err := s.GetProject(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	*project = res
+	return nil
+}
+
+func (s *ProjectService) DeleteProject(ctx context.Context, id int64) error {
+	if err := s.authorize(ctx, id, model.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	err := s.ProjectRepo.DeleteProject(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return projectNotFound()
+	}
+	return err
+}
+
+func (s *ProjectService) ListMembers(ctx context.Context, projectID int64) ([]model.ProjectMember, error) {
+	if err := s.authorize(ctx, projectID, model.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := s.ProjectRepo.ListMembers(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertProjectMembersToModels(members), nil
+}
+
+// keepOwner refuses changes taking away the last owner of the project.
+func (s *ProjectService) keepOwner(ctx context.Context, projectID, userID int64) error {
+	role, err := s.ProjectRepo.GetMemberRole(ctx, projectID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound("member not found")
+		}
+		return err
+	}
+	if model.ProjectRole(role) != model.ProjectRoleOwner {
+		return nil
+	}
+
+	owners, err := s.ProjectRepo.CountOwners(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errs.Conflict("project must keep at least one owner")
+	}
+	return nil
+}
+
+func (s *ProjectService) UpdateMember(ctx context.Context, member model.ProjectMember) error {
+	if member.UserID <= 0 {
+		return invalidID("user_id")
+	}
+	if err := member.Validate(); err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, member.ProjectID, model.ProjectRoleOwner); err != nil {
+		return err
+	}
+	if member.Role != model.ProjectRoleOwner {
+		if err := s.keepOwner(ctx, member.ProjectID, member.UserID); err != nil {
+			return err
+		}
+	}
+
+	err := s.ProjectRepo.UpdateMemberRole(ctx, member.ProjectID, member.UserID, string(member.Role))
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFound("member not found")
+	}
+	return err
+}
+
+// RemoveMember removes a member from the project. Owners remove anyone, other members
+// can only leave the project themselves.
+func (s *ProjectService) RemoveMember(ctx context.Context, projectID, userID int64) error {
+	if userID <= 0 {
+		return invalidID("user_id")
+	}
+
+	role := model.ProjectRoleOwner
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID == userID {
+		role = model.ProjectRoleViewer
+	}
+	if err := s.authorize(ctx, projectID, role); err != nil {
+		return err
+	}
+	if err := s.keepOwner(ctx, projectID, userID); err != nil {
+		return err
+	}
+
+	err := s.ProjectRepo.RemoveMember(ctx, projectID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFound("member not found")
+	}
+	return err
+}
+
+// Invite creates a pending invitation of the email address to the project. The invitation
+// is answered by the user having this address.
+func (s *ProjectService) Invite(ctx context.Context, inv *model.Invitation) error {
+	inv.Email = model.NormalizeEmail(inv.Email)
+	if err := inv.Validate(); err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, inv.ProjectID, model.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	inv.InvitedBy = nil
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		inv.InvitedBy = &p.UserID
+	}
+
+	invDto := converter.ConvertInvitationToDTO(*inv)
+	if err := s.ProjectRepo.CreateInvitation(ctx, &invDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.Conflict("pending invitation for " + inv.Email + " already exists")
+		}
+		return err
+	}
+
+	*inv = converter.ConvertInvitationToModel(invDto)
+	return nil
+}
+
+func (s *ProjectService) ListProjectInvitations(ctx context.Context, projectID int64) ([]model.Invitation, error) {
+	if err := s.authorize(ctx, projectID, model.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	invs, err := s.ProjectRepo.ListInvitations(ctx, dto.InvitationFilter{ProjectID: projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertInvitationsToModels(invs), nil
+}
+
+// ListMyInvitations returns pending invitations addressed to the email of the calling user.
+func (s *ProjectService) ListMyInvitations(ctx context.Context) ([]model.Invitation, error) {
+	p, _ := auth.PrincipalFromContext(ctx)
+	if p.Email == "" {
+		return []model.Invitation{}, nil
+	}
+
+	invs, err := s.ProjectRepo.ListInvitations(ctx, dto.InvitationFilter{
+		Email:  model.NormalizeEmail(p.Email),
+		Status: string(model.InvitationPending),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertInvitationsToModels(invs), nil
+}
+
+func (s *ProjectService) AcceptInvitation(ctx context.Context, id int64) error {
+	return s.respond(ctx, id, model.InvitationAccepted)
+}
+
+func (s *ProjectService) DeclineInvitation(ctx context.Context, id int64) error {
+	return s.respond(ctx, id, model.InvitationDeclined)
+}
+
+func (s *ProjectService) respond(ctx context.Context, id int64, status model.InvitationStatus) error {
+	if id <= 0 {
+		return invalidID("id")
+	}
+
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || p.UserID == 0 {
+		return errs.Forbidden("invitations can only be answered by users")
+	}
+
+	inv, err := s.ProjectRepo.GetInvitation(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound("invitation not found")
+		}
+		return err
+	}
+	// invitations of other addresses are not disclosed
+	if inv.Email != model.NormalizeEmail(p.Email) {
+		return errs.NotFound("invitation not found")
+	}
+	if inv.Status != string(model.InvitationPending) {
+		return errs.Conflict("invitation is already " + inv.Status)
+	}
+
+	err = s.ProjectRepo.RespondInvitation(ctx, id, string(status), p.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.Conflict("invitation is already answered")
+	}
+	return err
+}
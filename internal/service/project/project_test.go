@@ -0,0 +1,182 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+	mock_project "todo-list/pkg/mocks/service/project"
+)
+
+func asUser(id int64, email string) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Email:  email,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+}
+
+func TestProjectService_CreateProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_project.NewMockRepository(ctrl)
+	s := NewProjectService(repo)
+
+	t.Run("caller becomes owner", func(t *testing.T) {
+		repo.EXPECT().CreateProject(gomock.Any(), &dto.Project{Name: "team"}, int64(5)).
+			DoAndReturn(func(ctx context.Context, p *dto.Project, ownerID int64) error {
+				p.ID = 1
+				p.Role = pointer.Pointer("owner")
+				return nil
+			})
+
+		p := &model.Project{Name: "team", OwnerID: 9}
+		require.NoError(t, s.CreateProject(asUser(5, "ann@example.com"), p))
+		require.Equal(t, int64(1), p.ID)
+		require.Equal(t, int64(5), p.OwnerID)
+		require.Equal(t, model.ProjectRoleOwner, p.Role)
+	})
+
+	t.Run("owner required for callers without user", func(t *testing.T) {
+		err := s.CreateProject(context.Background(), &model.Project{Name: "team"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown owner", func(t *testing.T) {
+		repo.EXPECT().CreateProject(gomock.Any(), gomock.Any(), int64(9)).Return(sql.ErrNoRows)
+
+		err := s.CreateProject(context.Background(), &model.Project{Name: "team", OwnerID: 9})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestProjectService_UpdateProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_project.NewMockRepository(ctrl)
+	s := NewProjectService(repo)
+	ctx := asUser(5, "ann@example.com")
+
+	t.Run("archive project", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("owner", nil)
+		repo.EXPECT().UpdateProject(gomock.Any(), &dto.Project{ID: 1, Archived: true}, []string{model.ProjectArchivedField}).Return(nil)
+		repo.EXPECT().GetProject(gomock.Any(), int64(1), int64(5)).Return(dto.Project{ID: 1, Name: "team", Archived: true, PendingTodos: 2}, nil)
+
+		p := &model.Project{ID: 1, Archived: pointer.Pointer(true)}
+		require.NoError(t, s.UpdateProject(ctx, p))
+		require.Equal(t, "team", p.Name)
+		require.Equal(t, int64(2), p.PendingTodos)
+	})
+
+	t.Run("invalid color", func(t *testing.T) {
+		err := s.UpdateProject(ctx, &model.Project{ID: 1, Color: "red"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("editor can not archive", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("editor", nil)
+
+		err := s.UpdateProject(ctx, &model.Project{ID: 1, Archived: pointer.Pointer(true)})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestProjectService_Members(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_project.NewMockRepository(ctrl)
+	s := NewProjectService(repo)
+	ctx := asUser(5, "ann@example.com")
+
+	t.Run("non-member gets not found", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("", sql.ErrNoRows)
+
+		_, err := s.ListMembers(ctx, 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("editor can not change roles", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("editor", nil)
+
+		err := s.UpdateMember(ctx, model.ProjectMember{ProjectID: 1, UserID: 6, Role: model.ProjectRoleViewer})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("last owner can not be demoted", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("owner", nil).Times(2)
+		repo.EXPECT().CountOwners(gomock.Any(), int64(1)).Return(int64(1), nil)
+
+		err := s.UpdateMember(ctx, model.ProjectMember{ProjectID: 1, UserID: 5, Role: model.ProjectRoleEditor})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("viewer leaves project", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("viewer", nil).Times(2)
+		repo.EXPECT().RemoveMember(gomock.Any(), int64(1), int64(5)).Return(nil)
+
+		require.NoError(t, s.RemoveMember(ctx, 1, 5))
+	})
+}
+
+func TestProjectService_Invitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_project.NewMockRepository(ctrl)
+	s := NewProjectService(repo)
+	pending := dto.Invitation{ID: 3, ProjectID: 1, Email: "bob@example.com", Role: "editor", Status: "pending"}
+
+	t.Run("owner invites by email", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(1), int64(5)).Return("owner", nil)
+		repo.EXPECT().CreateInvitation(gomock.Any(), &dto.Invitation{
+			ProjectID: 1,
+			Email:     "bob@example.com",
+			Role:      "editor",
+			InvitedBy: pointer.Pointer(int64(5)),
+		}).Return(nil)
+
+		inv := &model.Invitation{ProjectID: 1, Email: " Bob@Example.com", Role: model.ProjectRoleEditor}
+		require.NoError(t, s.Invite(asUser(5, "ann@example.com"), inv))
+	})
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		err := s.Invite(asUser(5, "ann@example.com"), &model.Invitation{ProjectID: 1, Email: "bob", Role: "boss"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("invitation of another address", func(t *testing.T) {
+		repo.EXPECT().GetInvitation(gomock.Any(), int64(3)).Return(pending, nil)
+
+		err := s.AcceptInvitation(asUser(6, "eve@example.com"), 3)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("accept invitation", func(t *testing.T) {
+		repo.EXPECT().GetInvitation(gomock.Any(), int64(3)).Return(pending, nil)
+		repo.EXPECT().RespondInvitation(gomock.Any(), int64(3), "accepted", int64(7)).Return(nil)
+
+		require.NoError(t, s.AcceptInvitation(asUser(7, "Bob@example.com"), 3))
+	})
+
+	t.Run("already declined", func(t *testing.T) {
+		declined := pending
+		declined.Status = "declined"
+		repo.EXPECT().GetInvitation(gomock.Any(), int64(3)).Return(declined, nil)
+
+		err := s.AcceptInvitation(asUser(7, "bob@example.com"), 3)
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("only users answer invitations", func(t *testing.T) {
+		err := s.DeclineInvitation(context.Background(), 3)
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
@@ -0,0 +1,33 @@
+package reminder
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateReminder(ctx context.Context, reminder *model.Reminder) error
+		ListReminders(ctx context.Context, todoID int64) ([]model.Reminder, error)
+		DeleteReminder(ctx context.Context, todoID, id int64) error
+		Notify(ctx context.Context, due dto.DueReminder) error
+	}
+
+	Repository interface {
+		CreateReminder(ctx context.Context, reminder *dto.Reminder) error
+		ListReminders(ctx context.Context, todoID, userID int64) ([]dto.Reminder, error)
+		DeleteReminder(ctx context.Context, todoID, id, userID int64) error
+	}
+
+	// Todos gives access to todos with the permissions of the caller.
+	Todos interface {
+		GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error)
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+)
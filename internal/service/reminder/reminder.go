@@ -0,0 +1,152 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/metrics"
+	"todo-list/internal/notifier"
+	"todo-list/internal/timezone"
+	"todo-list/internal/util/converter"
+)
+
+type ReminderService struct {
+	ReminderRepo Repository
+	Todos        Todos
+	Notifier     notifier.Notifier
+}
+
+func NewReminderService(rr Repository, todos Todos, n notifier.Notifier) *ReminderService {
+	return &ReminderService{
+		ReminderRepo: rr,
+		Todos:        todos,
+		Notifier:     n,
+	}
+}
+
+func invalidID(field string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   field,
+		Code:    errs.ViolationInvalid,
+		Message: field + " must be positive",
+	})
+}
+
+// CreateReminder sets a reminder on a todo visible to the caller. Reminders belong to the
+// user who set them and are sent to that user only.
+func (s *ReminderService) CreateReminder(ctx context.Context, reminder *model.Reminder) error {
+	if err := reminder.Validate(); err != nil {
+		return err
+	}
+
+	todo, err := s.Todos.GetTodoByID(ctx, reminder.TodoID)
+	if err != nil {
+		return err
+	}
+	if reminder.OffsetSeconds != nil && todo.Date == nil {
+		return errs.Validation(errs.FieldViolation{
+			Field:   "offset_seconds",
+			Code:    errs.ViolationInvalid,
+			Message: "todo has no date to remind relative to",
+		})
+	}
+
+	reminder.UserID = nil
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		reminder.UserID = &p.UserID
+	}
+
+	reminderDto := converter.ConvertReminderToDTO(*reminder)
+	if err = s.ReminderRepo.CreateReminder(ctx, &reminderDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	*reminder = converter.ConvertReminderToModel(reminderDto)
+	reminder.DueAt = reminder.RemindAt
+	if reminder.OffsetSeconds != nil {
+		due := dueTime(todo).Add(time.Duration(*reminder.OffsetSeconds) * time.Second)
+		reminder.DueAt = &due
+	}
+	return nil
+}
+
+// dueTime is the due time of the todo, all-day todos are due at the start of their date
+// in the time zone of the todo.
+func dueTime(todo model.TodoItem) time.Time {
+	if todo.DueAt != nil {
+		return *todo.DueAt
+	}
+
+	loc, err := timezone.Load(todo.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	y, m, d := todo.Date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// ListReminders returns the reminders of the caller set on the todo.
+func (s *ReminderService) ListReminders(ctx context.Context, todoID int64) ([]model.Reminder, error) {
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	userID, _ := auth.Member(ctx)
+	reminders, err := s.ReminderRepo.ListReminders(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertRemindersToModels(reminders), nil
+}
+
+func (s *ReminderService) DeleteReminder(ctx context.Context, todoID, id int64) error {
+	if id <= 0 {
+		return invalidID("reminder_id")
+	}
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return err
+	}
+
+	userID, _ := auth.Member(ctx)
+	if err := s.ReminderRepo.DeleteReminder(ctx, todoID, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound("reminder not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Notify sends a due reminder, it is called by the scheduler.
+func (s *ReminderService) Notify(ctx context.Context, due dto.DueReminder) error {
+	n := notifier.Notification{
+		Kind:        notifier.KindReminder,
+		ReminderID:  due.ID,
+		TodoID:      due.TodoID,
+		Title:       due.Title,
+		Description: due.Description,
+		DueAt:       &due.DueAt,
+	}
+	if due.Email != nil {
+		n.Recipient = *due.Email
+	}
+
+	err := s.Notifier.Notify(ctx, n)
+	outcome := "sent"
+	if err != nil {
+		outcome = "error"
+	}
+	metrics.RemindersTotal.WithLabelValues(outcome).Inc()
+
+	return err
+}
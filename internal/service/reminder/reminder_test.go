@@ -0,0 +1,123 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/notifier"
+	"todo-list/internal/util/pointer"
+	mock_reminder "todo-list/pkg/mocks/service/reminder"
+)
+
+func asUser(id int64) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Scopes: []model.Scope{model.ScopeRead},
+	})
+}
+
+type notifierFunc func(ctx context.Context, n notifier.Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, n notifier.Notification) error {
+	return f(ctx, n)
+}
+
+func TestReminderService_CreateReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_reminder.NewMockRepository(ctrl)
+	todos := mock_reminder.NewMockTodos(ctrl)
+	s := NewReminderService(repo, todos, notifier.NewLog())
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("offset from the todo date", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1, Date: &date}, nil)
+		repo.EXPECT().CreateReminder(gomock.Any(), &dto.Reminder{
+			TodoID:        1,
+			UserID:        pointer.Pointer(int64(5)),
+			OffsetSeconds: pointer.Pointer(int64(-3600)),
+		}).DoAndReturn(func(ctx context.Context, r *dto.Reminder) error {
+			r.ID = 3
+			return nil
+		})
+
+		r := &model.Reminder{TodoID: 1, OffsetSeconds: pointer.Pointer(int64(-3600))}
+		require.NoError(t, s.CreateReminder(asUser(5), r))
+		require.Equal(t, int64(3), r.ID)
+		require.Equal(t, date.Add(-time.Hour), *r.DueAt)
+	})
+
+	t.Run("offset without todo date", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+
+		err := s.CreateReminder(asUser(5), &model.Reminder{TodoID: 1, OffsetSeconds: pointer.Pointer(int64(0))})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("both time and offset", func(t *testing.T) {
+		err := s.CreateReminder(asUser(5), &model.Reminder{
+			TodoID:        1,
+			RemindAt:      &date,
+			OffsetSeconds: pointer.Pointer(int64(0)),
+		})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("todo not visible", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(2)).Return(model.TodoItem{}, ErrNotFound)
+
+		err := s.CreateReminder(asUser(5), &model.Reminder{TodoID: 2, RemindAt: &date})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestReminderService_DeleteReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_reminder.NewMockRepository(ctrl)
+	todos := mock_reminder.NewMockTodos(ctrl)
+	s := NewReminderService(repo, todos, notifier.NewLog())
+
+	t.Run("reminder of another user", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().DeleteReminder(gomock.Any(), int64(1), int64(3), int64(5)).Return(sql.ErrNoRows)
+
+		require.ErrorIs(t, s.DeleteReminder(asUser(5), 1, 3), ErrNotFound)
+	})
+}
+
+func TestReminderService_Notify(t *testing.T) {
+	var got notifier.Notification
+	s := NewReminderService(nil, nil, notifierFunc(func(ctx context.Context, n notifier.Notification) error {
+		got = n
+		return nil
+	}))
+
+	due := dto.DueReminder{ID: 3, TodoID: 1, Title: "call", Email: pointer.Pointer("ann@example.com")}
+	require.NoError(t, s.Notify(context.Background(), due))
+	require.Equal(t, "ann@example.com", got.Recipient)
+
+	s.Notifier = notifierFunc(func(context.Context, notifier.Notification) error {
+		return errors.New("down")
+	})
+	require.Error(t, s.Notify(context.Background(), due))
+}
+
+func TestDueTime(t *testing.T) {
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	due := dueTime(model.TodoItem{Date: &date, TimeZone: "Europe/Moscow"})
+	require.Equal(t, time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC), due.UTC())
+
+	at := time.Date(2026, 10, 20, 15, 30, 0, 0, time.UTC)
+	require.Equal(t, at, dueTime(model.TodoItem{Date: &date, DueAt: &at, TimeZone: "Europe/Moscow"}))
+}
@@ -0,0 +1,41 @@
+package savedfilter
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		ListFilters(ctx context.Context) ([]model.SavedFilter, error)
+		CreateFilter(ctx context.Context, filter *model.SavedFilter) error
+		GetFilter(ctx context.Context, id int64) (model.SavedFilter, error)
+		UpdateFilter(ctx context.Context, filter *model.SavedFilter) error
+		DeleteFilter(ctx context.Context, id int64) error
+		RunFilter(ctx context.Context, id int64, page dto.TodoPage) (model.TodoPagination, error)
+		ListSmartLists(ctx context.Context) []model.SmartList
+		RunSmartList(ctx context.Context, key string, page dto.TodoPage) (model.TodoPagination, error)
+	}
+
+	Repository interface {
+		CreateFilter(ctx context.Context, filter *dto.SavedFilter) error
+		GetFilter(ctx context.Context, userID, id int64) (dto.SavedFilter, error)
+		ListFilters(ctx context.Context, userID int64) ([]dto.SavedFilter, error)
+		UpdateFilter(ctx context.Context, filter *dto.SavedFilter) error
+		DeleteFilter(ctx context.Context, userID, id int64) error
+	}
+
+	// Todos lists todos with the permissions of the caller.
+	Todos interface {
+		ListTodos(ctx context.Context, filter dto.TodoFilter) (model.TodoPagination, error)
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrForbidden  = errs.ErrForbidden
+	ErrConflict   = errs.ErrConflict
+)
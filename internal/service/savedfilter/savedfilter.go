@@ -0,0 +1,192 @@
+package savedfilter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+type SavedFilterService struct {
+	SavedFilterRepo Repository
+	Todos           Todos
+}
+
+func NewSavedFilterService(r Repository, todos Todos) *SavedFilterService {
+	return &SavedFilterService{
+		SavedFilterRepo: r,
+		Todos:           todos,
+	}
+}
+
+func invalidID() error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   "id",
+		Code:    errs.ViolationInvalid,
+		Message: "id must be positive",
+	})
+}
+
+func filterNotFound() error {
+	return errs.NotFound("saved filter not found")
+}
+
+func nameTaken() error {
+	return errs.Conflict("a saved filter with the name already exists")
+}
+
+// owner returns the user the filters of the caller belong to.
+func owner(ctx context.Context) (int64, error) {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		return p.UserID, nil
+	}
+	return 0, errs.Forbidden("filters are saved per user, use a key of a user")
+}
+
+// ListFilters returns the filters of the caller by name.
+func (s *SavedFilterService) ListFilters(ctx context.Context) ([]model.SavedFilter, error) {
+	userID, err := owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := s.SavedFilterRepo.ListFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ConvertSavedFiltersToModels(filters)
+}
+
+// CreateFilter saves a filter of the caller, names are unique per user.
+func (s *SavedFilterService) CreateFilter(ctx context.Context, filter *model.SavedFilter) error {
+	userID, err := owner(ctx)
+	if err != nil {
+		return err
+	}
+	if err = filter.Validate(); err != nil {
+		return err
+	}
+
+	filterDto, err := converter.ConvertSavedFilterToDTO(*filter, userID)
+	if err != nil {
+		return err
+	}
+	if err = s.SavedFilterRepo.CreateFilter(ctx, &filterDto); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nameTaken()
+		}
+		return err
+	}
+
+	filter.ID, filter.CreatedAt = filterDto.ID, filterDto.CreatedAt
+	return nil
+}
+
+func (s *SavedFilterService) GetFilter(ctx context.Context, id int64) (model.SavedFilter, error) {
+	if id <= 0 {
+		return model.SavedFilter{}, invalidID()
+	}
+	userID, err := owner(ctx)
+	if err != nil {
+		return model.SavedFilter{}, err
+	}
+
+	filter, err := s.SavedFilterRepo.GetFilter(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.SavedFilter{}, filterNotFound()
+		}
+		return model.SavedFilter{}, err
+	}
+	return converter.ConvertSavedFilterToModel(filter)
+}
+
+// UpdateFilter replaces the name and the query of a filter of the caller.
+func (s *SavedFilterService) UpdateFilter(ctx context.Context, filter *model.SavedFilter) error {
+	if filter.ID <= 0 {
+		return invalidID()
+	}
+	userID, err := owner(ctx)
+	if err != nil {
+		return err
+	}
+	if err = filter.Validate(); err != nil {
+		return err
+	}
+
+	filterDto, err := converter.ConvertSavedFilterToDTO(*filter, userID)
+	if err != nil {
+		return err
+	}
+	if err = s.SavedFilterRepo.UpdateFilter(ctx, &filterDto); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return filterNotFound()
+		case errors.Is(err, errs.ErrConflict):
+			return nameTaken()
+		}
+		return err
+	}
+
+	res, err := converter.ConvertSavedFilterToModel(filterDto)
+	if err != nil {
+		return err
+	}
+	*filter = res
+	return nil
+}
+
+func (s *SavedFilterService) DeleteFilter(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return invalidID()
+	}
+	userID, err := owner(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = s.SavedFilterRepo.DeleteFilter(ctx, userID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return filterNotFound()
+		}
+		return err
+	}
+	return nil
+}
+
+// RunFilter returns a page of the todos visible to the caller matching a filter of the caller.
+func (s *SavedFilterService) RunFilter(ctx context.Context, id int64, page dto.TodoPage) (model.TodoPagination, error) {
+	filter, err := s.GetFilter(ctx, id)
+	if err != nil {
+		return model.TodoPagination{}, err
+	}
+	return s.run(ctx, filter.Filter, page)
+}
+
+// ListSmartLists returns the built-in lists.
+func (s *SavedFilterService) ListSmartLists(_ context.Context) []model.SmartList {
+	return model.SmartLists
+}
+
+// RunSmartList returns a page of the todos visible to the caller in a built-in list.
+func (s *SavedFilterService) RunSmartList(ctx context.Context, key string, page dto.TodoPage) (model.TodoPagination, error) {
+	list, ok := model.FindSmartList(key)
+	if !ok {
+		return model.TodoPagination{}, errs.NotFound("smart list not found")
+	}
+	return s.run(ctx, list.Filter, page)
+}
+
+// run lists the todos of the query. Unlike the todo list, a saved query matching no todos
+// is an empty page rather than an error, as the query itself exists.
+func (s *SavedFilterService) run(ctx context.Context, query model.TodoQuery, page dto.TodoPage) (model.TodoPagination, error) {
+	res, err := s.Todos.ListTodos(ctx, converter.ConvertTodoQueryToFilter(query, page))
+	if errors.Is(err, errs.ErrNotFound) {
+		return model.TodoPagination{}, nil
+	}
+	return res, err
+}
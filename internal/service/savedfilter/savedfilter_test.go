@@ -0,0 +1,118 @@
+package savedfilter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+	mock_savedfilter "todo-list/pkg/mocks/service/savedfilter"
+)
+
+func asUser(id int64) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+}
+
+func TestSavedFilterService_CreateFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_savedfilter.NewMockRepository(ctrl)
+	s := NewSavedFilterService(repo, mock_savedfilter.NewMockTodos(ctrl))
+
+	t.Run("saved", func(t *testing.T) {
+		repo.EXPECT().CreateFilter(gomock.Any(), &dto.SavedFilter{
+			UserID: 5,
+			Name:   "work",
+			Filter: []byte(`{"project_id":3,"open":true,"due_within_days":7}`),
+		}).DoAndReturn(func(ctx context.Context, f *dto.SavedFilter) error {
+			f.ID = 2
+			return nil
+		})
+
+		f := &model.SavedFilter{Name: " work ", Filter: model.TodoQuery{ProjectID: pointer.Pointer(int64(3)), Open: true, DueWithinDays: 7}}
+		require.NoError(t, s.CreateFilter(asUser(5), f))
+		require.Equal(t, int64(2), f.ID)
+		require.Equal(t, "work", f.Name)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		err := s.CreateFilter(asUser(5), &model.SavedFilter{Name: "x", Filter: model.TodoQuery{Status: "done", DueWithinDays: 1000}})
+		var e *errs.Error
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Violations, 2)
+		require.Equal(t, "filter.status", e.Violations[0].Field)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		repo.EXPECT().CreateFilter(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: saved_filters_name_key", errs.ErrConflict))
+
+		err := s.CreateFilter(asUser(5), &model.SavedFilter{Name: "work"})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("key without user", func(t *testing.T) {
+		err := s.CreateFilter(context.Background(), &model.SavedFilter{Name: "work"})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestSavedFilterService_RunFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_savedfilter.NewMockRepository(ctrl)
+	todos := mock_savedfilter.NewMockTodos(ctrl)
+	s := NewSavedFilterService(repo, todos)
+
+	t.Run("paged", func(t *testing.T) {
+		repo.EXPECT().GetFilter(gomock.Any(), int64(5), int64(2)).Return(dto.SavedFilter{ID: 2, Name: "work", Filter: []byte(`{"status":"pending","sort":"date"}`)}, nil)
+		todos.EXPECT().ListTodos(gomock.Any(), dto.TodoFilter{Status: "pending", Sort: "date", Page: 2, Limit: 10}).
+			Return(model.TodoPagination{Item: []model.TodoItem{{ID: 1}}, TotalItems: 11}, nil)
+
+		res, err := s.RunFilter(asUser(5), 2, dto.TodoPage{Page: 2, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, int64(11), res.TotalItems)
+	})
+
+	t.Run("no todos", func(t *testing.T) {
+		repo.EXPECT().GetFilter(gomock.Any(), int64(5), int64(2)).Return(dto.SavedFilter{ID: 2, Filter: []byte(`{}`)}, nil)
+		todos.EXPECT().ListTodos(gomock.Any(), gomock.Any()).Return(model.TodoPagination{}, ErrNotFound)
+
+		res, err := s.RunFilter(asUser(5), 2, dto.TodoPage{})
+		require.NoError(t, err)
+		require.Empty(t, res.Item)
+	})
+
+	t.Run("filter of another user", func(t *testing.T) {
+		repo.EXPECT().GetFilter(gomock.Any(), int64(6), int64(2)).Return(dto.SavedFilter{}, sql.ErrNoRows)
+
+		_, err := s.RunFilter(asUser(6), 2, dto.TodoPage{})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSavedFilterService_RunSmartList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	todos := mock_savedfilter.NewMockTodos(ctrl)
+	s := NewSavedFilterService(mock_savedfilter.NewMockRepository(ctrl), todos)
+
+	todos.EXPECT().ListTodos(gomock.Any(), dto.TodoFilter{Overdue: true, Sort: "date"}).Return(model.TodoPagination{TotalItems: 1}, nil)
+	res, err := s.RunSmartList(context.Background(), "overdue", dto.TodoPage{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.TotalItems)
+
+	_, err = s.RunSmartList(context.Background(), "someday", dto.TodoPage{})
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,45 @@
+package timeentry
+
+import (
+	"context"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		StartTimer(ctx context.Context, todoID int64, note string) (model.TimeEntry, error)
+		StopTimer(ctx context.Context, todoID int64) (model.TimeEntry, error)
+		ListEntries(ctx context.Context, todoID int64) ([]model.TimeEntry, error)
+		CreateEntry(ctx context.Context, entry *model.TimeEntry) error
+		UpdateEntry(ctx context.Context, todoID, id int64, patch model.TimeEntryPatch) (model.TimeEntry, error)
+		DeleteEntry(ctx context.Context, todoID, id int64) error
+		Report(ctx context.Context, filter dto.TimeReportFilter) (model.TimeReport, error)
+	}
+
+	Repository interface {
+		CreateEntry(ctx context.Context, entry *dto.TimeEntry) error
+		GetEntry(ctx context.Context, todoID, id int64) (dto.TimeEntry, error)
+		RunningEntry(ctx context.Context, userID *int64) (dto.TimeEntry, error)
+		StopTimer(ctx context.Context, todoID int64, userID *int64) (dto.TimeEntry, error)
+		UpdateEntry(ctx context.Context, entry *dto.TimeEntry) error
+		DeleteEntry(ctx context.Context, todoID, id int64) error
+		ListEntries(ctx context.Context, todoID int64) ([]dto.TimeEntry, error)
+		Overlapping(ctx context.Context, userID int64, start, end time.Time, excludeID int64) (dto.TimeEntry, error)
+		Report(ctx context.Context, filter dto.TimeReportFilter) ([]dto.TimeReportRow, error)
+	}
+
+	// Todos gives access to todos with the permissions of the caller.
+	Todos interface {
+		GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error)
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrForbidden  = errs.ErrForbidden
+	ErrConflict   = errs.ErrConflict
+)
@@ -0,0 +1,305 @@
+package timeentry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
+	"todo-list/internal/util/converter"
+)
+
+const (
+	// DefaultReportDays is the length of a report without a period.
+	DefaultReportDays = 30
+	// MaxReportDays limits the period of a report.
+	MaxReportDays = 366
+)
+
+type TimeEntryService struct {
+	TimeEntryRepo Repository
+	Todos         Todos
+}
+
+func NewTimeEntryService(r Repository, todos Todos) *TimeEntryService {
+	return &TimeEntryService{
+		TimeEntryRepo: r,
+		Todos:         todos,
+	}
+}
+
+func invalidID(field string) error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   field,
+		Code:    errs.ViolationInvalid,
+		Message: field + " must be positive",
+	})
+}
+
+func entryNotFound() error {
+	return errs.NotFound("time entry not found")
+}
+
+// caller returns the user of the key, nil for keys without a user.
+func caller(ctx context.Context) *int64 {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		return &p.UserID
+	}
+	return nil
+}
+
+// StartTimer starts a timer of the caller on a todo visible to the caller. A user runs
+// one timer at a time, starting a second one is a conflict.
+func (s *TimeEntryService) StartTimer(ctx context.Context, todoID int64, note string) (model.TimeEntry, error) {
+	userID := caller(ctx)
+	if userID == nil {
+		return model.TimeEntry{}, errs.Forbidden("timers are tracked per user, use a key of a user")
+	}
+
+	entry := model.TimeEntry{TodoID: todoID, UserID: userID, StartedAt: time.Now(), Note: note}
+	if err := entry.Validate(entry.StartedAt); err != nil {
+		return model.TimeEntry{}, err
+	}
+
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return model.TimeEntry{}, err
+	}
+
+	running, err := s.TimeEntryRepo.RunningEntry(ctx, userID)
+	switch {
+	case err == nil:
+		return model.TimeEntry{}, errs.Conflict(fmt.Sprintf("a timer is already running on todo %d", running.TodoID))
+	case !errors.Is(err, sql.ErrNoRows):
+		return model.TimeEntry{}, err
+	}
+
+	entryDto := converter.ConvertTimeEntryToDTO(entry)
+	if err = s.TimeEntryRepo.CreateEntry(ctx, &entryDto); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return model.TimeEntry{}, ErrNotFound
+		case errors.Is(err, errs.ErrConflict):
+			// another request started a timer since the check
+			return model.TimeEntry{}, errs.Conflict("a timer is already running")
+		}
+		return model.TimeEntry{}, err
+	}
+	return converter.ConvertTimeEntryToModel(entryDto), nil
+}
+
+// StopTimer stops the timer of the caller on the todo.
+func (s *TimeEntryService) StopTimer(ctx context.Context, todoID int64) (model.TimeEntry, error) {
+	userID := caller(ctx)
+	if userID == nil {
+		return model.TimeEntry{}, errs.Forbidden("timers are tracked per user, use a key of a user")
+	}
+
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return model.TimeEntry{}, err
+	}
+
+	entry, err := s.TimeEntryRepo.StopTimer(ctx, todoID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TimeEntry{}, errs.NotFound("no timer is running on the todo")
+		}
+		return model.TimeEntry{}, err
+	}
+	return converter.ConvertTimeEntryToModel(entry), nil
+}
+
+// ListEntries returns the time tracked on the todo by everybody, oldest first.
+func (s *TimeEntryService) ListEntries(ctx context.Context, todoID int64) ([]model.TimeEntry, error) {
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.TimeEntryRepo.ListEntries(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ConvertTimeEntriesToModels(entries), nil
+}
+
+// checkOverlap rejects an entry of the user which overlaps another of the user's entries.
+func (s *TimeEntryService) checkOverlap(ctx context.Context, entry model.TimeEntry) error {
+	if entry.UserID == nil {
+		return nil
+	}
+
+	end := time.Now()
+	if entry.EndedAt != nil {
+		end = *entry.EndedAt
+	}
+	other, err := s.TimeEntryRepo.Overlapping(ctx, *entry.UserID, entry.StartedAt, end, entry.ID)
+	switch {
+	case err == nil:
+		return errs.Conflict(fmt.Sprintf("time entry overlaps time entry %d on todo %d", other.ID, other.TodoID))
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	}
+	return err
+}
+
+// CreateEntry records time the caller spent on a todo visible to the caller. Manual entries
+// must be finished and must not overlap other entries of the caller.
+func (s *TimeEntryService) CreateEntry(ctx context.Context, entry *model.TimeEntry) error {
+	if err := entry.Validate(time.Now()); err != nil {
+		return err
+	}
+	if entry.EndedAt == nil {
+		return errs.Validation(errs.FieldViolation{
+			Field:   model.TimeEntryEndedAtField,
+			Code:    errs.ViolationRequired,
+			Message: "ended_at must be set, start a timer to track time from now",
+		})
+	}
+
+	if _, err := s.Todos.GetTodoByID(ctx, entry.TodoID); err != nil {
+		return err
+	}
+
+	entry.ID = 0
+	entry.UserID = caller(ctx)
+	if err := s.checkOverlap(ctx, *entry); err != nil {
+		return err
+	}
+
+	entryDto := converter.ConvertTimeEntryToDTO(*entry)
+	if err := s.TimeEntryRepo.CreateEntry(ctx, &entryDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	*entry = converter.ConvertTimeEntryToModel(entryDto)
+	return nil
+}
+
+// authorized returns the entry if the caller tracked it. Keys which are not restricted
+// to a user may change any entry.
+func (s *TimeEntryService) authorized(ctx context.Context, todoID, id int64) (dto.TimeEntry, error) {
+	if id <= 0 {
+		return dto.TimeEntry{}, invalidID("entry_id")
+	}
+
+	if _, err := s.Todos.GetTodoByID(ctx, todoID); err != nil {
+		return dto.TimeEntry{}, err
+	}
+
+	current, err := s.TimeEntryRepo.GetEntry(ctx, todoID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.TimeEntry{}, entryNotFound()
+		}
+		return dto.TimeEntry{}, err
+	}
+
+	userID, restricted := auth.Member(ctx)
+	if restricted && (current.UserID == nil || *current.UserID != userID) {
+		return dto.TimeEntry{}, errs.Forbidden("only the user who tracked the time may change the entry")
+	}
+	return current, nil
+}
+
+// UpdateEntry changes the times or the note of an entry of the caller, setting ended_at
+// stops a running timer. The changed entry must not overlap other entries of its user.
+func (s *TimeEntryService) UpdateEntry(ctx context.Context, todoID, id int64, patch model.TimeEntryPatch) (model.TimeEntry, error) {
+	if len(patch.Fields()) == 0 {
+		return model.TimeEntry{}, errs.Validation(errs.FieldViolation{
+			Code:    errs.ViolationRequired,
+			Message: "at least one of started_at, ended_at and note must be set",
+		})
+	}
+
+	current, err := s.authorized(ctx, todoID, id)
+	if err != nil {
+		return model.TimeEntry{}, err
+	}
+
+	entry := patch.Apply(converter.ConvertTimeEntryToModel(current))
+	if err = entry.Validate(time.Now()); err != nil {
+		return model.TimeEntry{}, err
+	}
+	if err = s.checkOverlap(ctx, entry); err != nil {
+		return model.TimeEntry{}, err
+	}
+
+	entryDto := converter.ConvertTimeEntryToDTO(entry)
+	if err = s.TimeEntryRepo.UpdateEntry(ctx, &entryDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TimeEntry{}, entryNotFound()
+		}
+		return model.TimeEntry{}, err
+	}
+	return converter.ConvertTimeEntryToModel(entryDto), nil
+}
+
+func (s *TimeEntryService) DeleteEntry(ctx context.Context, todoID, id int64) error {
+	if _, err := s.authorized(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	if err := s.TimeEntryRepo.DeleteEntry(ctx, todoID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entryNotFound()
+		}
+		return err
+	}
+	return nil
+}
+
+// Report sums the time tracked on todos visible to the caller by day, project, status or todo.
+// Days are calendar days in the time zone of the caller, the last 30 days are reported by default.
+func (s *TimeEntryService) Report(ctx context.Context, filter dto.TimeReportFilter) (model.TimeReport, error) {
+	loc := timezone.FromContext(ctx)
+	if filter.To == nil {
+		to := timezone.Day(time.Now(), loc)
+		filter.To = &to
+	}
+	if filter.From == nil {
+		from := filter.To.AddDate(0, 0, 1-DefaultReportDays)
+		filter.From = &from
+	}
+
+	var v errs.Violations
+	switch {
+	case filter.From.After(*filter.To):
+		v.Add("from", errs.ViolationOutOfRange, "from must not be after to")
+	case filter.To.Sub(*filter.From) >= MaxReportDays*24*time.Hour:
+		v.Add("to", errs.ViolationOutOfRange, fmt.Sprintf("a report covers at most %d days", MaxReportDays))
+	}
+	if filter.ProjectID != nil && *filter.ProjectID <= 0 {
+		v.Add("project_id", errs.ViolationInvalid, "project_id must be positive")
+	}
+	if filter.UserID != nil && *filter.UserID <= 0 {
+		v.Add("user_id", errs.ViolationInvalid, "user_id must be positive")
+	}
+	if err := v.Err(); err != nil {
+		return model.TimeReport{}, err
+	}
+
+	filter.VisibleTo, _ = auth.Member(ctx)
+	filter.TimeZone = loc.String()
+
+	rows, err := s.TimeEntryRepo.Report(ctx, filter)
+	if err != nil {
+		return model.TimeReport{}, err
+	}
+
+	res := model.TimeReport{
+		GroupBy: filter.GroupBy,
+		From:    filter.From.Format(time.DateOnly),
+		To:      filter.To.Format(time.DateOnly),
+		Rows:    converter.ConvertTimeReportRowsToModels(rows),
+	}
+	for _, row := range res.Rows {
+		res.TotalSeconds += row.TrackedSeconds
+	}
+	return res, nil
+}
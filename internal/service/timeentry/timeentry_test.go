@@ -0,0 +1,209 @@
+package timeentry
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
+	"todo-list/internal/util/pointer"
+	mock_timeentry "todo-list/pkg/mocks/service/timeentry"
+)
+
+func asUser(id int64) context.Context {
+	return auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: id,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+}
+
+func TestTimeEntryService_StartTimer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_timeentry.NewMockRepository(ctrl)
+	todos := mock_timeentry.NewMockTodos(ctrl)
+	s := NewTimeEntryService(repo, todos)
+
+	t.Run("started", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().RunningEntry(gomock.Any(), pointer.Pointer(int64(5))).Return(dto.TimeEntry{}, sql.ErrNoRows)
+		repo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *dto.TimeEntry) error {
+			require.Nil(t, e.EndedAt)
+			require.Equal(t, int64(5), *e.UserID)
+			e.ID = 3
+			return nil
+		})
+
+		res, err := s.StartTimer(asUser(5), 1, "")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res.ID)
+		require.True(t, res.Running())
+	})
+
+	t.Run("timer already running", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().RunningEntry(gomock.Any(), pointer.Pointer(int64(5))).Return(dto.TimeEntry{ID: 3, TodoID: 2}, nil)
+
+		_, err := s.StartTimer(asUser(5), 1, "")
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("started concurrently", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().RunningEntry(gomock.Any(), gomock.Any()).Return(dto.TimeEntry{}, sql.ErrNoRows)
+		repo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: time_entries_running_idx", errs.ErrConflict))
+
+		_, err := s.StartTimer(asUser(5), 1, "")
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("key without user", func(t *testing.T) {
+		_, err := s.StartTimer(context.Background(), 1, "")
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestTimeEntryService_StopTimer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_timeentry.NewMockRepository(ctrl)
+	todos := mock_timeentry.NewMockTodos(ctrl)
+	s := NewTimeEntryService(repo, todos)
+
+	todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+	repo.EXPECT().StopTimer(gomock.Any(), int64(1), pointer.Pointer(int64(5))).Return(dto.TimeEntry{}, sql.ErrNoRows)
+
+	_, err := s.StopTimer(asUser(5), 1)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTimeEntryService_CreateEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_timeentry.NewMockRepository(ctrl)
+	todos := mock_timeentry.NewMockTodos(ctrl)
+	s := NewTimeEntryService(repo, todos)
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(time.Hour)
+
+	t.Run("ended_at required", func(t *testing.T) {
+		err := s.CreateEntry(asUser(5), &model.TimeEntry{TodoID: 1, StartedAt: start})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("ends before start", func(t *testing.T) {
+		before := start.Add(-time.Minute)
+		err := s.CreateEntry(asUser(5), &model.TimeEntry{TodoID: 1, StartedAt: start, EndedAt: &before})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().Overlapping(gomock.Any(), int64(5), start, end, int64(0)).Return(dto.TimeEntry{ID: 2, TodoID: 1}, nil)
+
+		err := s.CreateEntry(asUser(5), &model.TimeEntry{TodoID: 1, StartedAt: start, EndedAt: &end})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("created", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().Overlapping(gomock.Any(), int64(5), start, end, int64(0)).Return(dto.TimeEntry{}, sql.ErrNoRows)
+		repo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *dto.TimeEntry) error {
+			e.ID, e.DurationSeconds = 4, 3600
+			return nil
+		})
+
+		entry := &model.TimeEntry{TodoID: 1, StartedAt: start, EndedAt: &end}
+		require.NoError(t, s.CreateEntry(asUser(5), entry))
+		require.Equal(t, int64(4), entry.ID)
+		require.Equal(t, int64(5), *entry.UserID)
+	})
+}
+
+func TestTimeEntryService_UpdateEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_timeentry.NewMockRepository(ctrl)
+	todos := mock_timeentry.NewMockTodos(ctrl)
+	s := NewTimeEntryService(repo, todos)
+	start := time.Now().Add(-2 * time.Hour)
+
+	t.Run("only the owner", func(t *testing.T) {
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetEntry(gomock.Any(), int64(1), int64(2)).Return(dto.TimeEntry{ID: 2, TodoID: 1, UserID: pointer.Pointer(int64(6)), StartedAt: start}, nil)
+
+		_, err := s.UpdateEntry(asUser(5), 1, 2, model.TimeEntryPatch{Note: pointer.Pointer("x")})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("stops running timer", func(t *testing.T) {
+		end := start.Add(time.Hour)
+		todos.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(model.TodoItem{ID: 1}, nil)
+		repo.EXPECT().GetEntry(gomock.Any(), int64(1), int64(2)).Return(dto.TimeEntry{ID: 2, TodoID: 1, UserID: pointer.Pointer(int64(5)), StartedAt: start}, nil)
+		repo.EXPECT().Overlapping(gomock.Any(), int64(5), start, end, int64(2)).Return(dto.TimeEntry{}, sql.ErrNoRows)
+		repo.EXPECT().UpdateEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *dto.TimeEntry) error {
+			require.Equal(t, end, *e.EndedAt)
+			return nil
+		})
+
+		res, err := s.UpdateEntry(asUser(5), 1, 2, model.TimeEntryPatch{EndedAt: &end})
+		require.NoError(t, err)
+		require.False(t, res.Running())
+	})
+
+	t.Run("empty patch", func(t *testing.T) {
+		_, err := s.UpdateEntry(asUser(5), 1, 2, model.TimeEntryPatch{})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTimeEntryService_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_timeentry.NewMockRepository(ctrl)
+	todos := mock_timeentry.NewMockTodos(ctrl)
+	s := NewTimeEntryService(repo, todos)
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	t.Run("last 30 days by default", func(t *testing.T) {
+		ctx := timezone.WithLocation(asUser(5), loc)
+		repo.EXPECT().Report(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f dto.TimeReportFilter) ([]dto.TimeReportRow, error) {
+			require.Equal(t, "Europe/Moscow", f.TimeZone)
+			require.Equal(t, int64(5), f.VisibleTo)
+			require.Equal(t, 29*24*time.Hour, f.To.Sub(*f.From))
+			return []dto.TimeReportRow{{Key: "pending", TrackedSeconds: 60}, {Key: "completed", TrackedSeconds: 30}}, nil
+		})
+
+		res, err := s.Report(ctx, dto.TimeReportFilter{GroupBy: model.TimeReportByStatus})
+		require.NoError(t, err)
+		require.Equal(t, int64(90), res.TotalSeconds)
+		require.Len(t, res.Rows, 2)
+	})
+
+	t.Run("period too long", func(t *testing.T) {
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(2, 0, 0)
+		_, err := s.Report(asUser(5), dto.TimeReportFilter{GroupBy: model.TimeReportByDay, From: &from, To: &to})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, -1)
+		_, err := s.Report(asUser(5), dto.TimeReportFilter{GroupBy: model.TimeReportByDay, From: &from, To: &to})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
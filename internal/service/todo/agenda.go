@@ -0,0 +1,136 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
+	"todo-list/internal/util/converter"
+)
+
+// MaxAgendaDays limits the period of an agenda, a quarter.
+const MaxAgendaDays = 92
+
+// GetAgenda returns the todos visible to the caller falling on each day between the first and
+// the last day of the filter, in the time zone of the caller. Open recurring todos are repeated
+// on every day they recur on, completed and cancelled ones only appear on their own day.
+func (t *TodoService) GetAgenda(ctx context.Context, filter dto.AgendaFilter) (model.Agenda, error) {
+	var v errs.Violations
+	if filter.From == nil {
+		v.Add("from", errs.ViolationRequired, "from must be set")
+	}
+	if filter.To == nil {
+		v.Add("to", errs.ViolationRequired, "to must be set")
+	}
+	if err := v.Err(); err != nil {
+		return model.Agenda{}, err
+	}
+
+	from := timezone.Day(*filter.From, filter.From.Location())
+	to := timezone.Day(*filter.To, filter.To.Location())
+	switch {
+	case from.After(to):
+		v.Add("from", errs.ViolationOutOfRange, "from must not be after to")
+	case to.Sub(from) >= MaxAgendaDays*24*time.Hour:
+		v.Add("to", errs.ViolationOutOfRange, fmt.Sprintf("an agenda covers at most %d days", MaxAgendaDays))
+	}
+	if err := v.Err(); err != nil {
+		return model.Agenda{}, err
+	}
+
+	loc := timezone.FromContext(ctx)
+	todoFilter := dto.TodoFilter{
+		Status:          filter.Status,
+		ProjectID:       filter.ProjectID,
+		IncludeArchived: filter.IncludeArchived,
+		TimeZone:        loc.String(),
+	}
+	todoFilter.VisibleTo, _ = auth.Member(ctx)
+
+	items, err := t.TodoRepo.AgendaTodos(ctx, todoFilter, from, to)
+	if err != nil {
+		return model.Agenda{}, err
+	}
+
+	res := model.Agenda{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Days: make([]model.AgendaDay, 0, int(to.Sub(from).Hours()/24)+1),
+	}
+	// days are looked up by their text, dates read from the database have their own location
+	index := make(map[string]int)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		index[day.Format(time.DateOnly)] = len(res.Days)
+		res.Days = append(res.Days, model.AgendaDay{Date: day.Format(time.DateOnly), Items: make([]model.AgendaItem, 0)})
+	}
+	place := func(item model.AgendaItem) {
+		day := *item.Date
+		if item.DueAt != nil {
+			day = timezone.Day(*item.DueAt, loc)
+		}
+		if i, ok := index[day.Format(time.DateOnly)]; ok {
+			res.Days[i].Items = append(res.Days[i].Items, item)
+		}
+	}
+
+	for _, item := range converter.ConvertTodoToModels(items) {
+		for _, o := range occurrences(item, from, to) {
+			place(o)
+		}
+	}
+
+	for i := range res.Days {
+		day := &res.Days[i]
+		// todos come ordered by position, all-day todos go first and the others by due time
+		sort.SliceStable(day.Items, func(a, b int) bool {
+			x, y := day.Items[a].DueAt, day.Items[b].DueAt
+			if x == nil || y == nil {
+				return x == nil && y != nil
+			}
+			return x.Before(*y)
+		})
+		day.Count = int64(len(day.Items))
+		res.Total += day.Count
+	}
+	return res, nil
+}
+
+// occurrences returns the todo and its repetitions which may fall between from and to. The
+// repetitions of timed todos keep the time of day in the time zone of the todo. As the day of
+// a todo in the time zone of the caller may differ from its date, two more days are expanded
+// on each side and left to the caller to drop.
+func occurrences(item model.TodoItem, from, to time.Time) []model.AgendaItem {
+	if item.Recurrence == "" || item.Date == nil || item.Status.Closed() {
+		return []model.AgendaItem{{TodoItem: item}}
+	}
+	rule, err := model.ParseRecurrence(item.Recurrence)
+	if err != nil {
+		return []model.AgendaItem{{TodoItem: item}}
+	}
+	loc, err := timezone.Load(item.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	start := *item.Date
+	days := rule.Occurrences(start, from.AddDate(0, 0, -2), to.AddDate(0, 0, 2))
+	res := make([]model.AgendaItem, len(days))
+	for i, day := range days {
+		o := model.AgendaItem{TodoItem: item, Occurrence: !day.Equal(start)}
+		if o.Occurrence {
+			o.Date = &days[i]
+			if item.DueAt != nil {
+				local := item.DueAt.In(loc)
+				due := time.Date(day.Year(), day.Month(), day.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), loc)
+				o.DueAt = &due
+			}
+		}
+		res[i] = o
+	}
+	return res
+}
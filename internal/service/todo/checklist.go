@@ -0,0 +1,202 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strconv"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+func checklistItemNotFound() error {
+	return errs.NotFound("checklist item not found")
+}
+
+// GetChecklist returns the checklist of a todo visible to the caller.
+func (t *TodoService) GetChecklist(ctx context.Context, todoID int64) (model.Checklist, error) {
+	todo, err := t.GetTodoByID(ctx, todoID)
+	if err != nil {
+		return model.Checklist{}, err
+	}
+	return t.checklist(ctx, todo)
+}
+
+func (t *TodoService) checklist(ctx context.Context, todo model.TodoItem) (model.Checklist, error) {
+	items, err := t.TodoRepo.ListChecklist(ctx, todo.ID)
+	if err != nil {
+		return model.Checklist{}, err
+	}
+	return model.NewChecklist(todo, converter.ConvertChecklistItemsToModels(items)), nil
+}
+
+// changedChecklist returns the checklist after a change of its items and completes the
+// todo when it auto-completes and all items are checked. A todo which may not be
+// completed right now, e.g. because it is blocked, is left as it is.
+func (t *TodoService) changedChecklist(ctx context.Context, todoID int64) (model.Checklist, error) {
+	todoDto, err := t.TodoRepo.GetTodoByID(ctx, todoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Checklist{}, ErrNotFound
+		}
+		return model.Checklist{}, err
+	}
+
+	res, err := t.checklist(ctx, converter.ConvertTodoToModel(todoDto))
+	if err != nil {
+		return model.Checklist{}, err
+	}
+	if !res.AutoComplete || !res.Done() || res.Status.Closed() {
+		return res, nil
+	}
+
+	item := model.TodoItem{ID: todoID, Status: model.TodoStatusCompleted}
+	err = t.PatchTodo(ctx, &item, []string{model.TodoStatusField})
+	if errors.Is(err, ErrConflict) {
+		slog.InfoContext(ctx, "todo not auto-completed", slog.Int64("todo_id", todoID), slog.Any("reason", err))
+		return res, nil
+	}
+	if err != nil {
+		return model.Checklist{}, err
+	}
+
+	res.Status = item.Status
+	return res, nil
+}
+
+// AddChecklistItem appends an item to the checklist of the todo.
+func (t *TodoService) AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (model.Checklist, error) {
+	if todoID <= 0 {
+		return model.Checklist{}, invalidID()
+	}
+	if err := item.Validate(); err != nil {
+		return model.Checklist{}, err
+	}
+
+	if err := t.authorizeExisting(ctx, todoID, model.ProjectRoleEditor); err != nil {
+		return model.Checklist{}, err
+	}
+
+	items, err := t.TodoRepo.ListChecklist(ctx, todoID)
+	if err != nil {
+		return model.Checklist{}, err
+	}
+	if len(items) >= model.MaxChecklistItems {
+		return model.Checklist{}, errs.Validation(errs.FieldViolation{
+			Code:    errs.ViolationOutOfRange,
+			Message: "checklist must not have more than " + strconv.Itoa(model.MaxChecklistItems) + " items",
+		})
+	}
+
+	item.TodoID = todoID
+	itemDto := converter.ConvertChecklistItemToDTO(*item)
+	if err = t.TodoRepo.AddChecklistItem(ctx, &itemDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Checklist{}, ErrNotFound
+		}
+		return model.Checklist{}, err
+	}
+	*item = converter.ConvertChecklistItemToModel(itemDto)
+
+	return t.changedChecklist(ctx, todoID)
+}
+
+// UpdateChecklistItem renames, checks or unchecks an item. Unchecking an item does not
+// reopen a completed todo.
+func (t *TodoService) UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (model.Checklist, error) {
+	if todoID <= 0 || id <= 0 {
+		return model.Checklist{}, invalidID()
+	}
+	if err := patch.Validate(); err != nil {
+		return model.Checklist{}, err
+	}
+
+	if err := t.authorizeExisting(ctx, todoID, model.ProjectRoleEditor); err != nil {
+		return model.Checklist{}, err
+	}
+
+	item := model.ChecklistItem{ID: id, TodoID: todoID}
+	if patch.Title != nil {
+		item.Title = *patch.Title
+	}
+	if patch.Checked != nil {
+		item.Checked = *patch.Checked
+	}
+
+	itemDto := converter.ConvertChecklistItemToDTO(item)
+	if err := t.TodoRepo.UpdateChecklistItem(ctx, &itemDto, patch.Fields()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Checklist{}, checklistItemNotFound()
+		}
+		return model.Checklist{}, err
+	}
+
+	return t.changedChecklist(ctx, todoID)
+}
+
+// DeleteChecklistItem removes an item, removing the last unchecked item may complete the todo.
+func (t *TodoService) DeleteChecklistItem(ctx context.Context, todoID, id int64) error {
+	if todoID <= 0 || id <= 0 {
+		return invalidID()
+	}
+
+	if err := t.authorizeExisting(ctx, todoID, model.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	if err := t.TodoRepo.DeleteChecklistItem(ctx, todoID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return checklistItemNotFound()
+		}
+		return err
+	}
+
+	_, err := t.changedChecklist(ctx, todoID)
+	return err
+}
+
+// ReorderChecklist puts the items in the order of the ids, which must list every item
+// of the checklist once.
+func (t *TodoService) ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (model.Checklist, error) {
+	if todoID <= 0 {
+		return model.Checklist{}, invalidID()
+	}
+
+	if err := t.authorizeExisting(ctx, todoID, model.ProjectRoleEditor); err != nil {
+		return model.Checklist{}, err
+	}
+
+	items, err := t.TodoRepo.ListChecklist(ctx, todoID)
+	if err != nil {
+		return model.Checklist{}, err
+	}
+
+	invalidOrder := errs.Validation(errs.FieldViolation{
+		Field:   "ids",
+		Code:    errs.ViolationInvalid,
+		Message: "ids must list every item of the checklist once",
+	})
+	if len(order.IDs) != len(items) {
+		return model.Checklist{}, invalidOrder
+	}
+	listed := make(map[int64]bool, len(order.IDs))
+	for _, id := range order.IDs {
+		listed[id] = true
+	}
+	for _, item := range items {
+		if !listed[item.ID] {
+			return model.Checklist{}, invalidOrder
+		}
+	}
+
+	if err = t.TodoRepo.ReorderChecklist(ctx, todoID, order.IDs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Checklist{}, errs.Conflict("checklist was changed concurrently")
+		}
+		return model.Checklist{}, err
+	}
+
+	return t.GetChecklist(ctx, todoID)
+}
@@ -0,0 +1,176 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+// visible drops the todos of projects the caller can not read.
+func (t *TodoService) visible(ctx context.Context, items []dto.TodoItem) ([]model.TodoItem, error) {
+	allowed := make(map[int64]bool)
+	res := make([]model.TodoItem, 0, len(items))
+	for _, item := range items {
+		if item.ProjectID != nil {
+			ok, seen := allowed[*item.ProjectID]
+			if !seen {
+				err := t.authorize(ctx, item.ProjectID, model.ProjectRoleViewer)
+				if err != nil && !errors.Is(err, ErrNotFound) {
+					return nil, err
+				}
+				ok = err == nil
+				allowed[*item.ProjectID] = ok
+			}
+			if !ok {
+				continue
+			}
+		}
+		res = append(res, converter.ConvertTodoToModel(item))
+	}
+	return res, nil
+}
+
+// ListDependencies returns the todos the todo is blocked by and the todos it blocks.
+func (t *TodoService) ListDependencies(ctx context.Context, id int64) (model.TodoDependencies, error) {
+	if _, err := t.GetTodoByID(ctx, id); err != nil {
+		return model.TodoDependencies{}, err
+	}
+
+	blockedBy, blocks, err := t.TodoRepo.ListLinkedTodos(ctx, id)
+	if err != nil {
+		return model.TodoDependencies{}, err
+	}
+
+	var res model.TodoDependencies
+	if res.BlockedBy, err = t.visible(ctx, blockedBy); err != nil {
+		return model.TodoDependencies{}, err
+	}
+	if res.Blocks, err = t.visible(ctx, blocks); err != nil {
+		return model.TodoDependencies{}, err
+	}
+	return res, nil
+}
+
+// AddDependency links the todo to a todo it is blocked by or a todo it blocks. Links
+// which would make todos block each other, directly or through other todos, are
+// rejected with ErrConflict.
+func (t *TodoService) AddDependency(ctx context.Context, id int64, link model.TodoLink) (model.TodoDependencies, error) {
+	if id <= 0 {
+		return model.TodoDependencies{}, invalidID()
+	}
+	if err := link.Validate(); err != nil {
+		return model.TodoDependencies{}, err
+	}
+
+	dep := link.Dependency(id)
+	otherID := dep.BlockerID
+	if otherID == id {
+		otherID = dep.TodoID
+	}
+	if otherID == id {
+		return model.TodoDependencies{}, errs.Validation(errs.FieldViolation{
+			Field:   link.Field(),
+			Code:    errs.ViolationInvalid,
+			Message: "todo can not depend on itself",
+		})
+	}
+
+	if err := t.authorizeExisting(ctx, id, model.ProjectRoleEditor); err != nil {
+		return model.TodoDependencies{}, err
+	}
+	if _, err := t.GetTodoByID(ctx, otherID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return model.TodoDependencies{}, errs.Validation(errs.FieldViolation{
+				Field:   link.Field(),
+				Code:    errs.ViolationInvalid,
+				Message: "todo not found",
+			})
+		}
+		return model.TodoDependencies{}, err
+	}
+
+	if err := t.TodoRepo.AddDependency(ctx, converter.ConvertDependencyToDTO(dep)); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return model.TodoDependencies{}, ErrNotFound
+		case errors.Is(err, model.ErrDependencyCycle):
+			return model.TodoDependencies{}, errs.Conflict("todo " + strconv.FormatInt(dep.BlockerID, 10) +
+				" already depends on todo " + strconv.FormatInt(dep.TodoID, 10) + ", the dependency would create a cycle")
+		}
+		return model.TodoDependencies{}, err
+	}
+
+	return t.ListDependencies(ctx, id)
+}
+
+// RemoveDependency removes the blocker of the todo.
+func (t *TodoService) RemoveDependency(ctx context.Context, id, blockerID int64) error {
+	if id <= 0 || blockerID <= 0 {
+		return invalidID()
+	}
+
+	if err := t.authorizeExisting(ctx, id, model.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	if err := t.TodoRepo.DeleteDependency(ctx, id, blockerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// OrderTodos returns the todos so that every todo follows the todos it depends on.
+func (t *TodoService) OrderTodos(ctx context.Context, ids []int64) ([]model.TodoItem, error) {
+	if len(ids) == 0 {
+		return nil, errs.Validation(errs.FieldViolation{
+			Field:   "ids",
+			Code:    errs.ViolationRequired,
+			Message: "ids must be set",
+		})
+	}
+
+	found, err := t.TodoRepo.GetTodosByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	items, err := t.visible(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]model.TodoItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			return nil, errs.NotFound("todo " + strconv.FormatInt(id, 10) + " not found")
+		}
+	}
+
+	deps, err := t.TodoRepo.ListDependencies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	order, err := model.TopologicalOrder(ids, converter.ConvertDependenciesToModels(deps))
+	if errors.Is(err, model.ErrDependencyCycle) {
+		return nil, errs.Conflict("todos depend on each other in a cycle")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.TodoItem, len(order))
+	for i, id := range order {
+		res[i] = byID[id]
+	}
+	return res, nil
+}
@@ -2,8 +2,9 @@ package todo
 
 import (
 	"context"
-	"errors"
+	"time"
 	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
 	"todo-list/internal/domain/model"
 )
 
@@ -11,9 +12,25 @@ type (
 	Service interface {
 		CreateTodo(ctx context.Context, item *model.TodoItem) error
 		GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error)
+		AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) error
 		UpdateTodo(ctx context.Context, item *model.TodoItem) error
+		ReplaceTodo(ctx context.Context, item *model.TodoItem) error
+		PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) error
 		DeleteTodo(ctx context.Context, id int64) error
 		ListTodos(ctx context.Context, filter dto.TodoFilter) (model.TodoPagination, error)
+		MoveTodo(ctx context.Context, id int64, move model.TodoMove) (model.TodoItem, error)
+		ListDependencies(ctx context.Context, id int64) (model.TodoDependencies, error)
+		AddDependency(ctx context.Context, id int64, link model.TodoLink) (model.TodoDependencies, error)
+		RemoveDependency(ctx context.Context, id, blockerID int64) error
+		OrderTodos(ctx context.Context, ids []int64) ([]model.TodoItem, error)
+		GetChecklist(ctx context.Context, todoID int64) (model.Checklist, error)
+		AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (model.Checklist, error)
+		UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (model.Checklist, error)
+		DeleteChecklistItem(ctx context.Context, todoID, id int64) error
+		ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (model.Checklist, error)
+		GetStats(ctx context.Context, filter dto.TodoFilter) (model.TodoStats, error)
+		GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (model.TodoSeries, error)
+		GetAgenda(ctx context.Context, filter dto.AgendaFilter) (model.Agenda, error)
 	}
 
 	Repository interface {
@@ -22,12 +39,29 @@ type (
 		UpdateTodo(ctx context.Context, item *dto.TodoItem, updatedFields []string) error
 		DeleteTodo(ctx context.Context, id int64) error
 		ListTodos(ctx context.Context, filter dto.TodoFilter) ([]dto.TodoItem, int64, error)
+		GetMemberRole(ctx context.Context, projectID, userID int64) (string, error)
+		MoveTodo(ctx context.Context, id, targetID int64, after bool) error
+		AddDependency(ctx context.Context, dep dto.TodoDependency) error
+		DeleteDependency(ctx context.Context, todoID, blockerID int64) error
+		ListDependencies(ctx context.Context, ids []int64) ([]dto.TodoDependency, error)
+		ListLinkedTodos(ctx context.Context, id int64) (blockedBy, blocks []dto.TodoItem, err error)
+		GetTodosByIDs(ctx context.Context, ids []int64) ([]dto.TodoItem, error)
+		ListChecklist(ctx context.Context, todoID int64) ([]dto.ChecklistItem, error)
+		AddChecklistItem(ctx context.Context, item *dto.ChecklistItem) error
+		UpdateChecklistItem(ctx context.Context, item *dto.ChecklistItem, updatedFields []string) error
+		DeleteChecklistItem(ctx context.Context, todoID, id int64) error
+		ReorderChecklist(ctx context.Context, todoID int64, ids []int64) error
+		TodoStats(ctx context.Context, filter dto.TodoFilter) ([]dto.TodoStatusStats, error)
+		TodoSeries(ctx context.Context, filter dto.TodoSeriesFilter, start, end time.Time) ([]dto.TodoSeriesCount, error)
+		AgendaTodos(ctx context.Context, filter dto.TodoFilter, from, to time.Time) ([]dto.TodoItem, error)
 	}
 )
 
 var (
-	ErrValidation   = errors.New("validation error")
-	ErrNotFound     = errors.New("not found")
-	ErrInternal     = errors.New("internal error")
-	ErrEmptyContent = errors.New("empty content")
+	ErrValidation   = errs.ErrValidation
+	ErrNotFound     = errs.ErrNotFound
+	ErrConflict     = errs.ErrConflict
+	ErrForbidden    = errs.ErrForbidden
+	ErrInternal     = errs.ErrInternal
+	ErrEmptyContent = errs.ErrEmptyContent
 )
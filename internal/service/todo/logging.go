@@ -0,0 +1,171 @@
+package todo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// LoggingService decorates Service with a log line per method call. Request scoped
+// attributes (request id, trace id) are taken from ctx by the logger handler.
+type LoggingService struct {
+	next Service
+}
+
+func NewLoggingService(next Service) *LoggingService {
+	return &LoggingService{
+		next: next,
+	}
+}
+
+func (l *LoggingService) log(ctx context.Context, operation string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		res := outcome(err)
+		level := slog.LevelDebug
+		switch res {
+		case outcomeInternal:
+			level = slog.LevelError
+		case outcomeValidation, outcomeNotFound, outcomeForbidden, outcomeConflict:
+			level = slog.LevelInfo
+		}
+
+		attrs := []slog.Attr{
+			slog.String("operation", operation),
+			slog.String("outcome", res),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+		}
+		slog.LogAttrs(ctx, level, "todo service call", attrs...)
+	}
+}
+
+func (l *LoggingService) CreateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := l.log(ctx, "CreateTodo")
+	defer func() { done(err) }()
+	return l.next.CreateTodo(ctx, item)
+}
+
+func (l *LoggingService) GetTodoByID(ctx context.Context, id int64) (_ model.TodoItem, err error) {
+	done := l.log(ctx, "GetTodoByID")
+	defer func() { done(err) }()
+	return l.next.GetTodoByID(ctx, id)
+}
+
+func (l *LoggingService) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) (err error) {
+	done := l.log(ctx, "AuthorizeTodo")
+	defer func() { done(err) }()
+	return l.next.AuthorizeTodo(ctx, id, role)
+}
+
+func (l *LoggingService) UpdateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := l.log(ctx, "UpdateTodo")
+	defer func() { done(err) }()
+	return l.next.UpdateTodo(ctx, item)
+}
+
+func (l *LoggingService) ReplaceTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := l.log(ctx, "ReplaceTodo")
+	defer func() { done(err) }()
+	return l.next.ReplaceTodo(ctx, item)
+}
+
+func (l *LoggingService) PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) (err error) {
+	done := l.log(ctx, "PatchTodo")
+	defer func() { done(err) }()
+	return l.next.PatchTodo(ctx, item, fields)
+}
+
+func (l *LoggingService) DeleteTodo(ctx context.Context, id int64) (err error) {
+	done := l.log(ctx, "DeleteTodo")
+	defer func() { done(err) }()
+	return l.next.DeleteTodo(ctx, id)
+}
+
+func (l *LoggingService) ListTodos(ctx context.Context, filter dto.TodoFilter) (_ model.TodoPagination, err error) {
+	done := l.log(ctx, "ListTodos")
+	defer func() { done(err) }()
+	return l.next.ListTodos(ctx, filter)
+}
+
+func (l *LoggingService) MoveTodo(ctx context.Context, id int64, move model.TodoMove) (_ model.TodoItem, err error) {
+	done := l.log(ctx, "MoveTodo")
+	defer func() { done(err) }()
+	return l.next.MoveTodo(ctx, id, move)
+}
+
+func (l *LoggingService) ListDependencies(ctx context.Context, id int64) (_ model.TodoDependencies, err error) {
+	done := l.log(ctx, "ListDependencies")
+	defer func() { done(err) }()
+	return l.next.ListDependencies(ctx, id)
+}
+
+func (l *LoggingService) AddDependency(ctx context.Context, id int64, link model.TodoLink) (_ model.TodoDependencies, err error) {
+	done := l.log(ctx, "AddDependency")
+	defer func() { done(err) }()
+	return l.next.AddDependency(ctx, id, link)
+}
+
+func (l *LoggingService) RemoveDependency(ctx context.Context, id, blockerID int64) (err error) {
+	done := l.log(ctx, "RemoveDependency")
+	defer func() { done(err) }()
+	return l.next.RemoveDependency(ctx, id, blockerID)
+}
+
+func (l *LoggingService) OrderTodos(ctx context.Context, ids []int64) (_ []model.TodoItem, err error) {
+	done := l.log(ctx, "OrderTodos")
+	defer func() { done(err) }()
+	return l.next.OrderTodos(ctx, ids)
+}
+
+func (l *LoggingService) GetChecklist(ctx context.Context, todoID int64) (_ model.Checklist, err error) {
+	done := l.log(ctx, "GetChecklist")
+	defer func() { done(err) }()
+	return l.next.GetChecklist(ctx, todoID)
+}
+
+func (l *LoggingService) AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (_ model.Checklist, err error) {
+	done := l.log(ctx, "AddChecklistItem")
+	defer func() { done(err) }()
+	return l.next.AddChecklistItem(ctx, todoID, item)
+}
+
+func (l *LoggingService) UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (_ model.Checklist, err error) {
+	done := l.log(ctx, "UpdateChecklistItem")
+	defer func() { done(err) }()
+	return l.next.UpdateChecklistItem(ctx, todoID, id, patch)
+}
+
+func (l *LoggingService) DeleteChecklistItem(ctx context.Context, todoID, id int64) (err error) {
+	done := l.log(ctx, "DeleteChecklistItem")
+	defer func() { done(err) }()
+	return l.next.DeleteChecklistItem(ctx, todoID, id)
+}
+
+func (l *LoggingService) ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (_ model.Checklist, err error) {
+	done := l.log(ctx, "ReorderChecklist")
+	defer func() { done(err) }()
+	return l.next.ReorderChecklist(ctx, todoID, order)
+}
+
+func (l *LoggingService) GetStats(ctx context.Context, filter dto.TodoFilter) (_ model.TodoStats, err error) {
+	done := l.log(ctx, "GetStats")
+	defer func() { done(err) }()
+	return l.next.GetStats(ctx, filter)
+}
+
+func (l *LoggingService) GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (_ model.TodoSeries, err error) {
+	done := l.log(ctx, "GetSeries")
+	defer func() { done(err) }()
+	return l.next.GetSeries(ctx, filter)
+}
+
+func (l *LoggingService) GetAgenda(ctx context.Context, filter dto.AgendaFilter) (_ model.Agenda, err error) {
+	done := l.log(ctx, "GetAgenda")
+	defer func() { done(err) }()
+	return l.next.GetAgenda(ctx, filter)
+}
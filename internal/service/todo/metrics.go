@@ -0,0 +1,184 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/metrics"
+)
+
+const (
+	outcomeSuccess    = "success"
+	outcomeValidation = "validation"
+	outcomeNotFound   = "not_found"
+	outcomeEmpty      = "empty"
+	outcomeForbidden  = "forbidden"
+	outcomeConflict   = "conflict"
+	outcomeInternal   = "internal"
+)
+
+// MetricsService decorates Service with operation counters and latency histograms.
+type MetricsService struct {
+	next Service
+}
+
+func NewMetricsService(next Service) *MetricsService {
+	return &MetricsService{
+		next: next,
+	}
+}
+
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case errors.Is(err, ErrValidation):
+		return outcomeValidation
+	case errors.Is(err, ErrNotFound):
+		return outcomeNotFound
+	case errors.Is(err, ErrEmptyContent):
+		return outcomeEmpty
+	case errors.Is(err, ErrForbidden):
+		return outcomeForbidden
+	case errors.Is(err, ErrConflict):
+		return outcomeConflict
+	default:
+		return outcomeInternal
+	}
+}
+
+func (m *MetricsService) observe(operation string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		metrics.ServiceOperationsTotal.WithLabelValues(operation, outcome(err)).Inc()
+		metrics.ServiceOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (m *MetricsService) CreateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := m.observe("CreateTodo")
+	defer func() { done(err) }()
+	return m.next.CreateTodo(ctx, item)
+}
+
+func (m *MetricsService) GetTodoByID(ctx context.Context, id int64) (_ model.TodoItem, err error) {
+	done := m.observe("GetTodoByID")
+	defer func() { done(err) }()
+	return m.next.GetTodoByID(ctx, id)
+}
+
+func (m *MetricsService) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) (err error) {
+	done := m.observe("AuthorizeTodo")
+	defer func() { done(err) }()
+	return m.next.AuthorizeTodo(ctx, id, role)
+}
+
+func (m *MetricsService) UpdateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := m.observe("UpdateTodo")
+	defer func() { done(err) }()
+	return m.next.UpdateTodo(ctx, item)
+}
+
+func (m *MetricsService) ReplaceTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	done := m.observe("ReplaceTodo")
+	defer func() { done(err) }()
+	return m.next.ReplaceTodo(ctx, item)
+}
+
+func (m *MetricsService) PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) (err error) {
+	done := m.observe("PatchTodo")
+	defer func() { done(err) }()
+	return m.next.PatchTodo(ctx, item, fields)
+}
+
+func (m *MetricsService) DeleteTodo(ctx context.Context, id int64) (err error) {
+	done := m.observe("DeleteTodo")
+	defer func() { done(err) }()
+	return m.next.DeleteTodo(ctx, id)
+}
+
+func (m *MetricsService) ListTodos(ctx context.Context, filter dto.TodoFilter) (_ model.TodoPagination, err error) {
+	done := m.observe("ListTodos")
+	defer func() { done(err) }()
+	return m.next.ListTodos(ctx, filter)
+}
+
+func (m *MetricsService) MoveTodo(ctx context.Context, id int64, move model.TodoMove) (_ model.TodoItem, err error) {
+	done := m.observe("MoveTodo")
+	defer func() { done(err) }()
+	return m.next.MoveTodo(ctx, id, move)
+}
+
+func (m *MetricsService) ListDependencies(ctx context.Context, id int64) (_ model.TodoDependencies, err error) {
+	done := m.observe("ListDependencies")
+	defer func() { done(err) }()
+	return m.next.ListDependencies(ctx, id)
+}
+
+func (m *MetricsService) AddDependency(ctx context.Context, id int64, link model.TodoLink) (_ model.TodoDependencies, err error) {
+	done := m.observe("AddDependency")
+	defer func() { done(err) }()
+	return m.next.AddDependency(ctx, id, link)
+}
+
+func (m *MetricsService) RemoveDependency(ctx context.Context, id, blockerID int64) (err error) {
+	done := m.observe("RemoveDependency")
+	defer func() { done(err) }()
+	return m.next.RemoveDependency(ctx, id, blockerID)
+}
+
+func (m *MetricsService) OrderTodos(ctx context.Context, ids []int64) (_ []model.TodoItem, err error) {
+	done := m.observe("OrderTodos")
+	defer func() { done(err) }()
+	return m.next.OrderTodos(ctx, ids)
+}
+
+func (m *MetricsService) GetChecklist(ctx context.Context, todoID int64) (_ model.Checklist, err error) {
+	done := m.observe("GetChecklist")
+	defer func() { done(err) }()
+	return m.next.GetChecklist(ctx, todoID)
+}
+
+func (m *MetricsService) AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (_ model.Checklist, err error) {
+	done := m.observe("AddChecklistItem")
+	defer func() { done(err) }()
+	return m.next.AddChecklistItem(ctx, todoID, item)
+}
+
+func (m *MetricsService) UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (_ model.Checklist, err error) {
+	done := m.observe("UpdateChecklistItem")
+	defer func() { done(err) }()
+	return m.next.UpdateChecklistItem(ctx, todoID, id, patch)
+}
+
+func (m *MetricsService) DeleteChecklistItem(ctx context.Context, todoID, id int64) (err error) {
+	done := m.observe("DeleteChecklistItem")
+	defer func() { done(err) }()
+	return m.next.DeleteChecklistItem(ctx, todoID, id)
+}
+
+func (m *MetricsService) ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (_ model.Checklist, err error) {
+	done := m.observe("ReorderChecklist")
+	defer func() { done(err) }()
+	return m.next.ReorderChecklist(ctx, todoID, order)
+}
+
+func (m *MetricsService) GetStats(ctx context.Context, filter dto.TodoFilter) (_ model.TodoStats, err error) {
+	done := m.observe("GetStats")
+	defer func() { done(err) }()
+	return m.next.GetStats(ctx, filter)
+}
+
+func (m *MetricsService) GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (_ model.TodoSeries, err error) {
+	done := m.observe("GetSeries")
+	defer func() { done(err) }()
+	return m.next.GetSeries(ctx, filter)
+}
+
+func (m *MetricsService) GetAgenda(ctx context.Context, filter dto.AgendaFilter) (_ model.Agenda, err error) {
+	done := m.observe("GetAgenda")
+	defer func() { done(err) }()
+	return m.next.GetAgenda(ctx, filter)
+}
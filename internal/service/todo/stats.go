@@ -0,0 +1,156 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-list/internal/auth"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
+)
+
+// MaxSeriesPoints limits the number of periods of a series.
+const MaxSeriesPoints = 366
+
+// defaultSeriesPoints is the number of periods of a series without a start.
+const defaultSeriesPoints = 30
+
+// GetStats summarizes the todos visible to the caller matching the filter.
+func (t *TodoService) GetStats(ctx context.Context, filter dto.TodoFilter) (model.TodoStats, error) {
+	filter.VisibleTo, _ = auth.Member(ctx)
+	filter.TimeZone = timezone.FromContext(ctx).String()
+
+	rows, err := t.TodoRepo.TodoStats(ctx, filter)
+	if err != nil {
+		return model.TodoStats{}, searchError(err)
+	}
+
+	res := model.TodoStats{ByStatus: make(map[string]int64, len(model.TodoStatuses))}
+	for _, status := range model.TodoStatuses {
+		res.ByStatus[string(status)] = 0
+	}
+	var completionSeconds int64
+	for _, row := range rows {
+		res.Total += row.Total
+		res.ByStatus[row.Status] = row.Total
+		res.Overdue += row.Overdue
+		res.Completed += row.Completed
+		completionSeconds += row.CompletionSeconds
+	}
+	if res.Completed != 0 {
+		avg := completionSeconds / res.Completed
+		res.AvgCompletionSeconds = &avg
+	}
+	return res, nil
+}
+
+// periodStart returns the first day of the period day falls in, weeks start on Monday.
+func periodStart(day time.Time, interval string) time.Time {
+	switch interval {
+	case model.IntervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case model.IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// nextPeriod returns the first day of the period after the one starting on start.
+func nextPeriod(start time.Time, interval string) time.Time {
+	switch interval {
+	case model.IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case model.IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// GetSeries counts the todos visible to the caller created and completed per day, week or month
+// in the time zone of the caller, with running totals for burn-up charts. The last 30 periods are
+// returned by default.
+func (t *TodoService) GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (model.TodoSeries, error) {
+	loc := timezone.FromContext(ctx)
+	if filter.Interval == "" {
+		filter.Interval = model.IntervalDay
+	}
+
+	to := timezone.Day(time.Now(), loc)
+	if filter.To != nil {
+		to = *filter.To
+	}
+	to = periodStart(to, filter.Interval)
+	from := to
+	if filter.From != nil {
+		from = periodStart(*filter.From, filter.Interval)
+	} else {
+		for i := 1; i < defaultSeriesPoints; i++ {
+			from = periodStart(from.AddDate(0, 0, -1), filter.Interval)
+		}
+	}
+
+	if from.After(to) {
+		return model.TodoSeries{}, errs.Validation(errs.FieldViolation{
+			Field:   "from",
+			Code:    errs.ViolationOutOfRange,
+			Message: "from must not be after to",
+		})
+	}
+	periods := make([]time.Time, 0, defaultSeriesPoints)
+	for p := from; !p.After(to); p = nextPeriod(p, filter.Interval) {
+		if len(periods) == MaxSeriesPoints {
+			return model.TodoSeries{}, errs.Validation(errs.FieldViolation{
+				Field:   "to",
+				Code:    errs.ViolationOutOfRange,
+				Message: fmt.Sprintf("a series has at most %d periods", MaxSeriesPoints),
+			})
+		}
+		periods = append(periods, p)
+	}
+
+	filter.VisibleTo, _ = auth.Member(ctx)
+	filter.TimeZone = loc.String()
+
+	// days are kept as midnight UTC, the series is bound by midnights in the time zone of the caller
+	local := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	}
+	end := nextPeriod(to, filter.Interval)
+	rows, err := t.TodoRepo.TodoSeries(ctx, filter, local(from), local(end))
+	if err != nil {
+		return model.TodoSeries{}, searchError(err)
+	}
+
+	var totalCreated, totalCompleted int64
+	counts := make(map[string]dto.TodoSeriesCount, len(rows))
+	for _, row := range rows {
+		if row.Period == nil {
+			totalCreated, totalCompleted = row.Created, row.Completed
+			continue
+		}
+		counts[*row.Period] = row
+	}
+
+	res := model.TodoSeries{
+		Interval: filter.Interval,
+		From:     from.Format(time.DateOnly),
+		To:       end.AddDate(0, 0, -1).Format(time.DateOnly),
+		Points:   make([]model.TodoSeriesPoint, len(periods)),
+	}
+	for i, p := range periods {
+		period := p.Format(time.DateOnly)
+		c := counts[period]
+		totalCreated += c.Created
+		totalCompleted += c.Completed
+		res.Points[i] = model.TodoSeriesPoint{
+			Period:         period,
+			Created:        c.Created,
+			Completed:      c.Completed,
+			TotalCreated:   totalCreated,
+			TotalCompleted: totalCompleted,
+		}
+	}
+	return res, nil
+}
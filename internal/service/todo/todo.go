@@ -4,31 +4,156 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"time"
+	"todo-list/internal/auth"
 	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
 	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
 	"todo-list/internal/util/converter"
+	"todo-list/internal/util/search"
 )
 
 type TodoService struct {
-	TodoRepo Repository
+	TodoRepo    Repository
+	Transitions model.TodoTransitions
 }
 
-func NewTodoService(tr Repository) *TodoService {
+func NewTodoService(tr Repository, transitions model.TodoTransitions) *TodoService {
 	return &TodoService{
-		TodoRepo: tr,
+		TodoRepo:    tr,
+		Transitions: transitions,
 	}
 }
 
-func (t *TodoService) CreateTodo(ctx context.Context, item *model.TodoItem) error {
-	err := item.Validate()
+func invalidID() error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   "id",
+		Code:    errs.ViolationInvalid,
+		Message: "id must be positive",
+	})
+}
+
+// searchError reports an invalid search expression as a violation of the q parameter.
+func searchError(err error) error {
+	var e *search.Error
+	if errors.As(err, &e) {
+		return errs.Validation(errs.FieldViolation{
+			Field:    "q",
+			Code:     errs.ViolationInvalid,
+			Message:  e.Error(),
+			Position: e.Pos,
+		})
+	}
+	return err
+}
+
+func unknownProject() error {
+	return errs.Validation(errs.FieldViolation{
+		Field:   model.TodoProjectIDField,
+		Code:    errs.ViolationInvalid,
+		Message: "project not found",
+	})
+}
+
+// authorize checks that the caller has at least the role in the project of a todo.
+// Todos outside of projects stay shared with everybody. Projects the caller is not
+// a member of are reported as ErrNotFound.
+func (t *TodoService) authorize(ctx context.Context, projectID *int64, role model.ProjectRole) error {
+	userID, restricted := auth.Member(ctx)
+	if !restricted || projectID == nil {
+		return nil
+	}
+
+	got, err := t.TodoRepo.GetMemberRole(ctx, *projectID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if !model.ProjectRole(got).Can(role) {
+		return errs.Forbidden("project role " + string(role) + " is required")
+	}
+	return nil
+}
+
+// authorizeTarget checks access to the project a todo is created in or moved to.
+func (t *TodoService) authorizeTarget(ctx context.Context, projectID *int64) error {
+	err := t.authorize(ctx, projectID, model.ProjectRoleEditor)
+	if errors.Is(err, ErrNotFound) {
+		return unknownProject()
+	}
+	return err
+}
+
+func hasField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// schedule keeps the date of a todo the day its due time falls on in the time zone of
+// the todo and returns the fields to store. Moving a todo with a due time to another
+// date keeps its local time of day. Dates are calendar days, the offset they were sent
+// with is dropped.
+func schedule(item *model.TodoItem, current model.TodoItem, fields []string) []string {
+	loc, err := timezone.Load(item.TimeZone)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrValidation, err)
+		loc = time.UTC
+	}
+
+	if item.Date != nil {
+		y, m, d := item.Date.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		item.Date = &day
+
+		if hasField(fields, model.TodoDateField) && !hasField(fields, model.TodoDueAtField) && current.DueAt != nil {
+			local := current.DueAt.In(loc)
+			moved := time.Date(y, m, d, local.Hour(), local.Minute(), local.Second(), 0, loc)
+			item.DueAt = &moved
+			fields = append(fields, model.TodoDueAtField)
+		}
+	}
+
+	if item.DueAt != nil {
+		day := timezone.Day(*item.DueAt, loc)
+		if item.Date == nil || !item.Date.Equal(day) {
+			item.Date = &day
+			if !hasField(fields, model.TodoDateField) {
+				fields = append(fields, model.TodoDateField)
+			}
+		}
+	}
+	return fields
+}
+
+func (t *TodoService) CreateTodo(ctx context.Context, item *model.TodoItem) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	if item.TimeZone == "" {
+		item.TimeZone = timezone.FromContext(ctx).String()
+	}
+	schedule(item, model.TodoItem{}, nil)
+	if item.Status == model.TodoStatusCompleted {
+		now := time.Now()
+		item.CompletedAt = &now
+	}
+	if err := t.authorizeTarget(ctx, item.ProjectID); err != nil {
+		return err
 	}
 
 	todoDto := converter.ConvertTodoToDTO(*item)
-	err = t.TodoRepo.CreateTodo(ctx, &todoDto)
+	err := t.TodoRepo.CreateTodo(ctx, &todoDto)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return unknownProject()
+		}
 		return err
 	}
 
@@ -38,7 +163,7 @@ func (t *TodoService) CreateTodo(ctx context.Context, item *model.TodoItem) erro
 
 func (t *TodoService) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
 	if id <= 0 {
-		return model.TodoItem{}, ErrValidation
+		return model.TodoItem{}, invalidID()
 	}
 
 	td, err := t.TodoRepo.GetTodoByID(ctx, id)
@@ -49,14 +174,123 @@ func (t *TodoService) GetTodoByID(ctx context.Context, id int64) (model.TodoItem
 		return model.TodoItem{}, err
 	}
 
+	if err = t.authorize(ctx, td.ProjectID, model.ProjectRoleViewer); err != nil {
+		return model.TodoItem{}, err
+	}
+
 	return converter.ConvertTodoToModel(td), nil
 }
 
+// authorizeExisting checks the role of the caller in the current project of the todo.
+func (t *TodoService) authorizeExisting(ctx context.Context, id int64, role model.ProjectRole) error {
+	if _, restricted := auth.Member(ctx); !restricted {
+		return nil
+	}
+
+	td, err := t.TodoRepo.GetTodoByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return t.authorize(ctx, td.ProjectID, role)
+}
+
+// AuthorizeTodo checks that the todo exists and the caller has at least the role in its
+// project, it guards changes of things attached to todos made by other services.
+func (t *TodoService) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) error {
+	if id <= 0 {
+		return invalidID()
+	}
+
+	td, err := t.TodoRepo.GetTodoByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return t.authorize(ctx, td.ProjectID, role)
+}
+
+// UpdateTodo changes the fields of the todo which are set in item.
 func (t *TodoService) UpdateTodo(ctx context.Context, item *model.TodoItem) error {
-	fields := item.EditableFields()
+	return t.PatchTodo(ctx, item, item.EditableFields())
+}
+
+// ReplaceTodo replaces all writable fields of the todo, the time zone of the caller is
+// taken when item has none.
+func (t *TodoService) ReplaceTodo(ctx context.Context, item *model.TodoItem) error {
+	if item.ID <= 0 {
+		return invalidID()
+	}
+	if item.TimeZone == "" {
+		item.TimeZone = timezone.FromContext(ctx).String()
+	}
+
+	return t.PatchTodo(ctx, item, append([]string(nil), model.TodoWritableFields...))
+}
+
+// PatchTodo sets the fields of the todo to their values in item, a listed field with an
+// empty value is cleared. The resulting todo is validated as a whole.
+func (t *TodoService) PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) error {
+	var v errs.Violations
+	if item.TimeZone != "" && !timezone.Valid(item.TimeZone) {
+		v.Add(model.TodoTimeZoneField, errs.ViolationInvalid, "time_zone is not a known IANA time zone")
+	}
+	if item.Status != "" && !item.Status.Valid() {
+		v.Add(model.TodoStatusField, errs.ViolationInvalid, "status "+string(item.Status)+" is unknown")
+	}
+	if err := v.Err(); err != nil {
+		return err
+	}
+
+	if err := t.authorizeExisting(ctx, item.ID, model.ProjectRoleEditor); err != nil {
+		return err
+	}
+	if hasField(fields, model.TodoProjectIDField) {
+		if err := t.authorizeTarget(ctx, item.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	if len(fields) > 0 {
+		currentDto, err := t.TodoRepo.GetTodoByID(ctx, item.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+		current := converter.ConvertTodoToModel(currentDto)
+
+		merged := current
+		merged.Set(*item, fields)
+		if err = merged.Validate(); err != nil {
+			return err
+		}
+		fields = schedule(&merged, current, fields)
+
+		if hasField(fields, model.TodoStatusField) {
+			changed, err := t.transition(&merged, current.Status)
+			if err != nil {
+				return err
+			}
+			if changed {
+				fields = append(fields, model.TodoCompletedAtField)
+			}
+		}
+		*item = merged
+	}
 
 	todoDto := converter.ConvertTodoToDTO(*item)
 	if err := t.TodoRepo.UpdateTodo(ctx, &todoDto, fields); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
 		return err
 	}
 
@@ -64,9 +298,35 @@ func (t *TodoService) UpdateTodo(ctx context.Context, item *model.TodoItem) erro
 	return nil
 }
 
+// transition checks the status change against the transition graph and records the
+// completion time. changed reports whether completed_at has to be stored.
+func (t *TodoService) transition(item *model.TodoItem, from model.TodoStatus) (changed bool, err error) {
+	to := item.Status
+	if !t.Transitions.Allows(from, to) {
+		return false, errs.Conflict("status can not change from " + string(from) + " to " + string(to))
+	}
+	if to == model.TodoStatusCompleted && from != to && item.Blocked {
+		return false, errs.Conflict("todo is blocked by todos which are neither completed nor cancelled")
+	}
+	if from == to || (from != model.TodoStatusCompleted && to != model.TodoStatusCompleted) {
+		return false, nil
+	}
+
+	item.CompletedAt = nil
+	if to == model.TodoStatusCompleted {
+		now := time.Now()
+		item.CompletedAt = &now
+	}
+	return true, nil
+}
+
 func (t *TodoService) DeleteTodo(ctx context.Context, id int64) error {
 	if id <= 0 {
-		return ErrValidation
+		return invalidID()
+	}
+
+	if err := t.authorizeExisting(ctx, id, model.ProjectRoleEditor); err != nil {
+		return err
 	}
 
 	err := t.TodoRepo.DeleteTodo(ctx, id)
@@ -80,9 +340,12 @@ func (t *TodoService) DeleteTodo(ctx context.Context, id int64) error {
 }
 
 func (t *TodoService) ListTodos(ctx context.Context, filter dto.TodoFilter) (model.TodoPagination, error) {
+	filter.VisibleTo, _ = auth.Member(ctx)
+	filter.TimeZone = timezone.FromContext(ctx).String()
+
 	items, totalItems, err := t.TodoRepo.ListTodos(ctx, filter)
 	if err != nil {
-		return model.TodoPagination{}, err
+		return model.TodoPagination{}, searchError(err)
 	}
 
 	if totalItems == 0 {
@@ -94,3 +357,49 @@ func (t *TodoService) ListTodos(ctx context.Context, filter dto.TodoFilter) (mod
 		TotalItems: totalItems,
 	}, nil
 }
+
+// MoveTodo places the todo right before or after another todo in the manual order.
+func (t *TodoService) MoveTodo(ctx context.Context, id int64, move model.TodoMove) (model.TodoItem, error) {
+	if id <= 0 {
+		return model.TodoItem{}, invalidID()
+	}
+	if err := move.Validate(); err != nil {
+		return model.TodoItem{}, err
+	}
+
+	targetID, after := move.Target()
+	field := "before_id"
+	if after {
+		field = "after_id"
+	}
+	if targetID == id {
+		return model.TodoItem{}, errs.Validation(errs.FieldViolation{
+			Field:   field,
+			Code:    errs.ViolationInvalid,
+			Message: "todo can not be moved relative to itself",
+		})
+	}
+
+	if err := t.authorizeExisting(ctx, id, model.ProjectRoleEditor); err != nil {
+		return model.TodoItem{}, err
+	}
+	if _, err := t.GetTodoByID(ctx, targetID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return model.TodoItem{}, errs.Validation(errs.FieldViolation{
+				Field:   field,
+				Code:    errs.ViolationInvalid,
+				Message: "todo not found",
+			})
+		}
+		return model.TodoItem{}, err
+	}
+
+	if err := t.TodoRepo.MoveTodo(ctx, id, targetID, after); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TodoItem{}, ErrNotFound
+		}
+		return model.TodoItem{}, err
+	}
+
+	return t.GetTodoByID(ctx, id)
+}
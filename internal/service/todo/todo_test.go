@@ -7,9 +7,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
+	"todo-list/internal/auth"
 	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
 	"todo-list/internal/domain/model"
+	"todo-list/internal/timezone"
 	"todo-list/internal/util/pointer"
+	"todo-list/internal/util/search"
 	mock_todo "todo-list/pkg/mocks/service/todo"
 )
 
@@ -18,7 +22,7 @@ func TestTodoService_CreateTodo(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_todo.NewMockRepository(ctrl)
-	s := NewTodoService(repo)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
 	now := time.Now()
 
 	t.Run("casual creation todo", func(t *testing.T) {
@@ -32,6 +36,7 @@ func TestTodoService_CreateTodo(t *testing.T) {
 			Title:       "Полить цветы",
 			Description: "Взять лейку. Наполнить водой. Полить цветы.",
 			Date:        pointer.Pointer(time.Date(2023, time.February, 11, 0, 0, 0, 0, time.UTC)),
+			TimeZone:    "UTC",
 			Status:      "pending",
 		}
 		repo.EXPECT().CreateTodo(gomock.Any(), expectDto).DoAndReturn(func(ctx context.Context, inp *dto.TodoItem) {
@@ -47,6 +52,7 @@ func TestTodoService_CreateTodo(t *testing.T) {
 			Title:       "Полить цветы",
 			Description: "Взять лейку. Наполнить водой. Полить цветы.",
 			Date:        pointer.Pointer(time.Date(2023, time.February, 11, 0, 0, 0, 0, time.UTC)),
+			TimeZone:    "UTC",
 			CreatedAt:   now,
 			Status:      "pending",
 		}, input)
@@ -63,6 +69,19 @@ func TestTodoService_CreateTodo(t *testing.T) {
 		require.ErrorIs(t, err, ErrValidation)
 	})
 
+	t.Run("validation reports all fields", func(t *testing.T) {
+		err := s.CreateTodo(context.Background(), &model.TodoItem{})
+		require.ErrorIs(t, err, ErrValidation)
+
+		var appErr *errs.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, []errs.FieldViolation{
+			{Field: model.TodoTitleField, Code: errs.ViolationRequired, Message: "title must be set"},
+			{Field: model.TodoDateField, Code: errs.ViolationRequired, Message: "one of date and due_at must be set"},
+			{Field: model.TodoStatusField, Code: errs.ViolationRequired, Message: "status must be set"},
+		}, appErr.Violations)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any()).Return(sql.ErrConnDone)
 		err := s.CreateTodo(context.Background(), &model.TodoItem{
@@ -80,7 +99,7 @@ func TestTodoService_DeleteTodo(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_todo.NewMockRepository(ctrl)
-	s := NewTodoService(repo)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
 
 	t.Run("invalid id", func(t *testing.T) {
 		err := s.DeleteTodo(context.Background(), 0)
@@ -105,7 +124,7 @@ func TestTodoService_GetTodoByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_todo.NewMockRepository(ctrl)
-	s := NewTodoService(repo)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
 	now := time.Now()
 
 	t.Run("invalid id", func(t *testing.T) {
@@ -155,7 +174,7 @@ func TestTodoService_UpdateTodo(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_todo.NewMockRepository(ctrl)
-	s := NewTodoService(repo)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
 
 	t.Run("update all fields", func(t *testing.T) {
 		inp := &model.TodoItem{
@@ -163,15 +182,17 @@ func TestTodoService_UpdateTodo(t *testing.T) {
 			Title:       "title 33",
 			Description: "description 33",
 			Date:        pointer.Pointer(time.Date(2010, 12, 01, 0, 0, 0, 0, time.UTC)),
-			Status:      "complete",
+			Status:      "in_progress",
 		}
 		inpDto := &dto.TodoItem{
 			ID:          33,
 			Title:       "title 33",
 			Description: "description 33",
 			Date:        pointer.Pointer(time.Date(2010, 12, 01, 0, 0, 0, 0, time.UTC)),
-			Status:      "complete",
+			TimeZone:    "UTC",
+			Status:      "in_progress",
 		}
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(33)).Return(dto.TodoItem{ID: 33, TimeZone: "UTC", Status: "pending"}, nil)
 		repo.EXPECT().
 			UpdateTodo(
 				gomock.Any(),
@@ -198,7 +219,7 @@ func TestTodoService_ListTodos(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_todo.NewMockRepository(ctrl)
-	s := NewTodoService(repo)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
 
 	t.Run("ok case", func(t *testing.T) {
 		filter := dto.TodoFilter{
@@ -208,7 +229,9 @@ func TestTodoService_ListTodos(t *testing.T) {
 			Limit:  2,
 		}
 
-		repo.EXPECT().ListTodos(gomock.Any(), filter).Return([]dto.TodoItem{
+		expected := filter
+		expected.TimeZone = "UTC"
+		repo.EXPECT().ListTodos(gomock.Any(), expected).Return([]dto.TodoItem{
 			{
 				ID:          23,
 				Title:       "title 23",
@@ -256,4 +279,708 @@ func TestTodoService_ListTodos(t *testing.T) {
 		_, err := s.ListTodos(context.Background(), dto.TodoFilter{})
 		require.Error(t, err, sql.ErrConnDone)
 	})
+
+	t.Run("invalid search", func(t *testing.T) {
+		repo.EXPECT().ListTodos(gomock.Any(), gomock.Any()).Return(nil, int64(0), search.Errorf(12, "unknown field"))
+		_, err := s.ListTodos(context.Background(), dto.TodoFilter{Query: "status:done tag:work"})
+
+		var e *errs.Error
+		require.ErrorAs(t, err, &e)
+		require.ErrorIs(t, err, errs.ErrValidation)
+		require.Equal(t, []errs.FieldViolation{{
+			Field:    "q",
+			Code:     errs.ViolationInvalid,
+			Message:  "unknown field at position 12",
+			Position: 12,
+		}}, e.Violations)
+	})
+}
+
+func TestTodoService_ProjectAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	ctx := auth.WithPrincipal(context.Background(), model.Principal{
+		UserID: 5,
+		Scopes: []model.Scope{model.ScopeWrite},
+	})
+	shared := dto.TodoItem{ID: 10, Title: "shared", Status: "pending", ProjectID: pointer.Pointer(int64(2))}
+
+	t.Run("viewer reads todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(5)).Return("viewer", nil)
+
+		res, err := s.GetTodoByID(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), res.ID)
+	})
+
+	t.Run("viewer can not update todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(5)).Return("viewer", nil)
+
+		err := s.UpdateTodo(ctx, &model.TodoItem{ID: 10, Title: "changed"})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("viewer is not authorized as editor", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil).Times(2)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(5)).Return("viewer", nil).Times(2)
+
+		require.NoError(t, s.AuthorizeTodo(ctx, 10, model.ProjectRoleViewer))
+		require.ErrorIs(t, s.AuthorizeTodo(ctx, 10, model.ProjectRoleEditor), ErrForbidden)
+	})
+
+	t.Run("editor deletes todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(5)).Return("editor", nil)
+		repo.EXPECT().DeleteTodo(gomock.Any(), int64(10)).Return(nil)
+
+		require.NoError(t, s.DeleteTodo(ctx, 10))
+	})
+
+	t.Run("non-member does not see todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(5)).Return("", sql.ErrNoRows)
+
+		_, err := s.GetTodoByID(ctx, 10)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("non-member can not create todo in project", func(t *testing.T) {
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(3), int64(5)).Return("", sql.ErrNoRows)
+
+		err := s.CreateTodo(ctx, &model.TodoItem{
+			Title:     "title",
+			Date:      pointer.Pointer(time.Date(2023, time.February, 11, 0, 0, 0, 0, time.UTC)),
+			Status:    "pending",
+			ProjectID: pointer.Pointer(int64(3)),
+		})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("list is limited to member projects", func(t *testing.T) {
+		repo.EXPECT().ListTodos(gomock.Any(), dto.TodoFilter{VisibleTo: 5, TimeZone: "UTC"}).Return([]dto.TodoItem{shared}, int64(1), nil)
+
+		res, err := s.ListTodos(ctx, dto.TodoFilter{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.TotalItems)
+	})
+
+	t.Run("anonymous caller reaches only todos outside of projects", func(t *testing.T) {
+		anonymous := auth.WithPrincipal(context.Background(), model.Principal{
+			Scopes:    []model.Scope{model.ScopeWrite},
+			Anonymous: true,
+		})
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil).Times(2)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(2), int64(-1)).Return("", sql.ErrNoRows).Times(2)
+
+		_, err := s.GetTodoByID(anonymous, 10)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, s.DeleteTodo(anonymous, 10), ErrNotFound)
+
+		repo.EXPECT().ListTodos(gomock.Any(), dto.TodoFilter{VisibleTo: -1, TimeZone: "UTC"}).Return([]dto.TodoItem{{ID: 11}}, int64(1), nil)
+		_, err = s.ListTodos(anonymous, dto.TodoFilter{})
+		require.NoError(t, err)
+	})
+
+	t.Run("admin is not restricted", func(t *testing.T) {
+		admin := auth.WithPrincipal(context.Background(), model.Principal{
+			UserID: 5,
+			Scopes: []model.Scope{model.ScopeAdmin},
+		})
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(10)).Return(shared, nil)
+
+		_, err := s.GetTodoByID(admin, 10)
+		require.NoError(t, err)
+	})
+}
+
+func TestTodoService_MoveTodo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+
+	t.Run("move after another todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(2)).Return(dto.TodoItem{ID: 2, Position: "i"}, nil)
+		repo.EXPECT().MoveTodo(gomock.Any(), int64(1), int64(2), true).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Position: "r"}, nil)
+
+		res, err := s.MoveTodo(context.Background(), 1, model.TodoMove{AfterID: 2})
+		require.NoError(t, err)
+		require.Equal(t, "r", res.Position)
+	})
+
+	t.Run("exactly one neighbour", func(t *testing.T) {
+		_, err := s.MoveTodo(context.Background(), 1, model.TodoMove{})
+		require.ErrorIs(t, err, ErrValidation)
+
+		_, err = s.MoveTodo(context.Background(), 1, model.TodoMove{BeforeID: 2, AfterID: 3})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("relative to itself", func(t *testing.T) {
+		_, err := s.MoveTodo(context.Background(), 1, model.TodoMove{BeforeID: 1})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown neighbour", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(404)).Return(dto.TodoItem{}, sql.ErrNoRows)
+
+		_, err := s.MoveTodo(context.Background(), 1, model.TodoMove{BeforeID: 404})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(2)).Return(dto.TodoItem{ID: 2}, nil)
+		repo.EXPECT().MoveTodo(gomock.Any(), int64(404), int64(2), false).Return(sql.ErrNoRows)
+
+		_, err := s.MoveTodo(context.Background(), 404, model.TodoMove{BeforeID: 2})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	t.Run("date is the local day of the due time", func(t *testing.T) {
+		item := &model.TodoItem{
+			DueAt:    pointer.Pointer(time.Date(2026, 10, 19, 22, 30, 0, 0, time.UTC)),
+			TimeZone: "Europe/Moscow",
+		}
+		fields := schedule(item, model.TodoItem{}, []string{model.TodoDueAtField})
+		require.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), *item.Date)
+		require.Equal(t, []string{model.TodoDueAtField, model.TodoDateField}, fields)
+	})
+
+	t.Run("new date keeps the local time of day", func(t *testing.T) {
+		current := model.TodoItem{
+			DueAt:    pointer.Pointer(time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)),
+			TimeZone: "Europe/Moscow",
+		}
+		item := current
+		item.Date = pointer.Pointer(time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC))
+		fields := schedule(&item, current, []string{model.TodoDateField})
+		require.Equal(t, time.Date(2026, 10, 25, 6, 0, 0, 0, time.UTC), item.DueAt.UTC())
+		require.Equal(t, []string{model.TodoDateField, model.TodoDueAtField}, fields)
+		require.Equal(t, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), *item.Date)
+	})
+
+	t.Run("date sent with an offset stays the same day", func(t *testing.T) {
+		item := &model.TodoItem{Date: pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.FixedZone("MSK", 3*3600)))}
+		schedule(item, model.TodoItem{}, nil)
+		require.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), *item.Date)
+		require.Nil(t, item.DueAt)
+	})
+}
+
+func TestTodoService_UpdateTodo_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	date := pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	t.Run("completion is recorded", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Title: "todo", Date: date, Status: "in_progress"}, nil)
+		repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), []string{model.TodoStatusField, model.TodoCompletedAtField}).
+			DoAndReturn(func(ctx context.Context, item *dto.TodoItem, fields []string) error {
+				require.NotNil(t, item.CompletedAt)
+				return nil
+			})
+
+		require.NoError(t, s.UpdateTodo(context.Background(), &model.TodoItem{ID: 1, Status: "completed"}))
+	})
+
+	t.Run("reopening clears completion", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{
+			ID:          1,
+			Title:       "todo",
+			Date:        date,
+			Status:      "completed",
+			CompletedAt: pointer.Pointer(time.Now()),
+		}, nil)
+		repo.EXPECT().UpdateTodo(gomock.Any(), &dto.TodoItem{ID: 1, Title: "todo", Date: date, Status: "pending"}, []string{model.TodoStatusField, model.TodoCompletedAtField}).
+			Return(nil)
+
+		require.NoError(t, s.UpdateTodo(context.Background(), &model.TodoItem{ID: 1, Status: "pending"}))
+	})
+
+	t.Run("transition not allowed", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Title: "todo", Date: date, Status: "blocked"}, nil)
+
+		err := s.UpdateTodo(context.Background(), &model.TodoItem{ID: 1, Status: "completed"})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		err := s.UpdateTodo(context.Background(), &model.TodoItem{ID: 1, Status: "Done"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTodoService_PatchTodo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	date := pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+	current := dto.TodoItem{
+		ID:          1,
+		Title:       "todo",
+		Description: "description",
+		Date:        date,
+		DueAt:       pointer.Pointer(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)),
+		TimeZone:    "UTC",
+		Status:      "pending",
+	}
+
+	t.Run("clear fields", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(current, nil)
+		repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), []string{model.TodoDescriptionField, model.TodoDueAtField}).
+			DoAndReturn(func(ctx context.Context, item *dto.TodoItem, fields []string) error {
+				require.Empty(t, item.Description)
+				require.Nil(t, item.DueAt)
+				require.Equal(t, date, item.Date)
+				return nil
+			})
+
+		item := &model.TodoItem{ID: 1}
+		require.NoError(t, s.PatchTodo(context.Background(), item, []string{model.TodoDescriptionField, model.TodoDueAtField}))
+		require.Equal(t, "todo", item.Title)
+	})
+
+	t.Run("required field can not be cleared", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(current, nil)
+
+		err := s.PatchTodo(context.Background(), &model.TodoItem{ID: 1}, []string{model.TodoTitleField})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(404)).Return(dto.TodoItem{}, sql.ErrNoRows)
+
+		err := s.PatchTodo(context.Background(), &model.TodoItem{ID: 404}, []string{model.TodoTitleField})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestTodoService_ReplaceTodo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	date := pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	t.Run("omitted fields are cleared", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{
+			ID:          1,
+			Title:       "todo",
+			Description: "description",
+			Date:        date,
+			TimeZone:    "Europe/Moscow",
+			Status:      "pending",
+		}, nil)
+		repo.EXPECT().UpdateTodo(gomock.Any(), &dto.TodoItem{
+			ID:       1,
+			Title:    "replaced",
+			Date:     date,
+			TimeZone: "UTC",
+			Status:   "pending",
+		}, model.TodoWritableFields).Return(nil)
+
+		item := &model.TodoItem{ID: 1, Title: "replaced", Date: date, Status: "pending"}
+		require.NoError(t, s.ReplaceTodo(context.Background(), item))
+	})
+
+	t.Run("invalid todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Title: "todo", Date: date, Status: "pending"}, nil)
+
+		err := s.ReplaceTodo(context.Background(), &model.TodoItem{ID: 1, Date: date, Status: "pending"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTodoService_AddDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+
+	t.Run("blocked by another todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(2)).Return(dto.TodoItem{ID: 2}, nil)
+		repo.EXPECT().AddDependency(gomock.Any(), dto.TodoDependency{TodoID: 1, BlockerID: 2}).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Blocked: true}, nil)
+		repo.EXPECT().ListLinkedTodos(gomock.Any(), int64(1)).Return([]dto.TodoItem{{ID: 2}}, nil, nil)
+
+		res, err := s.AddDependency(context.Background(), 1, model.TodoLink{BlockedBy: 2})
+		require.NoError(t, err)
+		require.Len(t, res.BlockedBy, 1)
+		require.Empty(t, res.Blocks)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(3)).Return(dto.TodoItem{ID: 3}, nil)
+		repo.EXPECT().AddDependency(gomock.Any(), dto.TodoDependency{TodoID: 3, BlockerID: 1}).Return(model.ErrDependencyCycle)
+
+		_, err := s.AddDependency(context.Background(), 1, model.TodoLink{Blocks: 3})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("itself", func(t *testing.T) {
+		_, err := s.AddDependency(context.Background(), 1, model.TodoLink{BlockedBy: 1})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(404)).Return(dto.TodoItem{}, sql.ErrNoRows)
+
+		_, err := s.AddDependency(context.Background(), 1, model.TodoLink{BlockedBy: 404})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTodoService_UpdateTodo_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	date := pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).
+		Return(dto.TodoItem{ID: 1, Title: "todo", Date: date, Status: "in_progress", Blocked: true}, nil)
+
+	err := s.UpdateTodo(context.Background(), &model.TodoItem{ID: 1, Status: "completed"})
+	require.ErrorIs(t, err, ErrConflict)
+}
+
+func TestTodoService_OrderTodos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+
+	t.Run("blockers first", func(t *testing.T) {
+		repo.EXPECT().GetTodosByIDs(gomock.Any(), []int64{1, 2}).Return([]dto.TodoItem{{ID: 1}, {ID: 2}}, nil)
+		repo.EXPECT().ListDependencies(gomock.Any(), []int64{1, 2}).Return([]dto.TodoDependency{{TodoID: 1, BlockerID: 2}}, nil)
+
+		res, err := s.OrderTodos(context.Background(), []int64{1, 2})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res[0].ID)
+		require.Equal(t, int64(1), res[1].ID)
+	})
+
+	t.Run("unknown todo", func(t *testing.T) {
+		repo.EXPECT().GetTodosByIDs(gomock.Any(), []int64{1, 404}).Return([]dto.TodoItem{{ID: 1}}, nil)
+
+		_, err := s.OrderTodos(context.Background(), []int64{1, 404})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		repo.EXPECT().GetTodosByIDs(gomock.Any(), []int64{1, 2}).Return([]dto.TodoItem{{ID: 1}, {ID: 2}}, nil)
+		repo.EXPECT().ListDependencies(gomock.Any(), []int64{1, 2}).Return([]dto.TodoDependency{
+			{TodoID: 1, BlockerID: 2},
+			{TodoID: 2, BlockerID: 1},
+		}, nil)
+
+		_, err := s.OrderTodos(context.Background(), []int64{1, 2})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+func TestTodoService_UpdateChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	date := pointer.Pointer(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+	watering := dto.TodoItem{ID: 1, Title: "water the flowers", Date: date, Status: "in_progress", AutoComplete: true}
+	items := []dto.ChecklistItem{
+		{ID: 11, TodoID: 1, Title: "take the watering can", Checked: true},
+		{ID: 12, TodoID: 1, Title: "fill it", Checked: true},
+		{ID: 13, TodoID: 1, Title: "water", Checked: true},
+	}
+
+	t.Run("checking the last item completes the todo", func(t *testing.T) {
+		repo.EXPECT().UpdateChecklistItem(gomock.Any(), &dto.ChecklistItem{ID: 13, TodoID: 1, Checked: true}, []string{model.ChecklistCheckedField}).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(watering, nil).Times(2)
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(items, nil)
+		repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), []string{model.TodoStatusField, model.TodoCompletedAtField}).
+			DoAndReturn(func(ctx context.Context, item *dto.TodoItem, fields []string) error {
+				require.Equal(t, "completed", item.Status)
+				require.NotNil(t, item.CompletedAt)
+				return nil
+			})
+
+		res, err := s.UpdateChecklistItem(context.Background(), 1, 13, model.ChecklistItemPatch{Checked: pointer.Pointer(true)})
+		require.NoError(t, err)
+		require.Equal(t, model.TodoStatus("completed"), res.Status)
+		require.Equal(t, 3, res.Checked)
+		require.Equal(t, "water", res.Items[2].Title)
+	})
+
+	t.Run("blocked todo is left as it is", func(t *testing.T) {
+		blocked := watering
+		blocked.Blocked = true
+		repo.EXPECT().UpdateChecklistItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(blocked, nil).Times(2)
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(items, nil)
+
+		res, err := s.UpdateChecklistItem(context.Background(), 1, 13, model.ChecklistItemPatch{Checked: pointer.Pointer(true)})
+		require.NoError(t, err)
+		require.Equal(t, model.TodoStatus("in_progress"), res.Status)
+	})
+
+	t.Run("without auto_complete", func(t *testing.T) {
+		manual := watering
+		manual.AutoComplete = false
+		repo.EXPECT().UpdateChecklistItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(manual, nil)
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(items, nil)
+
+		res, err := s.UpdateChecklistItem(context.Background(), 1, 13, model.ChecklistItemPatch{Checked: pointer.Pointer(true)})
+		require.NoError(t, err)
+		require.Equal(t, model.TodoStatus("in_progress"), res.Status)
+		require.True(t, res.Done())
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		repo.EXPECT().UpdateChecklistItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := s.UpdateChecklistItem(context.Background(), 1, 404, model.ChecklistItemPatch{Title: pointer.Pointer("x")})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("empty patch", func(t *testing.T) {
+		_, err := s.UpdateChecklistItem(context.Background(), 1, 13, model.ChecklistItemPatch{})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTodoService_AddChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+
+	t.Run("appended", func(t *testing.T) {
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return([]dto.ChecklistItem{}, nil)
+		repo.EXPECT().AddChecklistItem(gomock.Any(), &dto.ChecklistItem{TodoID: 1, Title: "fill it"}).
+			DoAndReturn(func(ctx context.Context, item *dto.ChecklistItem) error {
+				item.ID, item.Position = 12, 1
+				return nil
+			})
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, Status: "pending"}, nil)
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return([]dto.ChecklistItem{{ID: 12, TodoID: 1, Title: "fill it", Position: 1}}, nil)
+
+		item := &model.ChecklistItem{Title: "fill it"}
+		res, err := s.AddChecklistItem(context.Background(), 1, item)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), item.ID)
+		require.Equal(t, 1, res.Total)
+		require.False(t, res.Done())
+	})
+
+	t.Run("too many items", func(t *testing.T) {
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(make([]dto.ChecklistItem, model.MaxChecklistItems), nil)
+
+		_, err := s.AddChecklistItem(context.Background(), 1, &model.ChecklistItem{Title: "one more"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("viewer may not change the checklist", func(t *testing.T) {
+		ctx := auth.WithPrincipal(context.Background(), model.Principal{UserID: 5, Scopes: []model.Scope{model.ScopeWrite}})
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1, ProjectID: pointer.Pointer(int64(7))}, nil)
+		repo.EXPECT().GetMemberRole(gomock.Any(), int64(7), int64(5)).Return(string(model.ProjectRoleViewer), nil)
+
+		_, err := s.AddChecklistItem(ctx, 1, &model.ChecklistItem{Title: "fill it"})
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestTodoService_ReorderChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	items := []dto.ChecklistItem{{ID: 11, TodoID: 1}, {ID: 12, TodoID: 1}}
+
+	t.Run("every item once", func(t *testing.T) {
+		for _, ids := range [][]int64{{11}, {11, 11}, {11, 13}} {
+			repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(items, nil)
+			_, err := s.ReorderChecklist(context.Background(), 1, model.ChecklistOrder{IDs: ids})
+			require.ErrorIs(t, err, ErrValidation)
+		}
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return(items, nil)
+		repo.EXPECT().ReorderChecklist(gomock.Any(), int64(1), []int64{12, 11}).Return(nil)
+		repo.EXPECT().GetTodoByID(gomock.Any(), int64(1)).Return(dto.TodoItem{ID: 1}, nil)
+		repo.EXPECT().ListChecklist(gomock.Any(), int64(1)).Return([]dto.ChecklistItem{items[1], items[0]}, nil)
+
+		res, err := s.ReorderChecklist(context.Background(), 1, model.ChecklistOrder{IDs: []int64{12, 11}})
+		require.NoError(t, err)
+		require.Equal(t, int64(12), res.Items[0].ID)
+	})
+}
+
+func TestTodoService_GetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+
+	repo.EXPECT().TodoStats(gomock.Any(), dto.TodoFilter{TimeZone: "UTC"}).Return([]dto.TodoStatusStats{
+		{Status: "completed", Total: 2, Completed: 2, CompletionSeconds: 300},
+		{Status: "pending", Total: 3, Overdue: 1},
+	}, nil)
+
+	res, err := s.GetStats(context.Background(), dto.TodoFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), res.Total)
+	require.Equal(t, int64(1), res.Overdue)
+	require.Equal(t, int64(150), *res.AvgCompletionSeconds)
+	require.Equal(t, int64(3), res.ByStatus["pending"])
+	require.Equal(t, int64(0), res.ByStatus["cancelled"])
+}
+
+func TestTodoService_GetSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), loc)
+
+	t.Run("weeks with running totals", func(t *testing.T) {
+		// Wednesday to Tuesday two weeks later
+		from := time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+		repo.EXPECT().TodoSeries(gomock.Any(), gomock.Any(),
+			time.Date(2026, 10, 5, 0, 0, 0, 0, loc), time.Date(2026, 10, 26, 0, 0, 0, 0, loc),
+		).Return([]dto.TodoSeriesCount{
+			{Created: 10, Completed: 4},
+			{Period: pointer.Pointer("2026-10-12"), Created: 3, Completed: 5},
+		}, nil)
+
+		res, err := s.GetSeries(ctx, dto.TodoSeriesFilter{From: &from, To: &to, Interval: "week"})
+		require.NoError(t, err)
+		require.Equal(t, "2026-10-05", res.From)
+		require.Equal(t, "2026-10-25", res.To)
+		require.Equal(t, []model.TodoSeriesPoint{
+			{Period: "2026-10-05", TotalCreated: 10, TotalCompleted: 4},
+			{Period: "2026-10-12", Created: 3, Completed: 5, TotalCreated: 13, TotalCompleted: 9},
+			{Period: "2026-10-19", TotalCreated: 13, TotalCompleted: 9},
+		}, res.Points)
+	})
+
+	t.Run("months", func(t *testing.T) {
+		from := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		repo.EXPECT().TodoSeries(gomock.Any(), gomock.Any(), gomock.Any(), time.Date(2026, 4, 1, 0, 0, 0, 0, loc)).Return(nil, nil)
+
+		res, err := s.GetSeries(ctx, dto.TodoSeriesFilter{From: &from, To: &to, Interval: "month"})
+		require.NoError(t, err)
+		require.Len(t, res.Points, 3)
+		require.Equal(t, "2026-02-01", res.Points[1].Period)
+	})
+
+	t.Run("too many periods", func(t *testing.T) {
+		from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := s.GetSeries(ctx, dto.TodoSeriesFilter{From: &from})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := s.GetSeries(ctx, dto.TodoSeriesFilter{From: &from, To: &to})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTodoService_GetAgenda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_todo.NewMockRepository(ctrl)
+	s := NewTodoService(repo, model.DefaultTodoTransitions())
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), loc)
+	day := func(d int) *time.Time {
+		return pointer.Pointer(time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	t.Run("grouped by local day with repetitions", func(t *testing.T) {
+		repo.EXPECT().AgendaTodos(gomock.Any(), dto.TodoFilter{TimeZone: "Europe/Moscow"}, *day(19), *day(21)).Return([]dto.TodoItem{
+			// 06:00 in Moscow, every Monday and Wednesday since the week before
+			{ID: 1, Title: "standup", Date: day(12), DueAt: pointer.Pointer(time.Date(2026, 10, 12, 3, 0, 0, 0, time.UTC)),
+				TimeZone: "Europe/Moscow", Status: "pending", Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE"},
+			// 23:30 UTC on the 19th is the 20th in Moscow
+			{ID: 2, Title: "deploy", Date: day(19), DueAt: pointer.Pointer(time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC)),
+				TimeZone: "UTC", Status: "pending"},
+			{ID: 3, Title: "plan", Date: day(20), Status: "pending"},
+			// closed recurring todos do not repeat
+			{ID: 4, Title: "done", Date: day(12), Status: "completed", Recurrence: "FREQ=DAILY"},
+		}, nil)
+
+		res, err := s.GetAgenda(ctx, dto.AgendaFilter{From: day(19), To: day(21)})
+		require.NoError(t, err)
+		require.Equal(t, int64(4), res.Total)
+		require.Len(t, res.Days, 3)
+
+		require.Equal(t, "2026-10-19", res.Days[0].Date)
+		require.Equal(t, int64(1), res.Days[0].Count)
+		standup := res.Days[0].Items[0]
+		require.True(t, standup.Occurrence)
+		require.Equal(t, *day(19), *standup.Date)
+		require.Equal(t, time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), standup.DueAt.UTC())
+
+		require.Equal(t, []int64{3, 2}, []int64{res.Days[1].Items[0].ID, res.Days[1].Items[1].ID})
+		require.Equal(t, int64(1), res.Days[2].Items[0].ID)
+	})
+
+	t.Run("dates read from the database", func(t *testing.T) {
+		// lib/pq decodes DATE columns with a location of its own
+		date := func(d int) *time.Time {
+			return pointer.Pointer(time.Date(2026, 10, d, 0, 0, 0, 0, time.FixedZone("", 0)))
+		}
+		repo.EXPECT().AgendaTodos(gomock.Any(), dto.TodoFilter{TimeZone: "Europe/Moscow"}, *day(19), *day(21)).Return([]dto.TodoItem{
+			{ID: 1, Title: "water the flowers", Date: date(12), TimeZone: "UTC", Status: "pending", Recurrence: "FREQ=WEEKLY"},
+			{ID: 2, Title: "plan", Date: date(20), Status: "pending"},
+		}, nil)
+
+		res, err := s.GetAgenda(ctx, dto.AgendaFilter{From: day(19), To: day(21)})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res.Total)
+		require.Equal(t, int64(1), res.Days[0].Items[0].ID)
+		require.Equal(t, int64(2), res.Days[1].Items[0].ID)
+	})
+
+	t.Run("period too long", func(t *testing.T) {
+		_, err := s.GetAgenda(ctx, dto.AgendaFilter{From: day(1), To: pointer.Pointer(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		_, err := s.GetAgenda(ctx, dto.AgendaFilter{From: day(2), To: day(1)})
+		require.ErrorIs(t, err, ErrValidation)
+	})
 }
@@ -0,0 +1,162 @@
+package todo
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+var tracer = otel.Tracer("todo-list/internal/service/todo")
+
+// TracingService decorates Service with a span per method call.
+type TracingService struct {
+	next Service
+}
+
+func NewTracingService(next Service) *TracingService {
+	return &TracingService{
+		next: next,
+	}
+}
+
+func (t *TracingService) start(ctx context.Context, operation string) (context.Context, func(err error)) {
+	ctx, span := tracer.Start(ctx, "TodoService."+operation, trace.WithSpanKind(trace.SpanKindInternal))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			if outcome(err) == outcomeInternal {
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}
+		span.End()
+	}
+}
+
+func (t *TracingService) CreateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	ctx, done := t.start(ctx, "CreateTodo")
+	defer func() { done(err) }()
+	return t.next.CreateTodo(ctx, item)
+}
+
+func (t *TracingService) GetTodoByID(ctx context.Context, id int64) (_ model.TodoItem, err error) {
+	ctx, done := t.start(ctx, "GetTodoByID")
+	defer func() { done(err) }()
+	return t.next.GetTodoByID(ctx, id)
+}
+
+func (t *TracingService) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) (err error) {
+	ctx, done := t.start(ctx, "AuthorizeTodo")
+	defer func() { done(err) }()
+	return t.next.AuthorizeTodo(ctx, id, role)
+}
+
+func (t *TracingService) UpdateTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	ctx, done := t.start(ctx, "UpdateTodo")
+	defer func() { done(err) }()
+	return t.next.UpdateTodo(ctx, item)
+}
+
+func (t *TracingService) ReplaceTodo(ctx context.Context, item *model.TodoItem) (err error) {
+	ctx, done := t.start(ctx, "ReplaceTodo")
+	defer func() { done(err) }()
+	return t.next.ReplaceTodo(ctx, item)
+}
+
+func (t *TracingService) PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) (err error) {
+	ctx, done := t.start(ctx, "PatchTodo")
+	defer func() { done(err) }()
+	return t.next.PatchTodo(ctx, item, fields)
+}
+
+func (t *TracingService) DeleteTodo(ctx context.Context, id int64) (err error) {
+	ctx, done := t.start(ctx, "DeleteTodo")
+	defer func() { done(err) }()
+	return t.next.DeleteTodo(ctx, id)
+}
+
+func (t *TracingService) ListTodos(ctx context.Context, filter dto.TodoFilter) (_ model.TodoPagination, err error) {
+	ctx, done := t.start(ctx, "ListTodos")
+	defer func() { done(err) }()
+	return t.next.ListTodos(ctx, filter)
+}
+
+func (t *TracingService) MoveTodo(ctx context.Context, id int64, move model.TodoMove) (_ model.TodoItem, err error) {
+	ctx, done := t.start(ctx, "MoveTodo")
+	defer func() { done(err) }()
+	return t.next.MoveTodo(ctx, id, move)
+}
+
+func (t *TracingService) ListDependencies(ctx context.Context, id int64) (_ model.TodoDependencies, err error) {
+	ctx, done := t.start(ctx, "ListDependencies")
+	defer func() { done(err) }()
+	return t.next.ListDependencies(ctx, id)
+}
+
+func (t *TracingService) AddDependency(ctx context.Context, id int64, link model.TodoLink) (_ model.TodoDependencies, err error) {
+	ctx, done := t.start(ctx, "AddDependency")
+	defer func() { done(err) }()
+	return t.next.AddDependency(ctx, id, link)
+}
+
+func (t *TracingService) RemoveDependency(ctx context.Context, id, blockerID int64) (err error) {
+	ctx, done := t.start(ctx, "RemoveDependency")
+	defer func() { done(err) }()
+	return t.next.RemoveDependency(ctx, id, blockerID)
+}
+
+func (t *TracingService) OrderTodos(ctx context.Context, ids []int64) (_ []model.TodoItem, err error) {
+	ctx, done := t.start(ctx, "OrderTodos")
+	defer func() { done(err) }()
+	return t.next.OrderTodos(ctx, ids)
+}
+
+func (t *TracingService) GetChecklist(ctx context.Context, todoID int64) (_ model.Checklist, err error) {
+	ctx, done := t.start(ctx, "GetChecklist")
+	defer func() { done(err) }()
+	return t.next.GetChecklist(ctx, todoID)
+}
+
+func (t *TracingService) AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (_ model.Checklist, err error) {
+	ctx, done := t.start(ctx, "AddChecklistItem")
+	defer func() { done(err) }()
+	return t.next.AddChecklistItem(ctx, todoID, item)
+}
+
+func (t *TracingService) UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (_ model.Checklist, err error) {
+	ctx, done := t.start(ctx, "UpdateChecklistItem")
+	defer func() { done(err) }()
+	return t.next.UpdateChecklistItem(ctx, todoID, id, patch)
+}
+
+func (t *TracingService) DeleteChecklistItem(ctx context.Context, todoID, id int64) (err error) {
+	ctx, done := t.start(ctx, "DeleteChecklistItem")
+	defer func() { done(err) }()
+	return t.next.DeleteChecklistItem(ctx, todoID, id)
+}
+
+func (t *TracingService) ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (_ model.Checklist, err error) {
+	ctx, done := t.start(ctx, "ReorderChecklist")
+	defer func() { done(err) }()
+	return t.next.ReorderChecklist(ctx, todoID, order)
+}
+
+func (t *TracingService) GetStats(ctx context.Context, filter dto.TodoFilter) (_ model.TodoStats, err error) {
+	ctx, done := t.start(ctx, "GetStats")
+	defer func() { done(err) }()
+	return t.next.GetStats(ctx, filter)
+}
+
+func (t *TracingService) GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (_ model.TodoSeries, err error) {
+	ctx, done := t.start(ctx, "GetSeries")
+	defer func() { done(err) }()
+	return t.next.GetSeries(ctx, filter)
+}
+
+func (t *TracingService) GetAgenda(ctx context.Context, filter dto.AgendaFilter) (_ model.Agenda, err error) {
+	ctx, done := t.start(ctx, "GetAgenda")
+	defer func() { done(err) }()
+	return t.next.GetAgenda(ctx, filter)
+}
@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+)
+
+type (
+	Service interface {
+		CreateUser(ctx context.Context, user *model.User) error
+		GetUserByID(ctx context.Context, id int64) (model.User, error)
+		UpdateUser(ctx context.Context, user *model.User) error
+		ListUsers(ctx context.Context) ([]model.User, error)
+	}
+
+	Repository interface {
+		CreateUser(ctx context.Context, user *dto.User) error
+		GetUserByID(ctx context.Context, id int64) (dto.User, error)
+		UpdateUser(ctx context.Context, user *dto.User) error
+		ListUsers(ctx context.Context) ([]dto.User, error)
+	}
+)
+
+var (
+	ErrValidation = errs.ErrValidation
+	ErrNotFound   = errs.ErrNotFound
+	ErrConflict   = errs.ErrConflict
+)
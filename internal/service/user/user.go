@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/converter"
+)
+
+type UserService struct {
+	UserRepo Repository
+}
+
+func NewUserService(ur Repository) *UserService {
+	return &UserService{
+		UserRepo: ur,
+	}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, user *model.User) error {
+	user.Email = model.NormalizeEmail(user.Email)
+	user.Username = model.NormalizeUsername(user.Username)
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	userDto := converter.ConvertUserToDTO(*user)
+	if err := s.UserRepo.CreateUser(ctx, &userDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.Conflict("user with email " + user.Email + " already exists")
+		}
+		if errors.Is(err, ErrConflict) {
+			return usernameTaken(user.Username)
+		}
+		return err
+	}
+
+	*user = converter.ConvertUserToModel(userDto)
+	return nil
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id int64) (model.User, error) {
+	if id <= 0 {
+		return model.User{}, errs.Validation(errs.FieldViolation{
+			Field:   "id",
+			Code:    errs.ViolationInvalid,
+			Message: "id must be positive",
+		})
+	}
+
+	u, err := s.UserRepo.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.User{}, ErrNotFound
+		}
+		return model.User{}, err
+	}
+
+	return converter.ConvertUserToModel(u), nil
+}
+
+func usernameTaken(username string) error {
+	return errs.Conflict("username " + username + " is already taken")
+}
+
+// UpdateUser changes the name, the username and the time zone of the user, the email is kept.
+func (s *UserService) UpdateUser(ctx context.Context, user *model.User) error {
+	user.Username = model.NormalizeUsername(user.Username)
+	if err := user.ValidateUpdate(); err != nil {
+		return err
+	}
+
+	userDto := converter.ConvertUserToDTO(*user)
+	userDto.Email = ""
+	if err := s.UserRepo.UpdateUser(ctx, &userDto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if errors.Is(err, ErrConflict) {
+			return usernameTaken(user.Username)
+		}
+		return err
+	}
+
+	*user = converter.ConvertUserToModel(userDto)
+	return nil
+}
+
+func (s *UserService) ListUsers(ctx context.Context) ([]model.User, error) {
+	users, err := s.UserRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ConvertUsersToModels(users), nil
+}
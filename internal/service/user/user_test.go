@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/errs"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+	mock_user "todo-list/pkg/mocks/service/user"
+)
+
+func TestUserService_CreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_user.NewMockRepository(ctrl)
+	s := NewUserService(repo)
+
+	t.Run("email is normalized", func(t *testing.T) {
+		repo.EXPECT().CreateUser(gomock.Any(), &dto.User{Email: "ann@example.com", Name: "Ann"}).
+			DoAndReturn(func(ctx context.Context, u *dto.User) error {
+				u.ID = 1
+				return nil
+			})
+
+		u := &model.User{Email: " Ann@Example.com ", Name: "Ann"}
+		require.NoError(t, s.CreateUser(context.Background(), u))
+		require.Equal(t, model.User{ID: 1, Email: "ann@example.com", Name: "Ann"}, *u)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		err := s.CreateUser(context.Background(), &model.User{Email: "ann", Name: "Ann"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		repo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := s.CreateUser(context.Background(), &model.User{Email: "ann@example.com", Name: "Ann"})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+func TestUserService_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_user.NewMockRepository(ctrl)
+	s := NewUserService(repo)
+
+	t.Run("time zone", func(t *testing.T) {
+		repo.EXPECT().UpdateUser(gomock.Any(), &dto.User{ID: 1, TimeZone: "Europe/Berlin"}).
+			DoAndReturn(func(ctx context.Context, u *dto.User) error {
+				u.Email, u.Name = "ann@example.com", "Ann"
+				return nil
+			})
+
+		u := &model.User{ID: 1, Email: "bob@example.com", TimeZone: "Europe/Berlin"}
+		require.NoError(t, s.UpdateUser(context.Background(), u))
+		require.Equal(t, "ann@example.com", u.Email)
+	})
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		err := s.UpdateUser(context.Background(), &model.User{ID: 1, TimeZone: "Berlin"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("username is normalized", func(t *testing.T) {
+		repo.EXPECT().UpdateUser(gomock.Any(), &dto.User{ID: 1, Username: pointer.Pointer("ann")}).Return(nil)
+
+		require.NoError(t, s.UpdateUser(context.Background(), &model.User{ID: 1, Username: "@Ann"}))
+	})
+
+	t.Run("username taken", func(t *testing.T) {
+		repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: users_username_key", errs.ErrConflict))
+
+		err := s.UpdateUser(context.Background(), &model.User{ID: 1, Username: "ann"})
+		require.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("invalid username", func(t *testing.T) {
+		err := s.UpdateUser(context.Background(), &model.User{ID: 1, Username: "ann smith"})
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
@@ -0,0 +1,56 @@
+// Package timezone carries the time zone of the caller through the request context.
+package timezone
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// Header selects the time zone of a request, the Query parameter takes precedence.
+	Header = "X-Time-Zone"
+	Query  = "tz"
+)
+
+var ErrUnknown = errors.New("unknown time zone")
+
+type locationKey struct{}
+
+// Load returns the location of an IANA time zone name. The empty name and "Local" are
+// rejected as they depend on the machine the server runs on.
+func Load(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrUnknown
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknown
+	}
+	return loc, nil
+}
+
+// Valid reports whether name is a known IANA time zone.
+func Valid(name string) bool {
+	_, err := Load(name)
+	return err == nil
+}
+
+// WithLocation returns a copy of ctx carrying the time zone of the caller.
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// FromContext returns the time zone of the caller, UTC when none was set.
+func FromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationKey{}).(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// Day returns midnight UTC of the calendar day t falls on in loc, the form dates are stored in.
+func Day(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
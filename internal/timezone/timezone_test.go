@@ -0,0 +1,37 @@
+package timezone
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	loc, err := Load("Asia/Tokyo")
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Tokyo", loc.String())
+
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		_, err = Load(name)
+		require.ErrorIs(t, err, ErrUnknown, name)
+	}
+}
+
+func TestDay(t *testing.T) {
+	loc, err := Load("America/New_York")
+	require.NoError(t, err)
+
+	// 02:00 UTC is still the previous evening in New York
+	at := time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Day(at, loc))
+	require.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Day(at, time.UTC))
+}
+
+func TestFromContext(t *testing.T) {
+	require.Equal(t, time.UTC, FromContext(context.Background()))
+
+	loc, err := Load("Europe/Moscow")
+	require.NoError(t, err)
+	require.Equal(t, loc, FromContext(WithLocation(context.Background(), loc)))
+}
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertAPIKeyToModel(inp dto.APIKey) model.APIKey {
+	scopes := make([]model.Scope, len(inp.Scopes))
+	for i, s := range inp.Scopes {
+		scopes[i] = model.Scope(s)
+	}
+
+	return model.APIKey{
+		ID:         inp.ID,
+		Name:       inp.Name,
+		Prefix:     inp.Prefix,
+		Scopes:     scopes,
+		UserID:     inp.UserID,
+		CreatedAt:  inp.CreatedAt,
+		LastUsedAt: inp.LastUsedAt,
+		RevokedAt:  inp.RevokedAt,
+	}
+}
+
+func ConvertAPIKeyToDTO(inp model.APIKey) dto.APIKey {
+	scopes := make([]string, len(inp.Scopes))
+	for i, s := range inp.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return dto.APIKey{
+		ID:         inp.ID,
+		Name:       inp.Name,
+		Prefix:     inp.Prefix,
+		Scopes:     scopes,
+		UserID:     inp.UserID,
+		CreatedAt:  inp.CreatedAt,
+		LastUsedAt: inp.LastUsedAt,
+		RevokedAt:  inp.RevokedAt,
+	}
+}
+
+func ConvertAPIKeysToModels(inp []dto.APIKey) []model.APIKey {
+	res := make([]model.APIKey, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertAPIKeyToModel(v)
+	}
+
+	return res
+}
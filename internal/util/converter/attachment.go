@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertAttachmentToModel(inp dto.Attachment) model.Attachment {
+	return model.Attachment{
+		ID:          inp.ID,
+		TodoID:      inp.TodoID,
+		UploaderID:  inp.UploaderID,
+		FileName:    inp.FileName,
+		ContentType: inp.ContentType,
+		Size:        inp.Size,
+		StorageKey:  inp.StorageKey,
+		CreatedAt:   inp.CreatedAt,
+	}
+}
+
+func ConvertAttachmentToDTO(inp model.Attachment) dto.Attachment {
+	return dto.Attachment{
+		ID:          inp.ID,
+		TodoID:      inp.TodoID,
+		UploaderID:  inp.UploaderID,
+		FileName:    inp.FileName,
+		ContentType: inp.ContentType,
+		Size:        inp.Size,
+		StorageKey:  inp.StorageKey,
+	}
+}
+
+func ConvertAttachmentsToModels(inp []dto.Attachment) []model.Attachment {
+	res := make([]model.Attachment, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertAttachmentToModel(v)
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertChecklistItemToModel(inp dto.ChecklistItem) model.ChecklistItem {
+	return model.ChecklistItem{
+		ID:        inp.ID,
+		TodoID:    inp.TodoID,
+		Title:     inp.Title,
+		Checked:   inp.Checked,
+		CheckedAt: inp.CheckedAt,
+		Position:  inp.Position,
+		CreatedAt: inp.CreatedAt,
+	}
+}
+
+func ConvertChecklistItemToDTO(inp model.ChecklistItem) dto.ChecklistItem {
+	return dto.ChecklistItem{
+		ID:        inp.ID,
+		TodoID:    inp.TodoID,
+		Title:     inp.Title,
+		Checked:   inp.Checked,
+		CheckedAt: inp.CheckedAt,
+		Position:  inp.Position,
+	}
+}
+
+func ConvertChecklistItemsToModels(inp []dto.ChecklistItem) []model.ChecklistItem {
+	res := make([]model.ChecklistItem, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertChecklistItemToModel(v)
+	}
+
+	return res
+}
@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertCommentToModel(inp dto.Comment) model.Comment {
+	res := model.Comment{
+		ID:        inp.ID,
+		TodoID:    inp.TodoID,
+		AuthorID:  inp.AuthorID,
+		Body:      inp.Body,
+		CreatedAt: inp.CreatedAt,
+		EditedAt:  inp.EditedAt,
+	}
+	if inp.AuthorName != nil {
+		res.AuthorName = *inp.AuthorName
+	}
+
+	return res
+}
+
+func ConvertCommentToDTO(inp model.Comment) dto.Comment {
+	return dto.Comment{
+		ID:       inp.ID,
+		TodoID:   inp.TodoID,
+		AuthorID: inp.AuthorID,
+		Body:     inp.Body,
+	}
+}
+
+func ConvertCommentsToModels(inp []dto.Comment) []model.Comment {
+	res := make([]model.Comment, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertCommentToModel(v)
+	}
+
+	return res
+}
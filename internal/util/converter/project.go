@@ -0,0 +1,108 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+	"todo-list/internal/util/pointer"
+)
+
+func ConvertProjectToModel(inp dto.Project) model.Project {
+	res := model.Project{
+		ID:             inp.ID,
+		Name:           inp.Name,
+		Color:          inp.Color,
+		Archived:       pointer.Pointer(inp.Archived),
+		Position:       pointer.Pointer(inp.Position),
+		PendingTodos:   inp.PendingTodos,
+		CompletedTodos: inp.CompletedTodos,
+		CreatedAt:      inp.CreatedAt,
+		UpdatedAt:      inp.UpdatedAt,
+	}
+	if inp.Role != nil {
+		res.Role = model.ProjectRole(*inp.Role)
+	}
+
+	return res
+}
+
+func ConvertProjectToDTO(inp model.Project) dto.Project {
+	res := dto.Project{
+		ID:    inp.ID,
+		Name:  inp.Name,
+		Color: inp.Color,
+	}
+	if inp.Archived != nil {
+		res.Archived = *inp.Archived
+	}
+	if inp.Position != nil {
+		res.Position = *inp.Position
+	}
+
+	return res
+}
+
+func ConvertProjectsToModels(inp []dto.Project) []model.Project {
+	res := make([]model.Project, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertProjectToModel(v)
+	}
+
+	return res
+}
+
+func ConvertProjectMemberToModel(inp dto.ProjectMember) model.ProjectMember {
+	return model.ProjectMember{
+		ProjectID: inp.ProjectID,
+		UserID:    inp.UserID,
+		Email:     inp.Email,
+		Name:      inp.Name,
+		Role:      model.ProjectRole(inp.Role),
+		CreatedAt: inp.CreatedAt,
+	}
+}
+
+func ConvertProjectMembersToModels(inp []dto.ProjectMember) []model.ProjectMember {
+	res := make([]model.ProjectMember, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertProjectMemberToModel(v)
+	}
+
+	return res
+}
+
+func ConvertInvitationToModel(inp dto.Invitation) model.Invitation {
+	return model.Invitation{
+		ID:          inp.ID,
+		ProjectID:   inp.ProjectID,
+		ProjectName: inp.ProjectName,
+		Email:       inp.Email,
+		Role:        model.ProjectRole(inp.Role),
+		Status:      model.InvitationStatus(inp.Status),
+		InvitedBy:   inp.InvitedBy,
+		CreatedAt:   inp.CreatedAt,
+		RespondedAt: inp.RespondedAt,
+	}
+}
+
+func ConvertInvitationToDTO(inp model.Invitation) dto.Invitation {
+	return dto.Invitation{
+		ID:        inp.ID,
+		ProjectID: inp.ProjectID,
+		Email:     inp.Email,
+		Role:      string(inp.Role),
+		Status:    string(inp.Status),
+		InvitedBy: inp.InvitedBy,
+	}
+}
+
+func ConvertInvitationsToModels(inp []dto.Invitation) []model.Invitation {
+	res := make([]model.Invitation, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertInvitationToModel(v)
+	}
+
+	return res
+}
@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertReminderToModel(inp dto.Reminder) model.Reminder {
+	res := model.Reminder{
+		ID:            inp.ID,
+		TodoID:        inp.TodoID,
+		UserID:        inp.UserID,
+		RemindAt:      inp.RemindAt,
+		OffsetSeconds: inp.OffsetSeconds,
+		DueAt:         inp.DueAt,
+		Attempts:      inp.Attempts,
+		SentAt:        inp.SentAt,
+		FailedAt:      inp.FailedAt,
+		CreatedAt:     inp.CreatedAt,
+	}
+	if inp.LastError != nil {
+		res.LastError = *inp.LastError
+	}
+
+	return res
+}
+
+func ConvertReminderToDTO(inp model.Reminder) dto.Reminder {
+	return dto.Reminder{
+		ID:            inp.ID,
+		TodoID:        inp.TodoID,
+		UserID:        inp.UserID,
+		RemindAt:      inp.RemindAt,
+		OffsetSeconds: inp.OffsetSeconds,
+	}
+}
+
+func ConvertRemindersToModels(inp []dto.Reminder) []model.Reminder {
+	res := make([]model.Reminder, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertReminderToModel(v)
+	}
+
+	return res
+}
@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"encoding/json"
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+// ConvertSavedFilterToModel decodes the stored query of the filter.
+func ConvertSavedFilterToModel(inp dto.SavedFilter) (model.SavedFilter, error) {
+	res := model.SavedFilter{
+		ID:        inp.ID,
+		Name:      inp.Name,
+		CreatedAt: inp.CreatedAt,
+		UpdatedAt: inp.UpdatedAt,
+	}
+	if err := json.Unmarshal(inp.Filter, &res.Filter); err != nil {
+		return model.SavedFilter{}, err
+	}
+
+	return res, nil
+}
+
+// ConvertSavedFilterToDTO encodes the query of the filter for storage.
+func ConvertSavedFilterToDTO(inp model.SavedFilter, userID int64) (dto.SavedFilter, error) {
+	filter, err := json.Marshal(inp.Filter)
+	if err != nil {
+		return dto.SavedFilter{}, err
+	}
+
+	return dto.SavedFilter{
+		ID:     inp.ID,
+		UserID: userID,
+		Name:   inp.Name,
+		Filter: filter,
+	}, nil
+}
+
+func ConvertSavedFiltersToModels(inp []dto.SavedFilter) ([]model.SavedFilter, error) {
+	res := make([]model.SavedFilter, len(inp))
+
+	for i, v := range inp {
+		f, err := ConvertSavedFilterToModel(v)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = f
+	}
+
+	return res, nil
+}
+
+// ConvertTodoQueryToFilter returns the list filter of the query for a page.
+func ConvertTodoQueryToFilter(inp model.TodoQuery, page dto.TodoPage) dto.TodoFilter {
+	return dto.TodoFilter{
+		Today:               inp.Today,
+		Overdue:             inp.Overdue,
+		Open:                inp.Open,
+		DueWithinDays:       inp.DueWithinDays,
+		CompletedWithinDays: inp.CompletedWithinDays,
+		Status:              inp.Status,
+		Page:                page.Page,
+		Limit:               page.Limit,
+		ProjectID:           inp.ProjectID,
+		IncludeArchived:     inp.IncludeArchived,
+		Sort:                inp.Sort,
+		Query:               inp.Query,
+	}
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertTimeEntryToModel(inp dto.TimeEntry) model.TimeEntry {
+	return model.TimeEntry{
+		ID:              inp.ID,
+		TodoID:          inp.TodoID,
+		UserID:          inp.UserID,
+		StartedAt:       inp.StartedAt,
+		EndedAt:         inp.EndedAt,
+		Note:            inp.Note,
+		DurationSeconds: inp.DurationSeconds,
+		CreatedAt:       inp.CreatedAt,
+	}
+}
+
+func ConvertTimeEntryToDTO(inp model.TimeEntry) dto.TimeEntry {
+	return dto.TimeEntry{
+		ID:        inp.ID,
+		TodoID:    inp.TodoID,
+		UserID:    inp.UserID,
+		StartedAt: inp.StartedAt,
+		EndedAt:   inp.EndedAt,
+		Note:      inp.Note,
+	}
+}
+
+func ConvertTimeEntriesToModels(inp []dto.TimeEntry) []model.TimeEntry {
+	res := make([]model.TimeEntry, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertTimeEntryToModel(v)
+	}
+
+	return res
+}
+
+func ConvertTimeReportRowsToModels(inp []dto.TimeReportRow) []model.TimeReportRow {
+	res := make([]model.TimeReportRow, len(inp))
+
+	for i, v := range inp {
+		res[i] = model.TimeReportRow{
+			Key:             v.Key,
+			Label:           v.Label,
+			TrackedSeconds:  v.TrackedSeconds,
+			EstimateMinutes: v.EstimateMinutes,
+		}
+	}
+
+	return res
+}
@@ -7,23 +7,41 @@ import (
 
 func ConvertTodoToDTO(inp model.TodoItem) dto.TodoItem {
 	return dto.TodoItem{
-		ID:          inp.ID,
-		Title:       inp.Title,
-		Description: inp.Description,
-		Date:        inp.Date,
-		Status:      string(inp.Status),
+		ID:              inp.ID,
+		Title:           inp.Title,
+		Description:     inp.Description,
+		Date:            inp.Date,
+		DueAt:           inp.DueAt,
+		TimeZone:        inp.TimeZone,
+		Status:          string(inp.Status),
+		ProjectID:       inp.ProjectID,
+		CompletedAt:     inp.CompletedAt,
+		AutoComplete:    inp.AutoComplete,
+		EstimateMinutes: inp.EstimateMinutes,
+		Recurrence:      inp.Recurrence,
 	}
 }
 
 func ConvertTodoToModel(inp dto.TodoItem) model.TodoItem {
 	return model.TodoItem{
-		ID:          inp.ID,
-		Title:       inp.Title,
-		Description: inp.Description,
-		Date:        inp.Date,
-		Status:      model.TodoStatus(inp.Status),
-		CreatedAt:   inp.CreatedAt,
-		UpdatedAt:   inp.UpdatedAt,
+		ID:              inp.ID,
+		Title:           inp.Title,
+		Description:     inp.Description,
+		Date:            inp.Date,
+		DueAt:           inp.DueAt,
+		TimeZone:        inp.TimeZone,
+		Status:          model.TodoStatus(inp.Status),
+		ProjectID:       inp.ProjectID,
+		CompletedAt:     inp.CompletedAt,
+		AutoComplete:    inp.AutoComplete,
+		EstimateMinutes: inp.EstimateMinutes,
+		TrackedSeconds:  inp.TrackedSeconds,
+		Recurrence:      inp.Recurrence,
+		Blocked:         inp.Blocked,
+		CommentCount:    inp.CommentCount,
+		Position:        inp.Position,
+		CreatedAt:       inp.CreatedAt,
+		UpdatedAt:       inp.UpdatedAt,
 	}
 }
 
@@ -36,3 +54,23 @@ func ConvertTodoToModels(inp []dto.TodoItem) []model.TodoItem {
 
 	return res
 }
+
+func ConvertDependencyToDTO(inp model.TodoDependency) dto.TodoDependency {
+	return dto.TodoDependency{
+		TodoID:    inp.TodoID,
+		BlockerID: inp.BlockerID,
+	}
+}
+
+func ConvertDependenciesToModels(inp []dto.TodoDependency) []model.TodoDependency {
+	res := make([]model.TodoDependency, len(inp))
+
+	for i, v := range inp {
+		res[i] = model.TodoDependency{
+			TodoID:    v.TodoID,
+			BlockerID: v.BlockerID,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"todo-list/internal/domain/dto"
+	"todo-list/internal/domain/model"
+)
+
+func ConvertUserToModel(inp dto.User) model.User {
+	res := model.User{
+		ID:        inp.ID,
+		Email:     inp.Email,
+		Name:      inp.Name,
+		TimeZone:  inp.TimeZone,
+		CreatedAt: inp.CreatedAt,
+	}
+	if inp.Username != nil {
+		res.Username = *inp.Username
+	}
+
+	return res
+}
+
+func ConvertUserToDTO(inp model.User) dto.User {
+	res := dto.User{
+		ID:       inp.ID,
+		Email:    inp.Email,
+		Name:     inp.Name,
+		TimeZone: inp.TimeZone,
+	}
+	if inp.Username != "" {
+		res.Username = &inp.Username
+	}
+
+	return res
+}
+
+func ConvertUsersToModels(inp []dto.User) []model.User {
+	res := make([]model.User, len(inp))
+
+	for i, v := range inp {
+		res[i] = ConvertUserToModel(v)
+	}
+
+	return res
+}
@@ -0,0 +1,128 @@
+// Package rank generates lexicographic ranks for manual ordering. A rank is a string of
+// base 36 digits read as a fraction in [0, 1), so a new rank fits between any two ranks
+// and moving an item only changes the rank of that item.
+//
+// Ranks never end with the zero digit, otherwise "a" and "a0" would be different ranks
+// of the same value with no room between them. Ranks must be compared bytewise
+// (COLLATE "C" in postgres).
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	base     = len(alphabet)
+)
+
+var ErrOrder = errors.New("rank: lower bound is not less than upper bound")
+
+func digit(c byte) (int, bool) {
+	i := strings.IndexByte(alphabet, c)
+	return i, i >= 0
+}
+
+// Valid reports whether s is a well-formed rank.
+func Valid(s string) bool {
+	if s == "" || s[len(s)-1] == alphabet[0] {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if _, ok := digit(s[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a rank strictly between a and b. An empty a means the beginning
+// and an empty b the end of the list. ErrOrder is returned unless a < b, this
+// includes equal ranks of items inserted concurrently.
+func Between(a, b string) (string, error) {
+	if a != "" && !Valid(a) {
+		return "", fmt.Errorf("rank: invalid rank %q", a)
+	}
+	if b != "" && !Valid(b) {
+		return "", fmt.Errorf("rank: invalid rank %q", b)
+	}
+	if b != "" && a >= b {
+		return "", ErrOrder
+	}
+	return midpoint(a, b), nil
+}
+
+// midpoint expects a < b, b == "" stands for 1.
+func midpoint(a, b string) string {
+	if b != "" {
+		// skip the common prefix, a is padded with zero digits
+		n := 0
+		for n < len(b) && at(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	da, _ := digit(at(a, 0))
+	db := base
+	if b != "" {
+		db, _ = digit(b[0])
+	}
+
+	if db-da > 1 {
+		return string(alphabet[(da+db)/2])
+	}
+
+	// adjacent first digits
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(alphabet[da]) + midpoint(rest, "")
+}
+
+func at(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return alphabet[0]
+}
+
+// Spread returns n increasing ranks of equal length evenly distributed over the range,
+// it is used to rebalance ranks grown too long.
+func Spread(n int) []string {
+	// one digit more than needed leaves room for inserts between neighbours
+	width := 2
+	for capacity := base; capacity <= n; capacity *= base {
+		width++
+	}
+
+	res := make([]string, n)
+	step := 1
+	for i := 0; i < width; i++ {
+		step *= base
+	}
+	step /= n + 1
+
+	buf := make([]byte, width)
+	for i := range res {
+		v := (i + 1) * step
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = alphabet[v%base]
+			v /= base
+		}
+		res[i] = strings.TrimRight(string(buf), alphabet[:1])
+	}
+	return res
+}
@@ -0,0 +1,99 @@
+package rank
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"", "01"},
+		{"z", ""},
+		{"zzz", ""},
+		{"1", "2"},
+		{"1", "11"},
+		{"a", "b"},
+		{"a1", "a2"},
+		{"az", "b"},
+		{"0001", "0002"},
+	} {
+		res, err := Between(tc.a, tc.b)
+		require.NoError(t, err)
+		require.True(t, Valid(res), "%q", res)
+		require.Less(t, tc.a, res)
+		if tc.b != "" {
+			require.Less(t, res, tc.b)
+		}
+	}
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		_, err := Between("b", "a")
+		require.ErrorIs(t, err, ErrOrder)
+
+		_, err = Between("a", "a")
+		require.ErrorIs(t, err, ErrOrder)
+
+		_, err = Between("a0", "b")
+		require.Error(t, err)
+
+		_, err = Between("A", "")
+		require.Error(t, err)
+	})
+}
+
+func TestBetween_RepeatedInserts(t *testing.T) {
+	ranks := []string{}
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		pos := rnd.Intn(len(ranks) + 1)
+		lo, hi := "", ""
+		if pos > 0 {
+			lo = ranks[pos-1]
+		}
+		if pos < len(ranks) {
+			hi = ranks[pos]
+		}
+
+		r, err := Between(lo, hi)
+		require.NoError(t, err)
+		ranks = append(ranks[:pos], append([]string{r}, ranks[pos:]...)...)
+	}
+
+	require.True(t, sort.StringsAreSorted(ranks))
+	for i := 1; i < len(ranks); i++ {
+		require.NotEqual(t, ranks[i-1], ranks[i])
+	}
+}
+
+func TestBetween_GrowsOnOneSide(t *testing.T) {
+	// inserting at the same place makes ranks longer, rebalancing is needed eventually
+	lo, hi := "", "1"
+	for i := 0; i < 100; i++ {
+		r, err := Between(lo, hi)
+		require.NoError(t, err)
+		hi = r
+	}
+	require.Greater(t, len(hi), 10)
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 35, 36, 1000, 50000} {
+		ranks := Spread(n)
+		require.Len(t, ranks, n)
+		for i, r := range ranks {
+			require.True(t, Valid(r), "%q", r)
+			if i > 0 {
+				require.Less(t, ranks[i-1], r)
+				_, err := Between(ranks[i-1], r)
+				require.NoError(t, err)
+			}
+		}
+	}
+}
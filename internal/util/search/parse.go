@@ -0,0 +1,246 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return "string " + quote(t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+// wordRune reports whether r may be a part of an unquoted word.
+func wordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()":=!<>\`, r)
+}
+
+// lex splits the expression into tokens, the last token is always tokenEOF.
+func lex(s string) ([]token, error) {
+	runes := []rune(s)
+	var res []token
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], i+1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			res = append(res, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			res = append(res, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ':' || r == '=':
+			res = append(res, token{kind: tokenOp, text: string(r), pos: pos})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, Errorf(pos, "unexpected '!', use != or not")
+			}
+			res = append(res, token{kind: tokenOp, text: op, pos: pos})
+			i += len(op)
+		case r == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					if i+1 == len(runes) || runes[i+1] != '"' && runes[i+1] != '\\' {
+						return nil, Errorf(i+1, `invalid escape, only \" and \\ are allowed`)
+					}
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, Errorf(pos, "unterminated string")
+			}
+			res = append(res, token{kind: tokenString, text: b.String(), pos: pos})
+			i++
+		case r == '\\':
+			return nil, Errorf(pos, `unexpected '\', escapes are allowed in quoted strings only`)
+		default:
+			start := i
+			for i < len(runes) && wordRune(runes[i]) {
+				i++
+			}
+			res = append(res, token{kind: tokenWord, text: string(runes[start:i]), pos: pos})
+		}
+	}
+	return append(res, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[0]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[0]
+	if t.kind != tokenEOF {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+// isKeyword reports whether the token is the unquoted keyword kw.
+func isKeyword(t token, kw string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, kw)
+}
+
+// Parse parses the expression, nil is returned for an empty one. Errors are *Error.
+func Parse(s string) (Expr, error) {
+	if n := utf8.RuneCountInString(s); n > MaxLength {
+		return nil, Errorf(MaxLength+1, "expression is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, Errorf(t.pos, "unexpected %s", t.describe())
+	}
+	return e, nil
+}
+
+// or = and { "or" and }
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), Or) {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: Or, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// and = unary { ["and"] unary }
+func (p *parser) and() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case isKeyword(t, And):
+			p.next()
+		case t.kind == tokenEOF, t.kind == tokenRParen, isKeyword(t, Or):
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: And, Left: left, Right: right}
+	}
+}
+
+// unary = "not" unary | "(" or ")" | term
+func (p *parser) unary() (Expr, error) {
+	t := p.peek()
+	if isKeyword(t, "not") || t.kind == tokenLParen {
+		if p.depth == MaxDepth {
+			return nil, Errorf(t.pos, "expression is nested deeper than %d levels", MaxDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	switch {
+	case isKeyword(t, "not"):
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{At: t.pos, X: x}, nil
+	case t.kind == tokenLParen:
+		p.next()
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != tokenRParen {
+			return nil, Errorf(r.pos, "expected ')' to close '(' at position %d, got %s", t.pos, r.describe())
+		}
+		return e, nil
+	}
+	return p.term()
+}
+
+// term = word op value | word | string
+func (p *parser) term() (Expr, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return &Term{Value: t.text, ValuePos: t.pos}, nil
+	case t.kind != tokenWord || keyword(t.text):
+		return nil, Errorf(t.pos, "expected a term, got %s", t.describe())
+	}
+
+	op := p.peek()
+	if op.kind != tokenOp {
+		return &Term{Value: t.text, ValuePos: t.pos}, nil
+	}
+	p.next()
+
+	v := p.next()
+	if v.kind != tokenWord && v.kind != tokenString {
+		return nil, Errorf(v.pos, "expected a value after '%s', got %s", op.text, v.describe())
+	}
+	return &Term{
+		Field:    strings.ToLower(t.text),
+		Op:       op.text,
+		Value:    v.text,
+		FieldPos: t.pos,
+		OpPos:    op.pos,
+		ValuePos: v.pos,
+	}, nil
+}
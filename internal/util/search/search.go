@@ -0,0 +1,157 @@
+// Package search parses the search expressions of todo lists, for example
+//
+//	status:pending and (project:3 or "release notes") and due<2026-11-01
+//
+// An expression is made of terms joined by "and", "or" and "not" (case-insensitive)
+// and grouped by parentheses, adjacent terms are joined by "and". A term is either a
+// bare word or quoted string, or a field followed by one of the operators
+// : = != < <= > >= and a value. Quoted strings use double quotes, \" and \\ escape
+// a quote and a backslash.
+//
+// The package knows nothing about fields, they are checked by the compiler of the
+// expression. Positions are 1-based and count characters, not bytes.
+package search
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxLength is the maximum length of an expression in characters.
+	MaxLength = 1000
+	// MaxDepth is the maximum nesting of parentheses and "not".
+	MaxDepth = 32
+)
+
+// Error is a problem of an expression at a position.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Errorf returns an error at the position pos.
+func Errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expr is a node of a parsed expression: *Binary, *Not or *Term.
+type Expr interface {
+	// Pos returns the position of the first character of the node.
+	Pos() int
+	// String formats the node so that parsing it returns the same tree.
+	String() string
+}
+
+// Operators of a binary node.
+const (
+	And = "and"
+	Or  = "or"
+)
+
+// Binary is a pair of expressions joined by And or Or.
+type Binary struct {
+	Op          string
+	Left, Right Expr
+}
+
+func (b *Binary) Pos() int {
+	return b.Left.Pos()
+}
+
+// String adds only the parentheses needed to keep the tree: "and" binds tighter than
+// "or" and both are left-associative.
+func (b *Binary) String() string {
+	left, right := b.Left.String(), b.Right.String()
+	if l, ok := b.Left.(*Binary); ok && l.Op == Or && b.Op == And {
+		left = "(" + left + ")"
+	}
+	if r, ok := b.Right.(*Binary); ok && (r.Op == b.Op || r.Op == Or) {
+		right = "(" + right + ")"
+	}
+	return left + " " + b.Op + " " + right
+}
+
+// Not negates an expression.
+type Not struct {
+	At int
+	X  Expr
+}
+
+func (n *Not) Pos() int {
+	return n.At
+}
+
+func (n *Not) String() string {
+	if _, ok := n.X.(*Binary); ok {
+		return "not (" + n.X.String() + ")"
+	}
+	return "not " + n.X.String()
+}
+
+// Operators of a term.
+const (
+	OpHas = ":"
+	OpEq  = "="
+	OpNe  = "!="
+	OpLt  = "<"
+	OpLe  = "<="
+	OpGt  = ">"
+	OpGe  = ">="
+)
+
+// Term is a condition on a field. Field and Op are empty for a bare word or string.
+type Term struct {
+	Field    string
+	Op       string
+	Value    string
+	FieldPos int
+	OpPos    int
+	ValuePos int
+}
+
+func (t *Term) Pos() int {
+	if t.Field == "" {
+		return t.ValuePos
+	}
+	return t.FieldPos
+}
+
+func (t *Term) String() string {
+	return t.Field + t.Op + quote(t.Value)
+}
+
+// quote returns the value as is when it reads back as a word, keywords and values
+// with special characters are quoted.
+func quote(v string) string {
+	plain := v != "" && !keyword(v)
+	for _, r := range v {
+		plain = plain && wordRune(r)
+	}
+	if plain {
+		return v
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range v {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func keyword(w string) bool {
+	switch strings.ToLower(w) {
+	case And, Or, "not":
+		return true
+	}
+	return false
+}
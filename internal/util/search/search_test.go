@@ -0,0 +1,125 @@
+package search
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "  ", want: ""},
+		{in: "status:pending", want: "status:pending"},
+		{in: "Status = pending", want: "status=pending"},
+		{in: "milk bread", want: "milk and bread"},
+		{in: `"release notes"`, want: `"release notes"`},
+		{in: `title:"say \"hi\" \\ bye"`, want: `title:"say \"hi\" \\ bye"`},
+		{in: `"and"`, want: `"and"`},
+		{in: "a or b c", want: "a or b and c"},
+		{in: "(a or b) c", want: "(a or b) and c"},
+		{in: "a and (b and c)", want: "a and (b and c)"},
+		{in: "((a))", want: "a"},
+		{in: "NOT a or not (b or c)", want: "not a or not (b or c)"},
+		{in: "due<2026-11-01 due>=2026-10-01 project!=none", want: "due<2026-11-01 and due>=2026-10-01 and project!=none"},
+		{
+			in:   "status:pending and (tag:work or tag:urgent) and due<2026-11-01",
+			want: "status:pending and (tag:work or tag:urgent) and due<2026-11-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := Parse(tt.in)
+			require.NoError(t, err)
+			if tt.want == "" {
+				require.Nil(t, e)
+				return
+			}
+			require.Equal(t, tt.want, e.String())
+		})
+	}
+}
+
+func TestParse_Positions(t *testing.T) {
+	e, err := Parse(`(status:done) "ы" due <= 2026-11-01`)
+	require.NoError(t, err)
+
+	and := e.(*Binary)
+	status := and.Left.(*Binary).Left.(*Term)
+	require.Equal(t, 2, status.FieldPos)
+	require.Equal(t, 8, status.OpPos)
+	require.Equal(t, 9, status.ValuePos)
+	require.Equal(t, 15, and.Left.(*Binary).Right.Pos())
+
+	due := and.Right.(*Term)
+	require.Equal(t, Term{Field: "due", Op: OpLe, Value: "2026-11-01", FieldPos: 19, OpPos: 23, ValuePos: 26}, *due)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{in: "status:", pos: 8, msg: "expected a value after ':', got end of expression"},
+		{in: "(a or b", pos: 8, msg: "expected ')' to close '(' at position 1, got end of expression"},
+		{in: "a or b)", pos: 7, msg: "unexpected ')'"},
+		{in: "a and or b", pos: 7, msg: "expected a term, got 'or'"},
+		{in: "a and", pos: 6, msg: "expected a term, got end of expression"},
+		{in: "ы !a", pos: 3, msg: "unexpected '!', use != or not"},
+		{in: `title:"milk`, pos: 7, msg: "unterminated string"},
+		{in: `"a\n"`, pos: 3, msg: `invalid escape, only \" and \\ are allowed`},
+		{in: `a\b`, pos: 2, msg: `unexpected '\', escapes are allowed in quoted strings only`},
+		{in: "due<<2026-11-01", pos: 5, msg: "expected a value after '<', got '<'"},
+		{in: "()", pos: 2, msg: "expected a term, got ')'"},
+		{in: strings.Repeat("(", MaxDepth+1) + "a", pos: MaxDepth + 1, msg: "expression is nested deeper than 32 levels"},
+		{in: strings.Repeat("a", MaxLength+1), pos: MaxLength + 1, msg: "expression is longer than 1000 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := Parse(tt.in)
+			var e *Error
+			require.True(t, errors.As(err, &e), err)
+			require.Equal(t, tt.pos, e.Pos)
+			require.Equal(t, tt.msg, e.Msg)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{
+		"status:pending and (tag:work or tag:urgent) and due<2026-11-01",
+		`not (title:"a \" b" or "x") y`,
+		"a or b and not c",
+		"due>=today project!=none",
+		`"unterminated`,
+		"((a)",
+		"'; DROP TABLE todos; --",
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		e, err := Parse(s)
+		if err != nil {
+			var perr *Error
+			require.True(t, errors.As(err, &perr), err)
+			require.True(t, perr.Pos >= 1 && perr.Pos <= utf8.RuneCountInString(s)+1, "position %d of %q", perr.Pos, s)
+			return
+		}
+		if e == nil {
+			require.Empty(t, strings.TrimSpace(s))
+			return
+		}
+
+		// the formatted tree parses to the same tree
+		again, err := Parse(e.String())
+		require.NoError(t, err, e.String())
+		require.Equal(t, e.String(), again.String())
+	})
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    prefix VARCHAR(32) NOT NULL UNIQUE,
+    hash VARCHAR NOT NULL,
+    scopes VARCHAR[] NOT NULL,
+    created_at timestamp DEFAULT NOW(),
+    last_used_at timestamp,
+    revoked_at timestamp
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE api_keys;
+-- +goose StatementEnd
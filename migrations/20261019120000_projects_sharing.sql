@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR NOT NULL UNIQUE,
+    name VARCHAR NOT NULL,
+    created_at timestamp DEFAULT NOW()
+);
+
+ALTER TABLE api_keys ADD COLUMN user_id INT REFERENCES users (id) ON DELETE CASCADE;
+
+CREATE TABLE projects (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    created_at timestamp DEFAULT NOW(),
+    updated_at timestamp
+);
+
+CREATE TABLE project_members (
+    project_id INT NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR NOT NULL,
+    created_at timestamp DEFAULT NOW(),
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX project_members_user_id_idx ON project_members (user_id);
+
+CREATE TABLE project_invitations (
+    id SERIAL PRIMARY KEY,
+    project_id INT NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    email VARCHAR NOT NULL,
+    role VARCHAR NOT NULL,
+    status VARCHAR NOT NULL DEFAULT 'pending',
+    invited_by INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at timestamp DEFAULT NOW(),
+    responded_at timestamp
+);
+
+-- only one open invitation per address and project
+CREATE UNIQUE INDEX project_invitations_pending_idx ON project_invitations (project_id, email) WHERE status = 'pending';
+CREATE INDEX project_invitations_email_idx ON project_invitations (email);
+
+ALTER TABLE todos ADD COLUMN project_id INT REFERENCES projects (id) ON DELETE CASCADE;
+
+CREATE INDEX todos_project_id_idx ON todos (project_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE todos DROP COLUMN project_id;
+DROP TABLE project_invitations;
+DROP TABLE project_members;
+DROP TABLE projects;
+ALTER TABLE api_keys DROP COLUMN user_id;
+DROP TABLE users;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE projects
+    ADD COLUMN color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+UPDATE projects SET position = id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE projects
+    DROP COLUMN color,
+    DROP COLUMN archived,
+    DROP COLUMN position;
+-- +goose StatementEnd
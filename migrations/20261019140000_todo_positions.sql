@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ranks are compared bytewise, see internal/util/rank
+ALTER TABLE todos ADD COLUMN position VARCHAR COLLATE "C";
+
+-- hex digits are valid ranks, the trailing 8 keeps them from ending with zero
+UPDATE todos SET position = ranked.position
+FROM (SELECT id, lpad(to_hex(row_number() OVER (ORDER BY id) * 16 + 8), 10, '0') AS position FROM todos) ranked
+WHERE todos.id = ranked.id;
+
+ALTER TABLE todos ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX todos_position_idx ON todos (position, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE todos DROP COLUMN position;
+-- +goose StatementEnd
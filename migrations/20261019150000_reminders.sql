@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reminders (
+    id SERIAL PRIMARY KEY,
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    user_id INT REFERENCES users (id) ON DELETE CASCADE,
+    -- either an absolute time or an offset from the date of the todo
+    remind_at timestamptz,
+    offset_seconds BIGINT,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at timestamptz,
+    sent_at timestamptz,
+    failed_at timestamptz,
+    created_at timestamp DEFAULT NOW(),
+    CHECK ((remind_at IS NULL) <> (offset_seconds IS NULL))
+);
+
+CREATE INDEX reminders_todo_id_idx ON reminders (todo_id);
+-- the scheduler only looks at reminders still to be sent
+CREATE INDEX reminders_pending_idx ON reminders (id) WHERE sent_at IS NULL AND failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reminders;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- existing todos were created with dates in UTC (the DSN forces timezone=UTC), they stay
+-- all-day todos in that zone
+ALTER TABLE todos ADD COLUMN due_at timestamptz;
+ALTER TABLE todos ADD COLUMN time_zone VARCHAR NOT NULL DEFAULT 'UTC';
+
+CREATE INDEX todos_due_at_idx ON todos (due_at);
+
+ALTER TABLE users ADD COLUMN time_zone VARCHAR NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN time_zone;
+
+DROP INDEX todos_due_at_idx;
+ALTER TABLE todos DROP COLUMN time_zone;
+ALTER TABLE todos DROP COLUMN due_at;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE todos SET status = lower(trim(status));
+UPDATE todos SET status = 'completed' WHERE status IN ('complete', 'done');
+UPDATE todos SET status = 'pending'
+    WHERE status NOT IN ('pending', 'in_progress', 'blocked', 'completed', 'cancelled');
+
+ALTER TABLE todos ADD CONSTRAINT todos_status_check
+    CHECK (status IN ('pending', 'in_progress', 'blocked', 'completed', 'cancelled'));
+
+ALTER TABLE todos ADD COLUMN completed_at timestamptz;
+UPDATE todos SET completed_at = COALESCE(updated_at, created_at) WHERE status = 'completed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE todos DROP COLUMN completed_at;
+ALTER TABLE todos DROP CONSTRAINT todos_status_check;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE todo_dependencies (
+    -- todo_id can not be completed before blocker_id is done
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    blocker_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    created_at timestamp DEFAULT NOW(),
+    PRIMARY KEY (todo_id, blocker_id),
+    CHECK (todo_id <> blocker_id)
+);
+
+CREATE INDEX todo_dependencies_blocker_id_idx ON todo_dependencies (blocker_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE todo_dependencies;
+-- +goose StatementEnd
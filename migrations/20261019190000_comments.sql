@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN username TEXT UNIQUE;
+
+CREATE TABLE comments (
+    id SERIAL PRIMARY KEY,
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    -- empty for API keys without a user
+    author_id INT REFERENCES users (id) ON DELETE SET NULL,
+    -- markdown
+    body TEXT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    edited_at timestamptz
+);
+
+CREATE INDEX comments_todo_id_idx ON comments (todo_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE comments;
+ALTER TABLE users DROP COLUMN username;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE attachments (
+    id SERIAL PRIMARY KEY,
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    -- empty for API keys without a user
+    uploader_id INT REFERENCES users (id) ON DELETE SET NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX attachments_todo_id_idx ON attachments (todo_id, id);
+CREATE INDEX attachments_uploader_id_idx ON attachments (uploader_id);
+
+-- blobs of deleted attachments, also of attachments deleted with their todo, wait here to be removed from the storage
+CREATE TABLE attachment_blob_deletions (
+    storage_key TEXT PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE FUNCTION attachments_enqueue_blob_deletion() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key) VALUES (OLD.storage_key) ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER attachments_blob_deletion AFTER DELETE ON attachments
+    FOR EACH ROW EXECUTE FUNCTION attachments_enqueue_blob_deletion();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE attachments;
+DROP FUNCTION attachments_enqueue_blob_deletion();
+DROP TABLE attachment_blob_deletions;
+-- +goose StatementEnd
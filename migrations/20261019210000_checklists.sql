@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todos ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE checklist_items (
+    id SERIAL PRIMARY KEY,
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_at timestamptz,
+    position INT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX checklist_items_todo_id_idx ON checklist_items (todo_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE checklist_items;
+ALTER TABLE todos DROP COLUMN auto_complete;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todos ADD COLUMN estimate_minutes INT CHECK (estimate_minutes > 0);
+
+CREATE TABLE time_entries (
+    id SERIAL PRIMARY KEY,
+    todo_id INT NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    -- empty for API keys without a user
+    user_id INT REFERENCES users (id) ON DELETE SET NULL,
+    started_at timestamptz NOT NULL,
+    -- empty while the timer runs
+    ended_at timestamptz,
+    note TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX time_entries_todo_id_idx ON time_entries (todo_id, started_at);
+CREATE INDEX time_entries_started_at_idx ON time_entries (started_at);
+-- a user runs one timer at a time
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (user_id) WHERE ended_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE time_entries;
+ALTER TABLE todos DROP COLUMN estimate_minutes;
+-- +goose StatementEnd
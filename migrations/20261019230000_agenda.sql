@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- RRULE subset of RFC 5545, empty for todos which do not recur
+ALTER TABLE todos ADD COLUMN recurrence TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX todos_date_idx ON todos (date);
+CREATE INDEX todos_recurring_date_idx ON todos (date) WHERE recurrence <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX todos_recurring_date_idx;
+DROP INDEX todos_date_idx;
+ALTER TABLE todos DROP COLUMN recurrence;
+-- +goose StatementEnd
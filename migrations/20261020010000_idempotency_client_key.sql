@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- stored keys were not bound to a client and can not be attributed to one
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD COLUMN client_key VARCHAR(255) NOT NULL;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (client_key, key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys DROP COLUMN client_key;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
+-- +goose StatementEnd
@@ -0,0 +1,245 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/attachment/interfaces.go
+
+// Package mock_attachment is a generated GoMock package.
+package mock_attachment
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	dto "todo-list/internal/domain/dto"
+	model "todo-list/internal/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockService) CreateAttachment(ctx context.Context, a *model.Attachment, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, a, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockServiceMockRecorder) CreateAttachment(ctx, a, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockService)(nil).CreateAttachment), ctx, a, r)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockService) DeleteAttachment(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockServiceMockRecorder) DeleteAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockService)(nil).DeleteAttachment), ctx, todoID, id)
+}
+
+// ListAttachments mocks base method.
+func (m *MockService) ListAttachments(ctx context.Context, todoID int64) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, todoID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockServiceMockRecorder) ListAttachments(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockService)(nil).ListAttachments), ctx, todoID)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockService) OpenAttachment(ctx context.Context, todoID, id int64) (model.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockServiceMockRecorder) OpenAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockService)(nil).OpenAttachment), ctx, todoID, id)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockRepository) CreateAttachment(ctx context.Context, a *dto.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockRepositoryMockRecorder) CreateAttachment(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockRepository)(nil).CreateAttachment), ctx, a)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockRepository) DeleteAttachment(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockRepositoryMockRecorder) DeleteAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockRepository)(nil).DeleteAttachment), ctx, todoID, id)
+}
+
+// GetAttachment mocks base method.
+func (m *MockRepository) GetAttachment(ctx context.Context, todoID, id int64) (dto.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(dto.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockRepositoryMockRecorder) GetAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockRepository)(nil).GetAttachment), ctx, todoID, id)
+}
+
+// ListAttachments mocks base method.
+func (m *MockRepository) ListAttachments(ctx context.Context, todoID int64) ([]dto.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, todoID)
+	ret0, _ := ret[0].([]dto.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockRepositoryMockRecorder) ListAttachments(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockRepository)(nil).ListAttachments), ctx, todoID)
+}
+
+// UsedBytes mocks base method.
+func (m *MockRepository) UsedBytes(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsedBytes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsedBytes indicates an expected call of UsedBytes.
+func (mr *MockRepositoryMockRecorder) UsedBytes(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsedBytes", reflect.TypeOf((*MockRepository)(nil).UsedBytes), ctx, userID)
+}
+
+// MockTodos is a mock of Todos interface.
+type MockTodos struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodosMockRecorder
+}
+
+// MockTodosMockRecorder is the mock recorder for MockTodos.
+type MockTodosMockRecorder struct {
+	mock *MockTodos
+}
+
+// NewMockTodos creates a new mock instance.
+func NewMockTodos(ctrl *gomock.Controller) *MockTodos {
+	mock := &MockTodos{ctrl: ctrl}
+	mock.recorder = &MockTodosMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodos) EXPECT() *MockTodosMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeTodo mocks base method.
+func (m *MockTodos) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeTodo", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeTodo indicates an expected call of AuthorizeTodo.
+func (mr *MockTodosMockRecorder) AuthorizeTodo(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeTodo", reflect.TypeOf((*MockTodos)(nil).AuthorizeTodo), ctx, id, role)
+}
+
+// GetTodoByID mocks base method.
+func (m *MockTodos) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoByID", ctx, id)
+	ret0, _ := ret[0].(model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoByID indicates an expected call of GetTodoByID.
+func (mr *MockTodosMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodos)(nil).GetTodoByID), ctx, id)
+}
@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/comment/interfaces.go
+
+// Package mock_comment is a generated GoMock package.
+package mock_comment
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "todo-list/internal/domain/dto"
+	model "todo-list/internal/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockService) CreateComment(ctx context.Context, comment *model.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockServiceMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), ctx, comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockService) DeleteComment(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockServiceMockRecorder) DeleteComment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), ctx, todoID, id)
+}
+
+// ListComments mocks base method.
+func (m *MockService) ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) (model.CommentPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", ctx, todoID, filter)
+	ret0, _ := ret[0].(model.CommentPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockServiceMockRecorder) ListComments(ctx, todoID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockService)(nil).ListComments), ctx, todoID, filter)
+}
+
+// UpdateComment mocks base method.
+func (m *MockService) UpdateComment(ctx context.Context, comment *model.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockServiceMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), ctx, comment)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockRepository) CreateComment(ctx context.Context, comment *dto.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockRepositoryMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRepository)(nil).CreateComment), ctx, comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRepository) DeleteComment(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRepositoryMockRecorder) DeleteComment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), ctx, todoID, id)
+}
+
+// FindMentioned mocks base method.
+func (m *MockRepository) FindMentioned(ctx context.Context, usernames []string, projectID *int64) ([]dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMentioned", ctx, usernames, projectID)
+	ret0, _ := ret[0].([]dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMentioned indicates an expected call of FindMentioned.
+func (mr *MockRepositoryMockRecorder) FindMentioned(ctx, usernames, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMentioned", reflect.TypeOf((*MockRepository)(nil).FindMentioned), ctx, usernames, projectID)
+}
+
+// GetComment mocks base method.
+func (m *MockRepository) GetComment(ctx context.Context, todoID, id int64) (dto.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ctx, todoID, id)
+	ret0, _ := ret[0].(dto.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockRepositoryMockRecorder) GetComment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockRepository)(nil).GetComment), ctx, todoID, id)
+}
+
+// ListComments mocks base method.
+func (m *MockRepository) ListComments(ctx context.Context, todoID int64, filter dto.CommentFilter) ([]dto.Comment, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", ctx, todoID, filter)
+	ret0, _ := ret[0].([]dto.Comment)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockRepositoryMockRecorder) ListComments(ctx, todoID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockRepository)(nil).ListComments), ctx, todoID, filter)
+}
+
+// UpdateComment mocks base method.
+func (m *MockRepository) UpdateComment(ctx context.Context, comment *dto.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockRepositoryMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepository)(nil).UpdateComment), ctx, comment)
+}
+
+// MockTodos is a mock of Todos interface.
+type MockTodos struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodosMockRecorder
+}
+
+// MockTodosMockRecorder is the mock recorder for MockTodos.
+type MockTodosMockRecorder struct {
+	mock *MockTodos
+}
+
+// NewMockTodos creates a new mock instance.
+func NewMockTodos(ctrl *gomock.Controller) *MockTodos {
+	mock := &MockTodos{ctrl: ctrl}
+	mock.recorder = &MockTodosMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodos) EXPECT() *MockTodosMockRecorder {
+	return m.recorder
+}
+
+// GetTodoByID mocks base method.
+func (m *MockTodos) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoByID", ctx, id)
+	ret0, _ := ret[0].(model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoByID indicates an expected call of GetTodoByID.
+func (mr *MockTodosMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodos)(nil).GetTodoByID), ctx, id)
+}
@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/reminder/interfaces.go
+
+// Package mock_reminder is a generated GoMock package.
+package mock_reminder
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "todo-list/internal/domain/dto"
+	model "todo-list/internal/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateReminder mocks base method.
+func (m *MockService) CreateReminder(ctx context.Context, reminder *model.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReminder indicates an expected call of CreateReminder.
+func (mr *MockServiceMockRecorder) CreateReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminder", reflect.TypeOf((*MockService)(nil).CreateReminder), ctx, reminder)
+}
+
+// DeleteReminder mocks base method.
+func (m *MockService) DeleteReminder(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminder", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReminder indicates an expected call of DeleteReminder.
+func (mr *MockServiceMockRecorder) DeleteReminder(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminder", reflect.TypeOf((*MockService)(nil).DeleteReminder), ctx, todoID, id)
+}
+
+// ListReminders mocks base method.
+func (m *MockService) ListReminders(ctx context.Context, todoID int64) ([]model.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReminders", ctx, todoID)
+	ret0, _ := ret[0].([]model.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReminders indicates an expected call of ListReminders.
+func (mr *MockServiceMockRecorder) ListReminders(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReminders", reflect.TypeOf((*MockService)(nil).ListReminders), ctx, todoID)
+}
+
+// Notify mocks base method.
+func (m *MockService) Notify(ctx context.Context, due dto.DueReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, due)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockServiceMockRecorder) Notify(ctx, due interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockService)(nil).Notify), ctx, due)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateReminder mocks base method.
+func (m *MockRepository) CreateReminder(ctx context.Context, reminder *dto.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReminder indicates an expected call of CreateReminder.
+func (mr *MockRepositoryMockRecorder) CreateReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminder", reflect.TypeOf((*MockRepository)(nil).CreateReminder), ctx, reminder)
+}
+
+// DeleteReminder mocks base method.
+func (m *MockRepository) DeleteReminder(ctx context.Context, todoID, id, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminder", ctx, todoID, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReminder indicates an expected call of DeleteReminder.
+func (mr *MockRepositoryMockRecorder) DeleteReminder(ctx, todoID, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminder", reflect.TypeOf((*MockRepository)(nil).DeleteReminder), ctx, todoID, id, userID)
+}
+
+// ListReminders mocks base method.
+func (m *MockRepository) ListReminders(ctx context.Context, todoID, userID int64) ([]dto.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReminders", ctx, todoID, userID)
+	ret0, _ := ret[0].([]dto.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReminders indicates an expected call of ListReminders.
+func (mr *MockRepositoryMockRecorder) ListReminders(ctx, todoID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReminders", reflect.TypeOf((*MockRepository)(nil).ListReminders), ctx, todoID, userID)
+}
+
+// MockTodos is a mock of Todos interface.
+type MockTodos struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodosMockRecorder
+}
+
+// MockTodosMockRecorder is the mock recorder for MockTodos.
+type MockTodosMockRecorder struct {
+	mock *MockTodos
+}
+
+// NewMockTodos creates a new mock instance.
+func NewMockTodos(ctrl *gomock.Controller) *MockTodos {
+	mock := &MockTodos{ctrl: ctrl}
+	mock.recorder = &MockTodosMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodos) EXPECT() *MockTodosMockRecorder {
+	return m.recorder
+}
+
+// GetTodoByID mocks base method.
+func (m *MockTodos) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoByID", ctx, id)
+	ret0, _ := ret[0].(model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoByID indicates an expected call of GetTodoByID.
+func (mr *MockTodosMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodos)(nil).GetTodoByID), ctx, id)
+}
@@ -0,0 +1,286 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/savedfilter/interfaces.go
+
+// Package mock_savedfilter is a generated GoMock package.
+package mock_savedfilter
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "todo-list/internal/domain/dto"
+	model "todo-list/internal/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateFilter mocks base method.
+func (m *MockService) CreateFilter(ctx context.Context, filter *model.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFilter indicates an expected call of CreateFilter.
+func (mr *MockServiceMockRecorder) CreateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilter", reflect.TypeOf((*MockService)(nil).CreateFilter), ctx, filter)
+}
+
+// DeleteFilter mocks base method.
+func (m *MockService) DeleteFilter(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilter", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilter indicates an expected call of DeleteFilter.
+func (mr *MockServiceMockRecorder) DeleteFilter(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilter", reflect.TypeOf((*MockService)(nil).DeleteFilter), ctx, id)
+}
+
+// GetFilter mocks base method.
+func (m *MockService) GetFilter(ctx context.Context, id int64) (model.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilter", ctx, id)
+	ret0, _ := ret[0].(model.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilter indicates an expected call of GetFilter.
+func (mr *MockServiceMockRecorder) GetFilter(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilter", reflect.TypeOf((*MockService)(nil).GetFilter), ctx, id)
+}
+
+// ListFilters mocks base method.
+func (m *MockService) ListFilters(ctx context.Context) ([]model.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilters", ctx)
+	ret0, _ := ret[0].([]model.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFilters indicates an expected call of ListFilters.
+func (mr *MockServiceMockRecorder) ListFilters(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilters", reflect.TypeOf((*MockService)(nil).ListFilters), ctx)
+}
+
+// ListSmartLists mocks base method.
+func (m *MockService) ListSmartLists(ctx context.Context) []model.SmartList {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSmartLists", ctx)
+	ret0, _ := ret[0].([]model.SmartList)
+	return ret0
+}
+
+// ListSmartLists indicates an expected call of ListSmartLists.
+func (mr *MockServiceMockRecorder) ListSmartLists(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSmartLists", reflect.TypeOf((*MockService)(nil).ListSmartLists), ctx)
+}
+
+// RunFilter mocks base method.
+func (m *MockService) RunFilter(ctx context.Context, id int64, page dto.TodoPage) (model.TodoPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunFilter", ctx, id, page)
+	ret0, _ := ret[0].(model.TodoPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunFilter indicates an expected call of RunFilter.
+func (mr *MockServiceMockRecorder) RunFilter(ctx, id, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunFilter", reflect.TypeOf((*MockService)(nil).RunFilter), ctx, id, page)
+}
+
+// RunSmartList mocks base method.
+func (m *MockService) RunSmartList(ctx context.Context, key string, page dto.TodoPage) (model.TodoPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSmartList", ctx, key, page)
+	ret0, _ := ret[0].(model.TodoPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunSmartList indicates an expected call of RunSmartList.
+func (mr *MockServiceMockRecorder) RunSmartList(ctx, key, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSmartList", reflect.TypeOf((*MockService)(nil).RunSmartList), ctx, key, page)
+}
+
+// UpdateFilter mocks base method.
+func (m *MockService) UpdateFilter(ctx context.Context, filter *model.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFilter indicates an expected call of UpdateFilter.
+func (mr *MockServiceMockRecorder) UpdateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilter", reflect.TypeOf((*MockService)(nil).UpdateFilter), ctx, filter)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFilter mocks base method.
+func (m *MockRepository) CreateFilter(ctx context.Context, filter *dto.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFilter indicates an expected call of CreateFilter.
+func (mr *MockRepositoryMockRecorder) CreateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilter", reflect.TypeOf((*MockRepository)(nil).CreateFilter), ctx, filter)
+}
+
+// DeleteFilter mocks base method.
+func (m *MockRepository) DeleteFilter(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilter", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilter indicates an expected call of DeleteFilter.
+func (mr *MockRepositoryMockRecorder) DeleteFilter(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilter", reflect.TypeOf((*MockRepository)(nil).DeleteFilter), ctx, userID, id)
+}
+
+// GetFilter mocks base method.
+func (m *MockRepository) GetFilter(ctx context.Context, userID, id int64) (dto.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilter", ctx, userID, id)
+	ret0, _ := ret[0].(dto.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilter indicates an expected call of GetFilter.
+func (mr *MockRepositoryMockRecorder) GetFilter(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilter", reflect.TypeOf((*MockRepository)(nil).GetFilter), ctx, userID, id)
+}
+
+// ListFilters mocks base method.
+func (m *MockRepository) ListFilters(ctx context.Context, userID int64) ([]dto.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilters", ctx, userID)
+	ret0, _ := ret[0].([]dto.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFilters indicates an expected call of ListFilters.
+func (mr *MockRepositoryMockRecorder) ListFilters(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilters", reflect.TypeOf((*MockRepository)(nil).ListFilters), ctx, userID)
+}
+
+// UpdateFilter mocks base method.
+func (m *MockRepository) UpdateFilter(ctx context.Context, filter *dto.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFilter indicates an expected call of UpdateFilter.
+func (mr *MockRepositoryMockRecorder) UpdateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilter", reflect.TypeOf((*MockRepository)(nil).UpdateFilter), ctx, filter)
+}
+
+// MockTodos is a mock of Todos interface.
+type MockTodos struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodosMockRecorder
+}
+
+// MockTodosMockRecorder is the mock recorder for MockTodos.
+type MockTodosMockRecorder struct {
+	mock *MockTodos
+}
+
+// NewMockTodos creates a new mock instance.
+func NewMockTodos(ctrl *gomock.Controller) *MockTodos {
+	mock := &MockTodos{ctrl: ctrl}
+	mock.recorder = &MockTodosMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodos) EXPECT() *MockTodosMockRecorder {
+	return m.recorder
+}
+
+// ListTodos mocks base method.
+func (m *MockTodos) ListTodos(ctx context.Context, filter dto.TodoFilter) (model.TodoPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTodos", ctx, filter)
+	ret0, _ := ret[0].(model.TodoPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTodos indicates an expected call of ListTodos.
+func (mr *MockTodosMockRecorder) ListTodos(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockTodos)(nil).ListTodos), ctx, filter)
+}
@@ -0,0 +1,334 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/timeentry/interfaces.go
+
+// Package mock_timeentry is a generated GoMock package.
+package mock_timeentry
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	dto "todo-list/internal/domain/dto"
+	model "todo-list/internal/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockService) CreateEntry(ctx context.Context, entry *model.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockServiceMockRecorder) CreateEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockService)(nil).CreateEntry), ctx, entry)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockService) DeleteEntry(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockServiceMockRecorder) DeleteEntry(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockService)(nil).DeleteEntry), ctx, todoID, id)
+}
+
+// ListEntries mocks base method.
+func (m *MockService) ListEntries(ctx context.Context, todoID int64) ([]model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, todoID)
+	ret0, _ := ret[0].([]model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockServiceMockRecorder) ListEntries(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockService)(nil).ListEntries), ctx, todoID)
+}
+
+// Report mocks base method.
+func (m *MockService) Report(ctx context.Context, filter dto.TimeReportFilter) (model.TimeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, filter)
+	ret0, _ := ret[0].(model.TimeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockServiceMockRecorder) Report(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockService)(nil).Report), ctx, filter)
+}
+
+// StartTimer mocks base method.
+func (m *MockService) StartTimer(ctx context.Context, todoID int64, note string) (model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, todoID, note)
+	ret0, _ := ret[0].(model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockServiceMockRecorder) StartTimer(ctx, todoID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockService)(nil).StartTimer), ctx, todoID, note)
+}
+
+// StopTimer mocks base method.
+func (m *MockService) StopTimer(ctx context.Context, todoID int64) (model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, todoID)
+	ret0, _ := ret[0].(model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockServiceMockRecorder) StopTimer(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockService)(nil).StopTimer), ctx, todoID)
+}
+
+// UpdateEntry mocks base method.
+func (m *MockService) UpdateEntry(ctx context.Context, todoID, id int64, patch model.TimeEntryPatch) (model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntry", ctx, todoID, id, patch)
+	ret0, _ := ret[0].(model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEntry indicates an expected call of UpdateEntry.
+func (mr *MockServiceMockRecorder) UpdateEntry(ctx, todoID, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockService)(nil).UpdateEntry), ctx, todoID, id, patch)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockRepository) CreateEntry(ctx context.Context, entry *dto.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockRepositoryMockRecorder) CreateEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockRepository)(nil).CreateEntry), ctx, entry)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockRepository) DeleteEntry(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockRepositoryMockRecorder) DeleteEntry(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockRepository)(nil).DeleteEntry), ctx, todoID, id)
+}
+
+// GetEntry mocks base method.
+func (m *MockRepository) GetEntry(ctx context.Context, todoID, id int64) (dto.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, todoID, id)
+	ret0, _ := ret[0].(dto.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockRepositoryMockRecorder) GetEntry(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockRepository)(nil).GetEntry), ctx, todoID, id)
+}
+
+// ListEntries mocks base method.
+func (m *MockRepository) ListEntries(ctx context.Context, todoID int64) ([]dto.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, todoID)
+	ret0, _ := ret[0].([]dto.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockRepositoryMockRecorder) ListEntries(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockRepository)(nil).ListEntries), ctx, todoID)
+}
+
+// Overlapping mocks base method.
+func (m *MockRepository) Overlapping(ctx context.Context, userID int64, start, end time.Time, excludeID int64) (dto.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Overlapping", ctx, userID, start, end, excludeID)
+	ret0, _ := ret[0].(dto.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Overlapping indicates an expected call of Overlapping.
+func (mr *MockRepositoryMockRecorder) Overlapping(ctx, userID, start, end, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Overlapping", reflect.TypeOf((*MockRepository)(nil).Overlapping), ctx, userID, start, end, excludeID)
+}
+
+// Report mocks base method.
+func (m *MockRepository) Report(ctx context.Context, filter dto.TimeReportFilter) ([]dto.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, filter)
+	ret0, _ := ret[0].([]dto.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockRepositoryMockRecorder) Report(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockRepository)(nil).Report), ctx, filter)
+}
+
+// RunningEntry mocks base method.
+func (m *MockRepository) RunningEntry(ctx context.Context, userID *int64) (dto.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunningEntry", ctx, userID)
+	ret0, _ := ret[0].(dto.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunningEntry indicates an expected call of RunningEntry.
+func (mr *MockRepositoryMockRecorder) RunningEntry(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunningEntry", reflect.TypeOf((*MockRepository)(nil).RunningEntry), ctx, userID)
+}
+
+// StopTimer mocks base method.
+func (m *MockRepository) StopTimer(ctx context.Context, todoID int64, userID *int64) (dto.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, todoID, userID)
+	ret0, _ := ret[0].(dto.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockRepositoryMockRecorder) StopTimer(ctx, todoID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockRepository)(nil).StopTimer), ctx, todoID, userID)
+}
+
+// UpdateEntry mocks base method.
+func (m *MockRepository) UpdateEntry(ctx context.Context, entry *dto.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEntry indicates an expected call of UpdateEntry.
+func (mr *MockRepositoryMockRecorder) UpdateEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockRepository)(nil).UpdateEntry), ctx, entry)
+}
+
+// MockTodos is a mock of Todos interface.
+type MockTodos struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodosMockRecorder
+}
+
+// MockTodosMockRecorder is the mock recorder for MockTodos.
+type MockTodosMockRecorder struct {
+	mock *MockTodos
+}
+
+// NewMockTodos creates a new mock instance.
+func NewMockTodos(ctrl *gomock.Controller) *MockTodos {
+	mock := &MockTodos{ctrl: ctrl}
+	mock.recorder = &MockTodosMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodos) EXPECT() *MockTodosMockRecorder {
+	return m.recorder
+}
+
+// GetTodoByID mocks base method.
+func (m *MockTodos) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoByID", ctx, id)
+	ret0, _ := ret[0].(model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoByID indicates an expected call of GetTodoByID.
+func (mr *MockTodosMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodos)(nil).GetTodoByID), ctx, id)
+}
@@ -7,6 +7,7 @@ package mock_todo
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	dto "todo-list/internal/domain/dto"
 	model "todo-list/internal/domain/model"
 
@@ -36,6 +37,50 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistItem mocks base method.
+func (m *MockService) AddChecklistItem(ctx context.Context, todoID int64, item *model.ChecklistItem) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, todoID, item)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockServiceMockRecorder) AddChecklistItem(ctx, todoID, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockService)(nil).AddChecklistItem), ctx, todoID, item)
+}
+
+// AddDependency mocks base method.
+func (m *MockService) AddDependency(ctx context.Context, id int64, link model.TodoLink) (model.TodoDependencies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, id, link)
+	ret0, _ := ret[0].(model.TodoDependencies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockServiceMockRecorder) AddDependency(ctx, id, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockService)(nil).AddDependency), ctx, id, link)
+}
+
+// AuthorizeTodo mocks base method.
+func (m *MockService) AuthorizeTodo(ctx context.Context, id int64, role model.ProjectRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeTodo", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeTodo indicates an expected call of AuthorizeTodo.
+func (mr *MockServiceMockRecorder) AuthorizeTodo(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeTodo", reflect.TypeOf((*MockService)(nil).AuthorizeTodo), ctx, id, role)
+}
+
 // CreateTodo mocks base method.
 func (m *MockService) CreateTodo(ctx context.Context, item *model.TodoItem) error {
 	m.ctrl.T.Helper()
@@ -50,6 +95,20 @@ func (mr *MockServiceMockRecorder) CreateTodo(ctx, item interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTodo", reflect.TypeOf((*MockService)(nil).CreateTodo), ctx, item)
 }
 
+// DeleteChecklistItem mocks base method.
+func (m *MockService) DeleteChecklistItem(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockServiceMockRecorder) DeleteChecklistItem(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockService)(nil).DeleteChecklistItem), ctx, todoID, id)
+}
+
 // DeleteTodo mocks base method.
 func (m *MockService) DeleteTodo(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -64,6 +123,66 @@ func (mr *MockServiceMockRecorder) DeleteTodo(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockService)(nil).DeleteTodo), ctx, id)
 }
 
+// GetAgenda mocks base method.
+func (m *MockService) GetAgenda(ctx context.Context, filter dto.AgendaFilter) (model.Agenda, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgenda", ctx, filter)
+	ret0, _ := ret[0].(model.Agenda)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgenda indicates an expected call of GetAgenda.
+func (mr *MockServiceMockRecorder) GetAgenda(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgenda", reflect.TypeOf((*MockService)(nil).GetAgenda), ctx, filter)
+}
+
+// GetChecklist mocks base method.
+func (m *MockService) GetChecklist(ctx context.Context, todoID int64) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklist", ctx, todoID)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklist indicates an expected call of GetChecklist.
+func (mr *MockServiceMockRecorder) GetChecklist(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklist", reflect.TypeOf((*MockService)(nil).GetChecklist), ctx, todoID)
+}
+
+// GetSeries mocks base method.
+func (m *MockService) GetSeries(ctx context.Context, filter dto.TodoSeriesFilter) (model.TodoSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", ctx, filter)
+	ret0, _ := ret[0].(model.TodoSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockServiceMockRecorder) GetSeries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockService)(nil).GetSeries), ctx, filter)
+}
+
+// GetStats mocks base method.
+func (m *MockService) GetStats(ctx context.Context, filter dto.TodoFilter) (model.TodoStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, filter)
+	ret0, _ := ret[0].(model.TodoStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockServiceMockRecorder) GetStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockService)(nil).GetStats), ctx, filter)
+}
+
 // GetTodoByID mocks base method.
 func (m *MockService) GetTodoByID(ctx context.Context, id int64) (model.TodoItem, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +198,21 @@ func (mr *MockServiceMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockService)(nil).GetTodoByID), ctx, id)
 }
 
+// ListDependencies mocks base method.
+func (m *MockService) ListDependencies(ctx context.Context, id int64) (model.TodoDependencies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependencies", ctx, id)
+	ret0, _ := ret[0].(model.TodoDependencies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependencies indicates an expected call of ListDependencies.
+func (mr *MockServiceMockRecorder) ListDependencies(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependencies", reflect.TypeOf((*MockService)(nil).ListDependencies), ctx, id)
+}
+
 // ListTodos mocks base method.
 func (m *MockService) ListTodos(ctx context.Context, filter dto.TodoFilter) (model.TodoPagination, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +228,108 @@ func (mr *MockServiceMockRecorder) ListTodos(ctx, filter interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockService)(nil).ListTodos), ctx, filter)
 }
 
+// MoveTodo mocks base method.
+func (m *MockService) MoveTodo(ctx context.Context, id int64, move model.TodoMove) (model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTodo", ctx, id, move)
+	ret0, _ := ret[0].(model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTodo indicates an expected call of MoveTodo.
+func (mr *MockServiceMockRecorder) MoveTodo(ctx, id, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTodo", reflect.TypeOf((*MockService)(nil).MoveTodo), ctx, id, move)
+}
+
+// OrderTodos mocks base method.
+func (m *MockService) OrderTodos(ctx context.Context, ids []int64) ([]model.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderTodos", ctx, ids)
+	ret0, _ := ret[0].([]model.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderTodos indicates an expected call of OrderTodos.
+func (mr *MockServiceMockRecorder) OrderTodos(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderTodos", reflect.TypeOf((*MockService)(nil).OrderTodos), ctx, ids)
+}
+
+// PatchTodo mocks base method.
+func (m *MockService) PatchTodo(ctx context.Context, item *model.TodoItem, fields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTodo", ctx, item, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTodo indicates an expected call of PatchTodo.
+func (mr *MockServiceMockRecorder) PatchTodo(ctx, item, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTodo", reflect.TypeOf((*MockService)(nil).PatchTodo), ctx, item, fields)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockService) RemoveDependency(ctx context.Context, id, blockerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, id, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockServiceMockRecorder) RemoveDependency(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockService)(nil).RemoveDependency), ctx, id, blockerID)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MockService) ReorderChecklist(ctx context.Context, todoID int64, order model.ChecklistOrder) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, todoID, order)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MockServiceMockRecorder) ReorderChecklist(ctx, todoID, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MockService)(nil).ReorderChecklist), ctx, todoID, order)
+}
+
+// ReplaceTodo mocks base method.
+func (m *MockService) ReplaceTodo(ctx context.Context, item *model.TodoItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTodo", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTodo indicates an expected call of ReplaceTodo.
+func (mr *MockServiceMockRecorder) ReplaceTodo(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTodo", reflect.TypeOf((*MockService)(nil).ReplaceTodo), ctx, item)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockService) UpdateChecklistItem(ctx context.Context, todoID, id int64, patch model.ChecklistItemPatch) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, todoID, id, patch)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockServiceMockRecorder) UpdateChecklistItem(ctx, todoID, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockService)(nil).UpdateChecklistItem), ctx, todoID, id, patch)
+}
+
 // UpdateTodo mocks base method.
 func (m *MockService) UpdateTodo(ctx context.Context, item *model.TodoItem) error {
 	m.ctrl.T.Helper()
@@ -131,6 +367,49 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistItem mocks base method.
+func (m *MockRepository) AddChecklistItem(ctx context.Context, item *dto.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockRepositoryMockRecorder) AddChecklistItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockRepository)(nil).AddChecklistItem), ctx, item)
+}
+
+// AddDependency mocks base method.
+func (m *MockRepository) AddDependency(ctx context.Context, dep dto.TodoDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, dep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockRepositoryMockRecorder) AddDependency(ctx, dep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockRepository)(nil).AddDependency), ctx, dep)
+}
+
+// AgendaTodos mocks base method.
+func (m *MockRepository) AgendaTodos(ctx context.Context, filter dto.TodoFilter, from, to time.Time) ([]dto.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AgendaTodos", ctx, filter, from, to)
+	ret0, _ := ret[0].([]dto.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AgendaTodos indicates an expected call of AgendaTodos.
+func (mr *MockRepositoryMockRecorder) AgendaTodos(ctx, filter, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgendaTodos", reflect.TypeOf((*MockRepository)(nil).AgendaTodos), ctx, filter, from, to)
+}
+
 // CreateTodo mocks base method.
 func (m *MockRepository) CreateTodo(ctx context.Context, item *dto.TodoItem) error {
 	m.ctrl.T.Helper()
@@ -145,6 +424,34 @@ func (mr *MockRepositoryMockRecorder) CreateTodo(ctx, item interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTodo", reflect.TypeOf((*MockRepository)(nil).CreateTodo), ctx, item)
 }
 
+// DeleteChecklistItem mocks base method.
+func (m *MockRepository) DeleteChecklistItem(ctx context.Context, todoID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockRepositoryMockRecorder) DeleteChecklistItem(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockRepository)(nil).DeleteChecklistItem), ctx, todoID, id)
+}
+
+// DeleteDependency mocks base method.
+func (m *MockRepository) DeleteDependency(ctx context.Context, todoID, blockerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDependency", ctx, todoID, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDependency indicates an expected call of DeleteDependency.
+func (mr *MockRepositoryMockRecorder) DeleteDependency(ctx, todoID, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDependency", reflect.TypeOf((*MockRepository)(nil).DeleteDependency), ctx, todoID, blockerID)
+}
+
 // DeleteTodo mocks base method.
 func (m *MockRepository) DeleteTodo(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -159,6 +466,21 @@ func (mr *MockRepositoryMockRecorder) DeleteTodo(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockRepository)(nil).DeleteTodo), ctx, id)
 }
 
+// GetMemberRole mocks base method.
+func (m *MockRepository) GetMemberRole(ctx context.Context, projectID, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", ctx, projectID, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockRepositoryMockRecorder) GetMemberRole(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockRepository)(nil).GetMemberRole), ctx, projectID, userID)
+}
+
 // GetTodoByID mocks base method.
 func (m *MockRepository) GetTodoByID(ctx context.Context, id int64) (dto.TodoItem, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +496,67 @@ func (mr *MockRepositoryMockRecorder) GetTodoByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockRepository)(nil).GetTodoByID), ctx, id)
 }
 
+// GetTodosByIDs mocks base method.
+func (m *MockRepository) GetTodosByIDs(ctx context.Context, ids []int64) ([]dto.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodosByIDs", ctx, ids)
+	ret0, _ := ret[0].([]dto.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodosByIDs indicates an expected call of GetTodosByIDs.
+func (mr *MockRepositoryMockRecorder) GetTodosByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodosByIDs", reflect.TypeOf((*MockRepository)(nil).GetTodosByIDs), ctx, ids)
+}
+
+// ListChecklist mocks base method.
+func (m *MockRepository) ListChecklist(ctx context.Context, todoID int64) ([]dto.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChecklist", ctx, todoID)
+	ret0, _ := ret[0].([]dto.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChecklist indicates an expected call of ListChecklist.
+func (mr *MockRepositoryMockRecorder) ListChecklist(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecklist", reflect.TypeOf((*MockRepository)(nil).ListChecklist), ctx, todoID)
+}
+
+// ListDependencies mocks base method.
+func (m *MockRepository) ListDependencies(ctx context.Context, ids []int64) ([]dto.TodoDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependencies", ctx, ids)
+	ret0, _ := ret[0].([]dto.TodoDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependencies indicates an expected call of ListDependencies.
+func (mr *MockRepositoryMockRecorder) ListDependencies(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependencies", reflect.TypeOf((*MockRepository)(nil).ListDependencies), ctx, ids)
+}
+
+// ListLinkedTodos mocks base method.
+func (m *MockRepository) ListLinkedTodos(ctx context.Context, id int64) ([]dto.TodoItem, []dto.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinkedTodos", ctx, id)
+	ret0, _ := ret[0].([]dto.TodoItem)
+	ret1, _ := ret[1].([]dto.TodoItem)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLinkedTodos indicates an expected call of ListLinkedTodos.
+func (mr *MockRepositoryMockRecorder) ListLinkedTodos(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinkedTodos", reflect.TypeOf((*MockRepository)(nil).ListLinkedTodos), ctx, id)
+}
+
 // ListTodos mocks base method.
 func (m *MockRepository) ListTodos(ctx context.Context, filter dto.TodoFilter) ([]dto.TodoItem, int64, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +573,78 @@ func (mr *MockRepositoryMockRecorder) ListTodos(ctx, filter interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockRepository)(nil).ListTodos), ctx, filter)
 }
 
+// MoveTodo mocks base method.
+func (m *MockRepository) MoveTodo(ctx context.Context, id, targetID int64, after bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTodo", ctx, id, targetID, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTodo indicates an expected call of MoveTodo.
+func (mr *MockRepositoryMockRecorder) MoveTodo(ctx, id, targetID, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTodo", reflect.TypeOf((*MockRepository)(nil).MoveTodo), ctx, id, targetID, after)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MockRepository) ReorderChecklist(ctx context.Context, todoID int64, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, todoID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MockRepositoryMockRecorder) ReorderChecklist(ctx, todoID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MockRepository)(nil).ReorderChecklist), ctx, todoID, ids)
+}
+
+// TodoSeries mocks base method.
+func (m *MockRepository) TodoSeries(ctx context.Context, filter dto.TodoSeriesFilter, start, end time.Time) ([]dto.TodoSeriesCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TodoSeries", ctx, filter, start, end)
+	ret0, _ := ret[0].([]dto.TodoSeriesCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TodoSeries indicates an expected call of TodoSeries.
+func (mr *MockRepositoryMockRecorder) TodoSeries(ctx, filter, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TodoSeries", reflect.TypeOf((*MockRepository)(nil).TodoSeries), ctx, filter, start, end)
+}
+
+// TodoStats mocks base method.
+func (m *MockRepository) TodoStats(ctx context.Context, filter dto.TodoFilter) ([]dto.TodoStatusStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TodoStats", ctx, filter)
+	ret0, _ := ret[0].([]dto.TodoStatusStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TodoStats indicates an expected call of TodoStats.
+func (mr *MockRepositoryMockRecorder) TodoStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TodoStats", reflect.TypeOf((*MockRepository)(nil).TodoStats), ctx, filter)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockRepository) UpdateChecklistItem(ctx context.Context, item *dto.ChecklistItem, updatedFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, item, updatedFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockRepositoryMockRecorder) UpdateChecklistItem(ctx, item, updatedFields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockRepository)(nil).UpdateChecklistItem), ctx, item, updatedFields)
+}
+
 // UpdateTodo mocks base method.
 func (m *MockRepository) UpdateTodo(ctx context.Context, item *dto.TodoItem, updatedFields []string) error {
 	m.ctrl.T.Helper()